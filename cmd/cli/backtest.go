@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/meoraeng/lotto_simulator/internal/formatter"
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/backtest"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/history"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+)
+
+// 과거 공식 당첨금과 분배 엔진 결과 비교
+// 사용법: cli backtest -history draws.csv [-profile 이름] [-alloc 7500,1250,1250,0,0] [-optimize]
+func runBacktest(args []string) error {
+	fs := flag.NewFlagSet("backtest", flag.ContinueOnError)
+	historyPath := fs.String("history", "", "판매액/당첨자 수/당첨금이 포함된 과거 기록 파일 (csv/json)")
+	gameID := fs.String("game", lotto.DefaultGameID, "게임 ID (회차 식별자 접두어)")
+	profileName := fs.String("profile", profile.KR645Parimutuel, "상한/라운딩/롤다운 규칙을 가져올 프로필")
+	profilesPath := fs.String("profiles", "", "사용자 정의 프로필 파일 (yaml/json)")
+	allocFlag := fs.String("alloc", "", "1~5등 배정 비율(bps, 쉼표 구분). 비우면 프로필 값")
+	optimize := fs.Bool("optimize", false, "1~3등 배정 비율을 격자 탐색해 오차가 가장 작은 조합 출력")
+	top := fs.Int("top", 5, "-optimize 시 출력할 후보 수")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *historyPath == "" {
+		return fmt.Errorf("-history 경로를 입력해 주세요")
+	}
+	records, err := history.Load(*historyPath, *gameID)
+	if err != nil {
+		return fmt.Errorf("과거 기록을 불러오지 못했습니다: %w", err)
+	}
+
+	profiles, err := loadProfiles(*profilesPath)
+	if err != nil {
+		return err
+	}
+	game, err := profiles.Get(*profileName)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := buildBacktestConfig(game, *allocFlag)
+	if err != nil {
+		return err
+	}
+
+	report, err := backtest.Run(cfg, records)
+	if err != nil {
+		return err
+	}
+	printBacktestReport(report)
+
+	if !*optimize {
+		return nil
+	}
+
+	candidates, err := backtest.Optimize(context.Background(), cfg, records, backtest.DefaultGrid(), *top)
+	if err != nil {
+		return err
+	}
+	printBacktestCandidates(candidates)
+	return nil
+}
+
+func buildBacktestConfig(game profile.Profile, allocFlag string) (lotto.SeriesConfig, error) {
+	cfg := game.SeriesConfig()
+	cfg.Mode = lotto.ModeParimutuel
+
+	if allocFlag == "" {
+		return cfg, nil
+	}
+
+	parts := strings.Split(allocFlag, ",")
+	if len(parts) != 5 {
+		return lotto.SeriesConfig{}, fmt.Errorf("배정 비율은 1~5등 5개를 입력해 주세요: %s", allocFlag)
+	}
+
+	ranks := []lotto.Rank{lotto.Rank1, lotto.Rank2, lotto.Rank3, lotto.Rank4, lotto.Rank5}
+	allocs := make([]lotto.Allocation, 0, len(ranks))
+	for i, p := range parts {
+		bps, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || bps < 0 {
+			return lotto.SeriesConfig{}, fmt.Errorf("배정 비율은 0 이상의 정수여야 합니다: %s", p)
+		}
+		allocs = append(allocs, lotto.Allocation{Rank: ranks[i], BasisPoints: bps})
+	}
+	cfg.Allocations = allocs
+	return cfg, nil
+}
+
+func printBacktestReport(report backtest.Report) {
+	fmt.Println("=== 백테스트: 공식 당첨금 vs 분배 엔진 ===")
+	for _, round := range report.Rounds {
+		fmt.Printf("\n%s\n", round.Meta.Label())
+		for _, dev := range round.Ranks {
+			if dev.Winners == 0 {
+				continue
+			}
+			fmt.Printf("  %d등 %d명: 공식 %s원 / 엔진 %s원 (차이 %s원)\n",
+				dev.Rank.Number(), dev.Winners,
+				formatter.Money(dev.Official), formatter.Money(dev.Simulated), formatter.Money(dev.Diff))
+		}
+		fmt.Printf("  회차 오차: %s원 / 누적 오차: %s원\n",
+			formatter.Money(round.AbsError), formatter.Money(round.CumulativeError))
+	}
+
+	if len(report.Skipped) > 0 {
+		fmt.Printf("\n공식 결과가 없어 건너뛴 회차: %v\n", report.Skipped)
+	}
+	fmt.Printf("\n누적 오차: %s원 (공식 총 지급액 대비 %.2f%%)\n",
+		formatter.Money(report.CumulativeError), report.RelativeError*100)
+}
+
+func printBacktestCandidates(candidates []backtest.Candidate) {
+	fmt.Println("\n=== 오차가 가장 작은 배정 비율 ===")
+	for i, c := range candidates {
+		bps := make([]string, 0, len(c.Allocations))
+		for _, a := range c.Allocations {
+			bps = append(bps, strconv.Itoa(a.BasisPoints))
+		}
+		fmt.Printf("%d. [%s] 누적 오차 %s원 (%.2f%%)\n",
+			i+1, strings.Join(bps, ","), formatter.Money(c.CumulativeError), c.RelativeError*100)
+	}
+}
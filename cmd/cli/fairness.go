@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/fairness"
+)
+
+var errFairnessFailed = errors.New("공정성 검정에 실패했습니다")
+
+// 자동 번호 생성기 공정성 검정 (하나라도 실패하면 종료 코드 1)
+// 사용법: cli fairness [-draws 1000000] [-alpha 0.001] [-seed 1]
+func runFairness(args []string) error {
+	fs := flag.NewFlagSet("fairness", flag.ContinueOnError)
+	draws := fs.Int("draws", fairness.DefaultDraws, "뽑을 번호 세트 수")
+	alpha := fs.Float64("alpha", fairness.DefaultAlpha, "유의수준 (p값이 이보다 작으면 실패)")
+	seed := fs.Int64("seed", 0, "난수 시드 (0이면 자동 구매와 같은 전역 난수원)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	src, source := lotto.DefaultSource, "전역 난수원"
+	if *seed != 0 {
+		src, source = rand.New(rand.NewSource(*seed)), fmt.Sprintf("시드 %d", *seed)
+	}
+
+	report, err := fairness.Run(fairness.Auto(src), fairness.Options{Draws: *draws, Alpha: *alpha})
+	if err != nil {
+		return err
+	}
+	printFairnessReport(report, source)
+
+	if failed := report.Failed(); len(failed) > 0 {
+		names := make([]string, 0, len(failed))
+		for _, c := range failed {
+			names = append(names, c.Name)
+		}
+		return fmt.Errorf("%w: %s", errFairnessFailed, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+func printFairnessReport(r fairness.Report, source string) {
+	fmt.Printf("=== 자동 번호 공정성 검정: %s, %d세트, 유의수준 %g ===\n", source, r.Draws, r.Alpha)
+	for _, c := range r.Checks {
+		verdict := "PASS"
+		if !c.Passed {
+			verdict = "FAIL"
+		}
+		stat := ""
+		switch {
+		case c.DF > 0:
+			stat = fmt.Sprintf("χ²=%.2f (자유도 %d)", c.Statistic, c.DF)
+		case strings.HasPrefix(c.Name, "runs-"):
+			stat = fmt.Sprintf("z=%.2f", c.Statistic)
+		}
+		fmt.Printf("[%s] %-11s p=%.4f  %-22s %s\n", verdict, c.Name, c.PValue, stat, c.Detail)
+	}
+
+	if r.Passed() {
+		fmt.Println("\n결과: 통과")
+	} else {
+		fmt.Printf("\n결과: 실패 (%d/%d개 검정)\n", len(r.Failed()), len(r.Checks))
+	}
+}
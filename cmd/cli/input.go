@@ -93,7 +93,7 @@ func readPlayerName(reader *bufio.Reader) string {
 
 func readPurchaseAmount(reader *bufio.Reader) int {
 	for {
-		fmt.Printf("구입금액을 입력해 주세요 (1장당 %d원):\n", lotto.LottoPrice)
+		fmt.Printf("회차당 구입금액을 입력해 주세요 (1장당 %d원, 회차마다 이 금액으로 구매):\n", lotto.LottoPrice)
 		line, _ := reader.ReadString('\n')
 		line = strings.TrimSpace(line)
 
@@ -140,6 +140,34 @@ func readBonusNumber(reader *bufio.Reader, ls *lotto.Lottos) {
 	}
 }
 
+// 가져온 추첨 결과가 있으면 idx번째 회차를, 없으면 당첨/보너스 번호를 입력받는다
+func readRoundDraw(
+	reader *bufio.Reader,
+	imported []lotto.Draw,
+	idx int,
+	calendar lotto.DrawCalendar,
+) lotto.Draw {
+	if idx < len(imported) {
+		d := imported[idx]
+		fmt.Printf("\n=== %s ===\n", d.Meta.Label())
+		fmt.Printf("당첨 번호: %s + 보너스 %d (가져온 기록)\n", formatNumbers(d.WinningNumbers), d.BonusNumber)
+		return d
+	}
+
+	meta := calendar.Round(idx)
+	fmt.Printf("\n=== %s ===\n", meta.Label())
+
+	var winning lotto.Lottos
+	readWinningNumbers(reader, &winning)
+	readBonusNumber(reader, &winning)
+
+	return lotto.Draw{
+		Meta:           meta,
+		WinningNumbers: winning.WinningNumbers,
+		BonusNumber:    winning.BonusNumber,
+	}
+}
+
 func readRoundCount(r *bufio.Reader) int {
 	for {
 		fmt.Println("몇 회차를 시뮬레이션할까요? (기본값 1)")
@@ -2,35 +2,125 @@ package main
 
 import (
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
+	"math/rand"
 	"os"
+	"time"
 
+	"github.com/meoraeng/lotto_simulator/internal/formatter"
 	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/history"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
 )
 
 type playerState struct {
 	Player         lotto.Player
-	PurchaseAmount int
+	PurchaseAmount int // 회차당 구매 금액
+	TotalSpent     int // 지금까지 회차마다 쓴 금액 합계
+}
+
+// 대화형 시뮬레이션 외의 하위 명령 (cli <명령> [플래그])
+var subcommands = map[string]func(args []string) error{
+	"backtest":   runBacktest,
+	"commit":     runCommit,
+	"fairness":   runFairness,
+	"sweep":      runSweep,
+	"population": runPopulation,
+	"split":      runSplit,
+	"stats":      runStats,
+	"verify":     runVerify,
+	"wheel":      runWheel,
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if run, exists := subcommands[os.Args[1]]; exists {
+			if err := run(os.Args[2:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+				printError(err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	gameID := flag.String("game", lotto.DefaultGameID, "게임 ID (회차 식별자 접두어)")
+	firstRound := flag.Int("first-round", 1, "첫 회차 번호")
+	firstDraw := flag.String("first-draw", "", "첫 회차 추첨일 (YYYY-MM-DD 또는 YYYY-MM-DD HH:MM, 매주 반복)")
+	drawsPath := flag.String("draws", "", "과거 추첨 결과 파일 (csv/json). 지정하면 당첨 번호를 입력받지 않고 파일 순서대로 사용")
+	profileName := flag.String("profile", "", "게임 규칙 프로필 이름 (kr-645-fixed, kr-645-parimutuel 등). 지정하면 모드를 묻지 않음")
+	profilesPath := flag.String("profiles", "", "사용자 정의 프로필 파일 (yaml/json)")
+	marketTickets := flag.Int("market", 0, "플레이어 외 일반 구매자의 회차당 기본 티켓 수 (0이면 플레이어 구매분만)")
+	elasticity := flag.Float64("elasticity", 0.5, "-market 사용 시 광고 1등 금액에 대한 판매 탄력성")
+	noise := flag.Float64("noise", 0.05, "-market 사용 시 회차별 판매량 무작위 변동 비율")
+	seed := flag.Int64("seed", 0, "-market 난수 시드 (0이면 현재 시각)")
+	keepNumbers := flag.Bool("keep-numbers", false, "여러 회차에서 첫 회차 번호를 계속 사용 (구매 금액은 매 회차 지불)")
+	commitReveal := flag.Bool("commit-reveal", false, "커밋-공개 추첨: 회차마다 판매 전에 커밋값을 보여 주고, 마감 뒤 시드를 공개해 당첨 번호를 정함")
+	flag.Parse()
+
+	if *commitReveal && *drawsPath != "" {
+		printError(errors.New("-commit-reveal과 -draws는 함께 쓸 수 없습니다"))
+		return
+	}
+
+	market, err := buildMarket(*marketTickets, *elasticity, *noise, *seed)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	profiles, err := loadProfiles(*profilesPath)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	calendar, err := buildDrawCalendar(*gameID, *firstRound, *firstDraw)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	imported, err := loadImportedDraws(*drawsPath, *gameID)
+	if err != nil {
+		printError(fmt.Errorf("과거 추첨 결과를 불러오지 못했습니다: %w", err))
+		return
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Println("=== 로또 시뮬레이터 ===")
 	fmt.Println()
-	// 모드 입력
-	mode := readMode(reader)
+	// 프로필을 지정하지 않았으면 모드를 입력받아 모드별 기본 프로필 사용
+	game, err := selectProfile(reader, profiles, *profileName)
+	if err != nil {
+		printError(err)
+		return
+	}
 	fmt.Println()
 
 	rounds := readRoundCount(reader)
+	if len(imported) > 0 && rounds > len(imported) {
+		fmt.Printf("가져온 추첨 결과가 %d회차뿐이라 %d회차까지만 진행합니다.\n", len(imported), len(imported))
+		rounds = len(imported)
+	}
 	fmt.Println()
 
+	// 커밋-공개 추첨이면 첫 회차 판매 전에 커밋값 공개
+	var sealed *commitreveal.Sealed
+	if *commitReveal {
+		if sealed, err = sealRound(calendar.Round(0)); err != nil {
+			printError(err)
+			return
+		}
+	}
+
 	// 플레이어 입력
 	playerStates := readPlayers(reader)
 	fmt.Println()
 
-	totalSales, players := collectPlayers(playerStates)
-
 	// 회차 간 이월 상태
 	carry := make(map[lotto.Rank]int)
 
@@ -38,17 +128,43 @@ func main() {
 	totalPayouts := make(map[string]int)
 
 	for round := 1; round <= rounds; round++ {
-		fmt.Printf("\n=== %d회차 ===\n", round)
+		// 2회차부터는 같은 회차당 금액으로 다시 구매
+		if round > 1 {
+			if *commitReveal {
+				if sealed, err = sealRound(calendar.Round(round - 1)); err != nil {
+					printError(err)
+					return
+				}
+			}
+			if err := purchaseRound(playerStates, *keepNumbers, round); err != nil {
+				printError(fmt.Errorf("로또 구매 중 오류 발생: %w", err))
+				return
+			}
+		}
+		totalSales, players := collectPlayers(playerStates)
 
-		// 당첨 번호 / 보너스 번호 입력
-		var winning lotto.Lottos
-		readWinningNumbers(reader, &winning)
-		readBonusNumber(reader, &winning)
+		// 당첨 번호 / 보너스 번호 입력 (가져온 기록이 있으면 그대로 사용)
+		var draw lotto.Draw
+		if sealed != nil {
+			draw = revealRound(sealed, calendar.Round(round-1))
+		} else {
+			draw = readRoundDraw(reader, imported, round-1, calendar)
+		}
+		meta := draw.Meta
+		winning := draw.Lottos(nil)
 
 		// 이번 회차 입력값 구성 (판매액 + 이월 상태 포함)
-		base := buildBaseRoundInput(mode, totalSales, carry)
+		base := game.RoundInput(totalSales, nil, carry)
+		base.Meta = meta
 		in := lotto.BuildRoundInput(base, players, winning)
 
+		// 일반 구매자 판매분: 직전 회차 이월이 클수록 판매량 증가
+		if market != nil {
+			var tickets int
+			in, tickets = market.AddTo(in, round-1)
+			fmt.Printf("\n일반 구매자 판매량: %s장 (총 판매액 %s원)\n", formatter.Money(tickets), formatter.Money(in.Sales))
+		}
+
 		// 분배 계산
 		out, err := lotto.CalculateRound(in)
 		if err != nil {
@@ -85,54 +201,114 @@ func main() {
 	fmt.Println("\n시뮬레이션이 종료되었습니다.")
 }
 
-// 전체 판매액 합계와 Player리스트 생성
+// 플래그로 받은 회차 번호/추첨일로 주간 추첨 달력 생성
+func buildDrawCalendar(gameID string, firstRound int, firstDraw string) (lotto.DrawCalendar, error) {
+	if firstRound <= 0 {
+		return lotto.DrawCalendar{}, fmt.Errorf("첫 회차 번호는 1 이상이어야 합니다: %d", firstRound)
+	}
+
+	var drawAt time.Time
+	if firstDraw != "" {
+		parsed, err := lotto.ParseDrawDate(firstDraw)
+		if err != nil {
+			return lotto.DrawCalendar{}, err
+		}
+		drawAt = parsed
+	}
+
+	return lotto.WeeklyCalendar(gameID, firstRound, drawAt), nil
+}
+
+func loadImportedDraws(path string, gameID string) ([]lotto.Draw, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	records, err := history.Load(path, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("파일에 회차가 없습니다")
+	}
+	return history.Draws(records), nil
+}
+
+// 이번 회차 판매액 합계와 Player리스트 생성 (플레이어별 누적 사용 금액도 갱신)
 func collectPlayers(states []playerState) (int, []lotto.Player) {
 	totalSales := 0
 	players := make([]lotto.Player, 0, len(states))
 
-	for _, ps := range states {
-		totalSales += ps.PurchaseAmount
-		players = append(players, ps.Player)
+	for i := range states {
+		states[i].TotalSpent += states[i].PurchaseAmount
+		totalSales += states[i].PurchaseAmount
+		players = append(players, states[i].Player)
 	}
 	return totalSales, players
 }
 
-func buildBaseRoundInput(
-	mode lotto.Mode,
-	sales int,
-	carryIn map[lotto.Rank]int,
-) lotto.RoundInput {
-	allocations := []lotto.Allocation{
-		{Rank: lotto.Rank1, BasisPoints: 7500},
-		{Rank: lotto.Rank2, BasisPoints: 1250},
-		{Rank: lotto.Rank3, BasisPoints: 1250},
-		{Rank: lotto.Rank4, BasisPoints: 0},
-		{Rank: lotto.Rank5, BasisPoints: 0},
-	}
-
-	caps := map[lotto.Rank]int{
-		lotto.Rank1: 2_000_000_000,
-	}
-
-	var fixedPayout map[lotto.Rank]int
-	if mode == lotto.ModeFixedPayout {
-		fixedPayout = map[lotto.Rank]int{
-			lotto.Rank1: lotto.Rank1.Prize(),
-			lotto.Rank2: lotto.Rank2.Prize(),
-			lotto.Rank3: lotto.Rank3.Prize(),
-			lotto.Rank4: lotto.Rank4.Prize(),
-			lotto.Rank5: lotto.Rank5.Prize(),
+// 회차당 금액으로 새 번호 구매. keepNumbers면 번호는 그대로 두고 금액만 다시 지불
+func purchaseRound(states []playerState, keepNumbers bool, round int) error {
+	if keepNumbers {
+		fmt.Printf("\n[%d회차 구매] 이전과 같은 번호로 다시 구매합니다.\n", round)
+		return nil
+	}
+
+	fmt.Printf("\n[%d회차 구매]\n", round)
+	for i := range states {
+		lottos, err := lotto.PurchaseLottos(states[i].PurchaseAmount)
+		if err != nil {
+			return err
+		}
+		states[i].Player.Tickets = lottos.Lottos
+
+		fmt.Printf("%s님 %d개를 구매했습니다.\n", states[i].Player.Name, len(lottos.Lottos))
+		for _, t := range lottos.Lottos {
+			fmt.Println(formatNumbers(t.Numbers))
 		}
 	}
+	return nil
+}
+
+func loadProfiles(path string) (*profile.Registry, error) {
+	profiles := profile.NewRegistry()
+	if path == "" {
+		return profiles, nil
+	}
+	if err := profiles.LoadFile(path); err != nil {
+		return nil, fmt.Errorf("프로필 파일을 불러오지 못했습니다: %w", err)
+	}
+	return profiles, nil
+}
 
-	return lotto.RoundInput{
-		Mode:           mode,
-		Sales:          sales,
-		CarryIn:        carryIn,
-		Allocations:    allocations,
-		CapPerRank:     caps,
-		RoundingUnit:   100,
-		RollDownMethod: lotto.RollDownProportional,
-		FixedPayout:    fixedPayout,
+func selectProfile(reader *bufio.Reader, profiles *profile.Registry, name string) (profile.Profile, error) {
+	if name != "" {
+		game, err := profiles.Get(name)
+		if err != nil {
+			return profile.Profile{}, err
+		}
+		fmt.Printf("프로필: %s (%s)\n", game.Name, game.Description)
+		return game, nil
 	}
+
+	mode := readMode(reader)
+	return profiles.Resolve("", mode)
+}
+
+func buildMarket(tickets int, elasticity, noise float64, seed int64) (*lotto.Market, error) {
+	if tickets < 0 || noise < 0 {
+		return nil, fmt.Errorf("일반 구매자 티켓 수와 변동 비율은 0 이상이어야 합니다")
+	}
+	if tickets == 0 {
+		return nil, nil
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	return &lotto.Market{
+		Demand: lotto.JackpotDemand{Base: tickets, Elasticity: elasticity, Noise: noise, Rng: rng},
+		Rng:    rng,
+	}, nil
 }
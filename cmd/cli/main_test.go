@@ -0,0 +1,67 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+// 회차마다 구매 금액이 판매액과 누적 사용 금액에 더해지는지 검증
+func TestCollectPlayers(t *testing.T) {
+	states := []playerState{
+		{Player: lotto.Player{Name: "a"}, PurchaseAmount: 3_000},
+		{Player: lotto.Player{Name: "b"}, PurchaseAmount: 1_000},
+	}
+
+	for range 3 {
+		sales, players := collectPlayers(states)
+		if sales != 4_000 {
+			t.Errorf("회차 판매액이 예상과 다릅니다. got=%d, want=%d", sales, 4_000)
+		}
+		if len(players) != 2 || players[0].Name != "a" || players[1].Name != "b" {
+			t.Errorf("플레이어 순서가 유지되어야 합니다. got=%+v", players)
+		}
+	}
+
+	if states[0].TotalSpent != 9_000 || states[1].TotalSpent != 3_000 {
+		t.Errorf("누적 사용 금액이 예상과 다릅니다. got=%d, %d, want=%d, %d",
+			states[0].TotalSpent, states[1].TotalSpent, 9_000, 3_000)
+	}
+}
+
+func TestPurchaseRound(t *testing.T) {
+	first := []lotto.Lotto{{Numbers: []int{1, 2, 3, 4, 5, 6}}}
+
+	tests := []struct {
+		name        string
+		keepNumbers bool
+	}{
+		{"같은 번호 유지", true},
+		{"새 번호 구매", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			states := []playerState{{Player: lotto.Player{Name: "a", Tickets: first}, PurchaseAmount: 3_000}}
+
+			if err := purchaseRound(states, tt.keepNumbers, 2); err != nil {
+				t.Fatalf("구매 중 에러가 발생했습니다. err=%v", err)
+			}
+
+			tickets := states[0].Player.Tickets
+			if tt.keepNumbers {
+				if len(tickets) != 1 || !slices.Equal(tickets[0].Numbers, first[0].Numbers) {
+					t.Errorf("번호가 그대로여야 합니다. got=%v", tickets)
+				}
+				return
+			}
+			if len(tickets) != 3 {
+				t.Errorf("구매 금액만큼 새로 사야 합니다. got=%d장, want=%d장", len(tickets), 3)
+			}
+			if states[0].TotalSpent != 0 {
+				t.Errorf("구매는 누적 사용 금액을 바꾸지 않아야 합니다 (collectPlayers가 더함). got=%d", states[0].TotalSpent)
+			}
+		})
+	}
+}
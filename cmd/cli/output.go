@@ -38,6 +38,9 @@ func printRoundReport(in lotto.RoundInput, out lotto.RoundOutput) {
 		return
 	}
 	printer(in, out)
+
+	fmt.Println()
+	fmt.Print(ui.FormatLedger(in, out))
 }
 
 var rankConditionLabels = map[lotto.Rank]string{
@@ -112,7 +115,7 @@ func printPlayerPayouts(states []playerState, payouts map[string]int) {
 func printPlayerTotals(states []playerState, totals map[string]int) {
 	for _, ps := range states {
 		name := ps.Player.Name
-		spent := ps.PurchaseAmount
+		spent := ps.TotalSpent
 		earned := totals[name]
 
 		rate := 0.0
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/formatter"
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/strategy"
+)
+
+// 전략별 가상 플레이어 집단을 여러 회차 돌려 전략끼리 수익률 비교
+// 사용법: cli population -population "fixed=100,jackpot/avoid-popular=50" [-rounds 52] [-profile 이름]
+func runPopulation(args []string) error {
+	fs := flag.NewFlagSet("population", flag.ContinueOnError)
+	spec := fs.String("population", "fixed=100,jackpot=100,martingale=20,fixed/favorite=50,fixed/avoid-popular=50",
+		"금액규칙[/번호규칙]=인원 목록 (금액규칙: "+strings.Join(strategy.BudgetNames(), ", ")+
+			" / 번호규칙: "+strings.Join(strategy.PickerNames(), ", ")+")")
+	rounds := fs.Int("rounds", 52, "시뮬레이션 회차 수")
+	amount := fs.Int("amount", 5000, "회차당 기본 구매 금액")
+	threshold := fs.Int("threshold", 2_000_000_000, "jackpot 규칙: 광고 1등 금액이 이 이상일 때만 구매")
+	maxAmount := fs.Int("max-amount", 64_000, "martingale 규칙: 회차당 상한 (0이면 기본 금액의 1024배)")
+	favorite := fs.String("favorite", "1,7,13,22,34,45", "favorite 규칙: 매 회차 고르는 번호 6개")
+	profileName := fs.String("profile", profile.KR645Parimutuel, "게임 규칙 프로필 이름")
+	profilesPath := fs.String("profiles", "", "사용자 정의 프로필 파일 (yaml/json)")
+	drawsPath := fs.String("draws", "", "과거 추첨 결과 파일 (csv/json). 모자란 회차는 무작위 추첨")
+	gameID := fs.String("game", lotto.DefaultGameID, "게임 ID (회차 식별자 접두어)")
+	marketTickets := fs.Int("market", 0, "플레이어 외 일반 구매자의 회차당 기본 티켓 수")
+	elasticity := fs.Float64("elasticity", 0.5, "-market 사용 시 광고 1등 금액에 대한 판매 탄력성")
+	noise := fs.Float64("noise", 0.05, "-market 사용 시 회차별 판매량 무작위 변동 비율")
+	seed := fs.Int64("seed", 0, "난수 시드 (0이면 현재 시각)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	favoriteNumbers, err := parseNumberList(*favorite)
+	if err != nil {
+		return fmt.Errorf("-favorite 값이 올바르지 않습니다: %w", err)
+	}
+	groups, err := strategy.ParsePopulation(*spec, strategy.Params{
+		Amount:    *amount,
+		Threshold: *threshold,
+		MaxAmount: *maxAmount,
+		Favorite:  favoriteNumbers,
+	})
+	if err != nil {
+		return err
+	}
+
+	profiles, err := loadProfiles(*profilesPath)
+	if err != nil {
+		return err
+	}
+	game, err := profiles.Get(*profileName)
+	if err != nil {
+		return err
+	}
+
+	draws, err := loadImportedDraws(*drawsPath, *gameID)
+	if err != nil {
+		return fmt.Errorf("과거 추첨 결과를 불러오지 못했습니다: %w", err)
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	// 시장 판매량은 별도 난수열을 써서 번호 선택과 섞이지 않게 한다
+	market, err := buildMarket(*marketTickets, *elasticity, *noise, *seed+1)
+	if err != nil {
+		return err
+	}
+
+	sim := strategy.Simulation{
+		Game:   game,
+		Rounds: *rounds,
+		Draws:  draws,
+		Market: market,
+		Rng:    rand.New(rand.NewSource(*seed)),
+	}
+	report, err := strategy.Simulate(sim, strategy.NewPopulation(groups))
+	if err != nil {
+		return err
+	}
+
+	printPopulationReport(game, sim, report)
+	return nil
+}
+
+func parseNumberList(input string) ([]int, error) {
+	parts := strings.Split(input, ",")
+	numbers := make([]int, 0, len(parts))
+	for _, p := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return nil, fmt.Errorf("숫자가 아닌 값입니다: %q", p)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+func printPopulationReport(game profile.Profile, sim strategy.Simulation, report strategy.Report) {
+	fmt.Printf("=== 전략별 플레이어 시뮬레이션 (%s, %d회차) ===\n", game.Name, sim.Rounds)
+
+	rolled, maxJackpot, marketTickets := 0, 0, 0
+	for _, r := range report.Rounds {
+		if r.Output.PaidTotal[lotto.Rank1] == 0 {
+			rolled++
+		}
+		maxJackpot = max(maxJackpot, r.Jackpot)
+		marketTickets += r.MarketTickets
+	}
+	fmt.Printf("1등 미지급 회차: %d / %d, 최대 광고 1등 금액: %s원\n", rolled, len(report.Rounds), formatter.Money(maxJackpot))
+	if sim.Market != nil {
+		fmt.Printf("일반 구매자 티켓: 회차 평균 %s장\n", formatter.Money(marketTickets/len(report.Rounds)))
+	}
+
+	fmt.Println()
+	fmt.Printf("%-26s %6s %10s %16s %16s %8s %8s  %s\n",
+		"전략", "인원", "구매 회차", "구매 금액", "수령 금액", "환급률", "수익 인원", "당첨 티켓 (1~5등)")
+	for _, g := range report.Groups {
+		wins := make([]string, 0, 5)
+		for n := 1; n <= 5; n++ {
+			rank, _ := lotto.RankFromNumber(n)
+			wins = append(wins, strconv.Itoa(g.Wins[rank]))
+		}
+
+		fmt.Printf("%-26s %6d %10d %16s %16s %7.1f%% %8d  %s\n",
+			g.Group, g.Bettors, g.RoundsPlayed, formatter.Money(g.Spent), formatter.Money(g.Won),
+			g.ReturnRate()*100, g.Profitable, strings.Join(wins, "/"))
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/meoraeng/lotto_simulator/internal/formatter"
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/popularity"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+)
+
+// 번호 선호 모델에 따른 1등 당첨금 나눔 분석
+// 사용법: cli split [-model realistic] [-tickets 100000000] [-combos "1,2,3,4,5,6;8,19,27,33,40,44"]
+func runSplit(args []string) error {
+	fs := flag.NewFlagSet("split", flag.ContinueOnError)
+	modelName := fs.String("model", "realistic", "번호 선호 모델 ("+strings.Join(popularity.PresetNames(), ", ")+")")
+	tickets := fs.Int("tickets", 100_000_000, "회차 판매 티켓 수")
+	pool := fs.Int("pool", 0, "1등 풀 금액 (0이면 프로필의 1등 배정 비율과 상한으로 계산)")
+	profileName := fs.String("profile", profile.KR645Parimutuel, "1등 풀 계산에 쓸 프로필")
+	profilesPath := fs.String("profiles", "", "사용자 정의 프로필 파일 (yaml/json)")
+	previous := fs.String("previous", "", "직전 회차 당첨 번호 (쉼표 구분, 재선택 비율에 사용)")
+	combos := fs.String("combos", "1,2,3,4,5,6;3,9,14,22,27,30;8,19,27,33,40,44;32,35,38,40,43,45",
+		"나눔을 비교할 조합 목록 (세미콜론 구분)")
+	samples := fs.Int("samples", 2000, "공정 추첨 표본 수")
+	seed := fs.Int64("seed", 1, "표본 시드")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	model, err := popularity.Preset(*modelName)
+	if err != nil {
+		return err
+	}
+	if *previous != "" {
+		numbers, err := parseNumberList(*previous)
+		if err != nil {
+			return fmt.Errorf("-previous 값이 올바르지 않습니다: %w", err)
+		}
+		last, err := lotto.NewLotto(numbers)
+		if err != nil {
+			return fmt.Errorf("-previous 값이 올바르지 않습니다: %w", err)
+		}
+		model = model.WithPrevious(last.Numbers)
+	}
+
+	if *pool == 0 {
+		profiles, err := loadProfiles(*profilesPath)
+		if err != nil {
+			return err
+		}
+		game, err := profiles.Get(*profileName)
+		if err != nil {
+			return err
+		}
+		*pool = rank1Pool(game, *tickets)
+	}
+
+	picks, err := parseCombos(*combos)
+	if err != nil {
+		return err
+	}
+
+	baseline, err := popularity.SurveyDraws(popularity.Uniform(), rand.New(rand.NewSource(*seed)), *tickets, *pool, *samples)
+	if err != nil {
+		return err
+	}
+	survey, err := popularity.SurveyDraws(model, rand.New(rand.NewSource(*seed)), *tickets, *pool, *samples)
+	if err != nil {
+		return err
+	}
+
+	printSplitReport(baseline, survey, model, picks)
+	return nil
+}
+
+// 판매액 × 1등 배정 비율 (상한 적용). 고정 상금 프로필이면 1등 상금
+func rank1Pool(game profile.Profile, tickets int) int {
+	if game.Mode == lotto.ModeFixedPayout {
+		return game.FixedPayout[lotto.Rank1]
+	}
+
+	pool := 0
+	for _, a := range game.Allocations {
+		if a.Rank == lotto.Rank1 {
+			pool = tickets * lotto.LottoPrice * a.BasisPoints / lotto.BasisPoints
+		}
+	}
+	if cap1, hasCap := game.CapPerRank[lotto.Rank1]; hasCap {
+		pool = min(pool, cap1)
+	}
+	return pool
+}
+
+func parseCombos(input string) ([][]int, error) {
+	var combos [][]int
+	for _, part := range strings.Split(input, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		numbers, err := parseNumberList(part)
+		if err != nil {
+			return nil, err
+		}
+		ticket, err := lotto.NewLotto(numbers)
+		if err != nil {
+			return nil, fmt.Errorf("조합 %q: %w", part, err)
+		}
+		combos = append(combos, ticket.Numbers)
+	}
+	return combos, nil
+}
+
+func printSplitReport(baseline, survey popularity.Survey, model popularity.Model, picks [][]int) {
+	fmt.Printf("=== 1등 당첨금 나눔 분석 (판매 %s장, 1등 풀 %s원, 표본 %d회) ===\n",
+		formatter.Money(survey.Tickets), formatter.Money(survey.Pool), survey.Samples)
+	fmt.Printf("%-12s %14s %10s %20s %14s\n", "모델", "1등 기대 수", "이월 확률", "1인당 당첨금 기대값", "최대 쏠림")
+	for _, s := range []popularity.Survey{baseline, survey} {
+		fmt.Printf("%-12s %14.3f %9.1f%% %20s %14.2f\n",
+			s.Model, s.MeanWinners, s.RolloverRate*100, formatter.Money(s.PrizePerWinner), s.MaxWinners)
+	}
+
+	if len(picks) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Printf("조합별 나눔 (%s 모델)\n", model.Name)
+	fmt.Printf("%-24s %10s %14s %10s %18s\n", "조합", "인기 배수", "같은 조합 수", "기대 비율", "1등 시 기대 당첨금")
+	for _, combo := range picks {
+		split := popularity.Analyze(model, survey.Tickets, survey.Pool, combo)
+		fmt.Printf("%-24s %10.2f %14.2f %9.1f%% %18s\n",
+			formatNumbers(combo), split.Popularity, split.CoWinners, split.ExpectedShare*100, formatter.Money(split.ExpectedPrize))
+	}
+}
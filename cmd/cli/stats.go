@@ -0,0 +1,134 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/drawstats"
+)
+
+// 추첨 기록 번호 통계 (빈도, 미출현 간격, 쌍/3개 조합, 합/홀짝 분포, 균등성 검정)
+// 사용법: cli stats -draws draws.csv | cli stats -random 1000 [-seed 1]
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	drawsPath := fs.String("draws", "", "과거 추첨 결과 파일 (csv/json)")
+	gameID := fs.String("game", lotto.DefaultGameID, "게임 ID (회차 식별자 접두어)")
+	random := fs.Int("random", 0, "파일 대신 난수로 만든 추첨 회차 수 (난수 생성기 점검용)")
+	seed := fs.Int64("seed", 0, "-random 난수 시드 (0이면 현재 시각)")
+	bonus := fs.Bool("bonus", false, "번호별 빈도/간격에 보너스 번호 포함")
+	top := fs.Int("top", 10, "순위 목록 길이")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	draws, source, err := loadStatsDraws(*drawsPath, *gameID, *random, *seed)
+	if err != nil {
+		return err
+	}
+
+	report, err := drawstats.Analyze(draws, drawstats.Options{Top: *top, IncludeBonus: *bonus})
+	if err != nil {
+		return err
+	}
+	printDrawStats(report, source)
+	return nil
+}
+
+func loadStatsDraws(path, gameID string, random int, seed int64) ([]lotto.Lottos, string, error) {
+	if path != "" {
+		imported, err := loadImportedDraws(path, gameID)
+		if err != nil {
+			return nil, "", fmt.Errorf("과거 추첨 결과를 불러오지 못했습니다: %w", err)
+		}
+		draws := make([]lotto.Lottos, 0, len(imported))
+		for _, d := range imported {
+			draws = append(draws, d.Lottos(nil))
+		}
+		return draws, path, nil
+	}
+
+	if random <= 0 {
+		return nil, "", fmt.Errorf("-draws 파일 또는 -random 회차 수를 입력해 주세요")
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	draws := make([]lotto.Lottos, 0, random)
+	for i := range random {
+		draws = append(draws, lotto.RandomDraw(rng, lotto.RoundMeta{Sequence: i + 1}).Lottos(nil))
+	}
+	return draws, fmt.Sprintf("난수 추첨 (시드 %d)", seed), nil
+}
+
+func printDrawStats(r drawstats.Report, source string) {
+	fmt.Printf("=== 추첨 번호 통계: %s, %d회차 ===\n", source, r.Draws)
+
+	fmt.Println("\n번호별 출현 (번호:횟수/미출현 회차)")
+	for i, s := range r.Numbers {
+		fmt.Printf("%2d:%4d/%-4d", s.Number, s.Count, s.Gap)
+		if (i+1)%9 == 0 {
+			fmt.Println()
+		} else {
+			fmt.Print("  ")
+		}
+	}
+	if len(r.Numbers) > 0 {
+		fmt.Printf("기대 출현 수: %.1f\n", r.Numbers[0].Expected)
+	}
+
+	fmt.Printf("\n많이 나온 번호: %s\n", joinNumberStats(r.Hot, func(s drawstats.NumberStat) int { return s.Count }))
+	fmt.Printf("적게 나온 번호: %s\n", joinNumberStats(r.Cold, func(s drawstats.NumberStat) int { return s.Count }))
+	fmt.Printf("오래 안 나온 번호: %s\n", joinNumberStats(r.Overdue, func(s drawstats.NumberStat) int { return s.Gap }))
+
+	fmt.Printf("\n자주 나온 쌍: %s\n", joinCombos(r.Pairs))
+	fmt.Printf("자주 나온 3개 조합: %s\n", joinCombos(r.Triplets))
+
+	fmt.Printf("\n당첨 번호 합: 평균 %.1f (기대 %.0f), 표준편차 %.1f\n", r.SumMean, r.SumExpected, r.SumStdDev)
+	for _, b := range r.Sums {
+		if b.Count == 0 {
+			continue
+		}
+		fmt.Printf("  %3d~%3d: %5d회 (%5.1f%%)\n", b.From, b.To, b.Count, percent(b.Count, r.Draws))
+	}
+
+	fmt.Println("\n홀수 개수 분포 (실제 / 균등 기대)")
+	for _, oe := range r.OddEven {
+		fmt.Printf("  홀%d 짝%d: %5.1f%% / %5.1f%%\n",
+			oe.Odd, lotto.LottoSize-oe.Odd, percent(oe.Count, r.Draws), oe.Expected*100)
+	}
+
+	c := r.ChiSquare
+	verdict := "균등하다고 볼 수 있음"
+	if c.PValue < 0.05 {
+		verdict = "균등하지 않을 가능성 (p < 0.05)"
+	}
+	fmt.Printf("\n카이제곱 균등성 검정: χ²=%.2f, 자유도 %d, p=%.4f → %s\n", c.Statistic, c.DF, c.PValue, verdict)
+}
+
+func joinNumberStats(stats []drawstats.NumberStat, value func(drawstats.NumberStat) int) string {
+	parts := make([]string, 0, len(stats))
+	for _, s := range stats {
+		parts = append(parts, fmt.Sprintf("%d(%d)", s.Number, value(s)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func joinCombos(combos []drawstats.Combo) string {
+	parts := make([]string, 0, len(combos))
+	for _, c := range combos {
+		parts = append(parts, fmt.Sprintf("%s×%d", formatNumbers(c.Numbers), c.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func percent(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total) * 100
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/meoraeng/lotto_simulator/internal/formatter"
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/sweep"
+)
+
+// 분배 설정 탐색: 같은 당첨자 표본으로 여러 설정의 시리즈를 돌려 지표 순으로 정렬
+// 사용법: cli sweep [-space space.yaml] [-allocations ...] [-caps ...] [-units ...] [-rolldown ...] [-search grid|random] [-sort volatility] [-csv out.csv]
+func runSweep(args []string) error {
+	fs := flag.NewFlagSet("sweep", flag.ContinueOnError)
+	rounds := fs.Int("rounds", 52, "설정마다 돌릴 회차 수")
+	tickets := fs.Int("tickets", 100_000_000, "이월이 없을 때의 회차당 판매 티켓 수")
+	elasticity := fs.Float64("elasticity", 0, "광고 1등 금액에 대한 판매 탄력성 (0이면 판매량 고정)")
+	noise := fs.Float64("noise", 0, "회차별 판매량 무작위 변동 비율 (예: 0.05)")
+	seed := fs.Int64("seed", 1, "당첨자 표본/무작위 탐색 시드")
+	search := fs.String("search", "grid", "탐색 방식 (grid, random)")
+	samples := fs.Int("samples", 20, "-search random 시 뽑을 설정 수")
+	objective := fs.String("sort", "volatility", "정렬 기준 ("+strings.Join(sweep.Objectives(), ", ")+")")
+	top := fs.Int("top", 10, "표로 출력할 상위 설정 수 (0이면 전부)")
+	csvPath := fs.String("csv", "", "전체 결과를 기록할 CSV 경로")
+	spacePath := fs.String("space", "", "탐색 공간 파일 (.yaml/.yml/.json, 없으면 기본 탐색 공간)")
+	allocFlag := fs.String("allocations", "", "1~5등 배정 비율(bps) 후보, 묶음은 ;로 구분 (예: 7500,1250,1250,0,0;6000,1500,1500,500,500)")
+	capsFlag := fs.String("caps", "", "1등 상한 후보, 0이면 상한 없음 (예: 0,2000000000)")
+	unitsFlag := fs.String("units", "", "라운딩 단위 후보 (예: 1,100,1000)")
+	rollDownFlag := fs.String("rolldown", "", "롤다운 방식 후보 (예: proportional,equal)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	space, err := buildSweepSpace(*spacePath, *allocFlag, *capsFlag, *unitsFlag, *rollDownFlag)
+	if err != nil {
+		return err
+	}
+	var configs []lotto.SeriesConfig
+	switch *search {
+	case "grid":
+		configs = space.Grid()
+	case "random":
+		configs = space.Random(rand.New(rand.NewSource(*seed)), *samples)
+	default:
+		return fmt.Errorf("지원하지 않는 탐색 방식입니다: %s", *search)
+	}
+
+	sc := sweep.Scenario{
+		Rounds:          *rounds,
+		TicketsPerRound: *tickets,
+		Elasticity:      *elasticity,
+		Noise:           *noise,
+		Seed:            *seed,
+	}
+	results, err := sweep.Run(sc, configs, *objective)
+	if err != nil {
+		return err
+	}
+
+	printSweepResults(sc, results, *objective, *top)
+
+	if *csvPath == "" {
+		return nil
+	}
+	f, err := os.Create(*csvPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := sweep.WriteCSV(f, results); err != nil {
+		return err
+	}
+	fmt.Printf("\nCSV 저장: %s (%d개 설정)\n", *csvPath, len(results))
+	return nil
+}
+
+// 파일(없으면 기본 탐색 공간)을 읽고 플래그로 준 축만 덮어쓴다
+func buildSweepSpace(path, allocFlag, capsFlag, unitsFlag, rollDownFlag string) (sweep.Space, error) {
+	space := sweep.DefaultSpace()
+	if path != "" {
+		var err error
+		if space, err = sweep.LoadSpaceFile(path); err != nil {
+			return sweep.Space{}, err
+		}
+	}
+
+	if allocFlag != "" {
+		space.Allocations = nil
+		for _, part := range strings.Split(allocFlag, ";") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			bps, err := parseNumberList(part)
+			if err != nil {
+				return sweep.Space{}, fmt.Errorf("-allocations: %w", err)
+			}
+			allocs, err := sweep.AllocationsFromBasisPoints(bps)
+			if err != nil {
+				return sweep.Space{}, err
+			}
+			space.Allocations = append(space.Allocations, allocs)
+		}
+	}
+	if capsFlag != "" {
+		caps, err := parseNumberList(capsFlag)
+		if err != nil {
+			return sweep.Space{}, fmt.Errorf("-caps: %w", err)
+		}
+		space.Rank1Caps = caps
+	}
+	if unitsFlag != "" {
+		units, err := parseNumberList(unitsFlag)
+		if err != nil {
+			return sweep.Space{}, fmt.Errorf("-units: %w", err)
+		}
+		space.RoundingUnits = units
+	}
+	if rollDownFlag != "" {
+		methods, err := sweep.ParseRollDownMethods(strings.Split(rollDownFlag, ","))
+		if err != nil {
+			return sweep.Space{}, err
+		}
+		space.RollDownMethods = methods
+	}
+	return space, space.Validate()
+}
+
+func printSweepResults(sc sweep.Scenario, results []sweep.Result, objective string, top int) {
+	fmt.Printf("=== 분배 설정 스윕 (%d회차, 회차당 %s장, 정렬: %s) ===\n",
+		sc.Rounds, formatter.Money(sc.TicketsPerRound), objective)
+	fmt.Printf("%-4s %-28s %-15s %-6s %-6s %18s %8s %8s %8s\n",
+		"순위", "배정(bps)", "1등 상한", "단위", "롤다운", "평균 1등 당첨금", "변동계수", "이월률", "환급률")
+
+	if top > 0 && len(results) > top {
+		results = results[:top]
+	}
+	for i, res := range results {
+		bps := make([]string, 0, 5)
+		for _, v := range sweep.AllocationBps(res.Config) {
+			bps = append(bps, fmt.Sprint(v))
+		}
+
+		capLabel := "없음"
+		if cap1 := res.Config.CapPerRank[lotto.Rank1]; cap1 > 0 {
+			capLabel = formatter.Money(cap1)
+		}
+		method := "비례"
+		if res.Config.RollDownMethod == lotto.RollDownEqual {
+			method = "균등"
+		}
+
+		m := res.Metrics
+		fmt.Printf("%-4d %-28s %-15s %-6d %-6s %18s %8.3f %7.1f%% %7.1f%%\n",
+			i+1, strings.Join(bps, ","), capLabel, res.Config.RoundingUnit, method,
+			formatter.Money(m.AvgJackpot), m.JackpotVolatility, m.RolloverRate*100, m.PayoutRatio*100)
+	}
+}
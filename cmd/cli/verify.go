@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+)
+
+var errVerifyFailed = errors.New("추첨 검증에 실패했습니다")
+
+// 커밋-공개 추첨용 시드와 커밋값 생성 (판매 마감 전 커밋값만 공개, 커밋값은 회차에 묶인다)
+// 사용법: cli commit -round LOTTO-1123
+func runCommit(args []string) error {
+	fs := flag.NewFlagSet("commit", flag.ContinueOnError)
+	round := fs.String("round", "", "회차 이름 (커밋값과 번호 유도에 함께 쓰임)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *round == "" {
+		return commitreveal.ErrInvalidRound
+	}
+
+	seed, err := commitreveal.NewSeed()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("회차: %s\n", *round)
+	fmt.Printf("커밋값 (지금 공개): %s\n", seed.Commitment(*round))
+	fmt.Printf("시드 (마감 후 공개, 그 전까지 비밀): %s\n", seed)
+	return nil
+}
+
+// 공개된 시드로 커밋값과 추첨 번호를 다시 계산해 대조
+// 사용법: cli verify -round LOTTO-1123 -commitment <hex> -seed <hex> [-numbers 1,2,3,4,5,6 -bonus 7]
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	round := fs.String("round", "", "회차 이름")
+	commitment := fs.String("commitment", "", "판매 마감 전에 공개된 커밋값 (SHA-256 16진수)")
+	seed := fs.String("seed", "", "공개된 시드 (16진수)")
+	numbersFlag := fs.String("numbers", "", "발표된 당첨 번호 (쉼표 구분, 생략하면 계산 결과만 출력)")
+	bonus := fs.Int("bonus", 0, "발표된 보너스 번호")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	req := commitreveal.VerifyRequest{Round: *round, Commitment: *commitment, Seed: *seed, BonusNumber: *bonus}
+	if *numbersFlag != "" {
+		numbers, err := parseNumberList(*numbersFlag)
+		if err != nil {
+			return fmt.Errorf("-numbers 값이 올바르지 않습니다: %w", err)
+		}
+		req.WinningNumbers = numbers
+	}
+
+	v, err := commitreveal.Verify(req)
+	if err != nil {
+		return err
+	}
+	printVerification(v)
+
+	if !v.Valid {
+		return errVerifyFailed
+	}
+	return nil
+}
+
+// 판매 전에 이 회차 커밋값을 만들어 보여 준다
+func sealRound(meta lotto.RoundMeta) (*commitreveal.Sealed, error) {
+	sealed, err := commitreveal.Seal(meta.ID())
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("\n[%s 커밋-공개 추첨] 회차: %s\n", meta.Label(), sealed.Round)
+	fmt.Printf("커밋값 (판매 전 공개): %s\n", sealed.Commitment)
+	return sealed, nil
+}
+
+// 판매 마감: 시드를 공개하고 당첨 번호를 정한다
+func revealRound(sealed *commitreveal.Sealed, meta lotto.RoundMeta) lotto.Draw {
+	draw, proof := sealed.Reveal()
+	draw.Meta = meta
+
+	fmt.Printf("\n=== %s ===\n", meta.Label())
+	fmt.Printf("시드 공개: %s\n", proof.Seed)
+	fmt.Printf("당첨 번호: %s + 보너스 %d (커밋-공개 추첨)\n", formatNumbers(draw.WinningNumbers), draw.BonusNumber)
+	fmt.Printf("검증: cli verify -round %s -commitment %s -seed %s\n", proof.Round, proof.Commitment, proof.Seed)
+	return draw
+}
+
+func printVerification(v commitreveal.Verification) {
+	fmt.Printf("=== 추첨 검증: %s ===\n", v.Round)
+	fmt.Printf("커밋값 일치: %s\n", okMark(v.CommitmentValid))
+	fmt.Printf("시드로 계산한 번호: %s + 보너스 %d\n", formatNumbers(v.WinningNumbers), v.BonusNumber)
+	if v.NumbersChecked {
+		fmt.Printf("발표 번호 일치: %s\n", okMark(v.NumbersMatch))
+	}
+
+	if v.Valid {
+		fmt.Println("\n결과: 검증 통과")
+	} else {
+		fmt.Println("\n결과: 검증 실패")
+	}
+}
+
+func okMark(ok bool) string {
+	if ok {
+		return "예"
+	}
+	return "아니오"
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/meoraeng/lotto_simulator/internal/formatter"
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/wheel"
+)
+
+// 고른 번호로 휠 티켓 생성 후 보장 조건 검증
+// 사용법: cli wheel -numbers 3,8,11,17,21,26,30,34,39,44 [-method abbreviated] [-guarantee 3/4]
+func runWheel(args []string) error {
+	fs := flag.NewFlagSet("wheel", flag.ContinueOnError)
+	numbersFlag := fs.String("numbers", "", "휠에 넣을 번호 (쉼표 구분, 6개 이상)")
+	method := fs.String("method", "abbreviated", "휠 방식 ("+strings.Join(wheel.Methods(), ", ")+")")
+	guaranteeFlag := fs.String("guarantee", "3/4", "보장 조건 일치수/당첨수 (abbreviated, 검증 기준)")
+	tickets := fs.Int("tickets", 10, "balanced 방식의 티켓 수")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *numbersFlag == "" {
+		return fmt.Errorf("-numbers 를 입력해 주세요")
+	}
+	numbers, err := parseNumberList(*numbersFlag)
+	if err != nil {
+		return fmt.Errorf("-numbers 값이 올바르지 않습니다: %w", err)
+	}
+	guarantee, err := wheel.ParseGuarantee(*guaranteeFlag)
+	if err != nil {
+		return err
+	}
+
+	lottos, err := wheel.Generate(wheel.Request{
+		Method:    *method,
+		Numbers:   numbers,
+		Guarantee: guarantee,
+		Tickets:   *tickets,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("=== %s 휠: 번호 %d개, %d장 (%s원) ===\n",
+		*method, len(numbers), len(lottos), formatter.Money(len(lottos)*lotto.LottoPrice))
+	for _, t := range lottos {
+		fmt.Println(formatNumbers(t.Numbers))
+	}
+
+	coverage, err := wheel.Verify(lottos, numbers, guarantee)
+	if err != nil {
+		return err
+	}
+	guarantees, err := wheel.Guarantees(lottos, numbers)
+	if err != nil {
+		return err
+	}
+	printWheelCoverage(coverage, guarantees)
+	return nil
+}
+
+func printWheelCoverage(c wheel.Coverage, guarantees []wheel.Guarantee) {
+	fmt.Println()
+	status := "보장"
+	if !c.Guaranteed() {
+		status = "미보장"
+	}
+	fmt.Printf("보장 조건 %s (%d개 일치 = %d등): %s, %d / %d 경우 충족\n",
+		c.Guarantee, c.Guarantee.Match, c.Guarantee.Rank().Number(), status, c.Covered, c.Targets)
+	for _, m := range c.Missing {
+		fmt.Printf("  미충족: %s\n", formatNumbers(m))
+	}
+
+	fmt.Println("\n휠 번호 중 당첨 번호 개수별 최소 보장")
+	for _, g := range guarantees {
+		if g.Match == 0 {
+			fmt.Printf("  %d개 당첨 시: 보장 없음\n", g.IfDrawn)
+			continue
+		}
+		fmt.Printf("  %d개 당첨 시: 최소 한 장 %d개 일치\n", g.IfDrawn, g.Match)
+	}
+}
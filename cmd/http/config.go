@@ -0,0 +1,217 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/job"
+)
+
+// 서버 설정. 기본값 < 환경 변수(LOTTO_*) < 플래그 순으로 덮어쓴다
+type config struct {
+	Addr     string
+	Dev      bool   // 템플릿/정적 파일을 디스크에서 읽고 템플릿 수정을 바로 반영
+	Assets   string // Dev일 때 읽을 디렉터리 (templates/, static/을 포함. 비어 있으면 찾아서 사용)
+	Profiles string
+
+	VendorCDN bool // static/vendor/에 내려받지 않은 외부 CSS/JS를 CDN에서 불러옴
+
+	LogFormat string // 접근 로그 형식: text 또는 json
+	MaxBody   int    // 요청 본문 최대 바이트 (0이면 제한 없음)
+
+	APIKeys   string   // API 키 파일. 비어 있으면 인증 없음
+	Public    []string // API 키 없이 열어 둘 경로 패턴
+	PublicWeb bool     // 웹 화면을 API 키 없이 열어 둠 (아니면 Basic 인증, 비밀번호 칸에 키)
+
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration // 이벤트 스트림(SSE)에는 적용하지 않음
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration // 종료 신호 후 진행 중인 요청/작업을 기다리는 최대 시간
+
+	Jobs job.Config
+}
+
+const defaultAssetsDir = "internal/webui"
+
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+func defaultConfig() config {
+	return config{
+		Addr:              ":8080",
+		LogFormat:         logFormatText,
+		MaxBody:           4 << 20,
+		Public:            []string{"/health", "/metrics", "/api/openapi.json"}, // 수집기(Prometheus)는 API 키를 보내지 않는다
+		PublicWeb:         true,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		ShutdownTimeout:   30 * time.Second,
+		Jobs: job.Config{
+			Workers:   job.DefaultWorkers,
+			QueueSize: job.DefaultQueueSize,
+			Timeout:   job.DefaultTimeout,
+		},
+	}
+}
+
+func parseConfig(args []string, getenv func(string) string) (config, error) {
+	cfg := defaultConfig()
+	env := envReader{getenv: getenv}
+
+	fs := flag.NewFlagSet("http", flag.ContinueOnError)
+	fs.StringVar(&cfg.Addr, "addr", env.string("LOTTO_ADDR", cfg.Addr), "listen 주소 (LOTTO_ADDR)")
+	fs.BoolVar(&cfg.Dev, "dev", env.bool("LOTTO_DEV", false), "템플릿/정적 파일을 디스크에서 읽음, 템플릿 수정 바로 반영 (LOTTO_DEV)")
+	fs.StringVar(&cfg.Assets, "assets", env.string("LOTTO_ASSETS", ""), "-dev일 때 읽을 웹 UI 디렉터리. 비우면 실행 위치에서 찾음 (LOTTO_ASSETS)")
+	fs.StringVar(&cfg.Profiles, "profiles", env.string("LOTTO_PROFILES", ""), "사용자 정의 게임 규칙 프로필 파일 (yaml/json) (LOTTO_PROFILES)")
+	fs.BoolVar(&cfg.VendorCDN, "vendor-cdn", env.bool("LOTTO_VENDOR_CDN", false), "go generate로 내려받지 않은 Bootstrap을 CDN에서 불러옴. 끄면 포함된 파일이 없을 때 시작하지 않음 (LOTTO_VENDOR_CDN)")
+
+	fs.StringVar(&cfg.LogFormat, "log-format", env.string("LOTTO_LOG_FORMAT", cfg.LogFormat), "로그 형식 text 또는 json (LOTTO_LOG_FORMAT)")
+	fs.IntVar(&cfg.MaxBody, "max-body", env.int("LOTTO_MAX_BODY", cfg.MaxBody), "요청 본문 최대 바이트, 넘으면 413. 0이면 제한 없음 (LOTTO_MAX_BODY)")
+
+	fs.StringVar(&cfg.APIKeys, "api-keys", env.string("LOTTO_API_KEYS", ""), "API 키 파일 (yaml/json). 지정하면 API 키 인증과 키별 요청 한도 사용 (LOTTO_API_KEYS)")
+	public := fs.String("public", env.string("LOTTO_PUBLIC", strings.Join(cfg.Public, ",")), "-api-keys일 때 키 없이 열어 둘 경로 패턴, 쉼표로 구분. /로 끝나면 그 아래 전부. 바꿀 때 /metrics를 빼면 지표 수집에도 API 키가 필요 (LOTTO_PUBLIC)")
+	fs.BoolVar(&cfg.PublicWeb, "public-web", env.bool("LOTTO_PUBLIC_WEB", cfg.PublicWeb), "-api-keys일 때 웹 화면을 키 없이 열어 둠 (LOTTO_PUBLIC_WEB)")
+
+	fs.DurationVar(&cfg.ReadHeaderTimeout, "read-header-timeout", env.duration("LOTTO_READ_HEADER_TIMEOUT", cfg.ReadHeaderTimeout), "요청 헤더를 읽는 최대 시간 (LOTTO_READ_HEADER_TIMEOUT)")
+	fs.DurationVar(&cfg.ReadTimeout, "read-timeout", env.duration("LOTTO_READ_TIMEOUT", cfg.ReadTimeout), "요청 전체를 읽는 최대 시간 (LOTTO_READ_TIMEOUT)")
+	fs.DurationVar(&cfg.WriteTimeout, "write-timeout", env.duration("LOTTO_WRITE_TIMEOUT", cfg.WriteTimeout), "응답을 쓰는 최대 시간, 이벤트 스트림 제외 (LOTTO_WRITE_TIMEOUT)")
+	fs.DurationVar(&cfg.IdleTimeout, "idle-timeout", env.duration("LOTTO_IDLE_TIMEOUT", cfg.IdleTimeout), "keep-alive 연결 유지 시간 (LOTTO_IDLE_TIMEOUT)")
+	fs.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", env.duration("LOTTO_SHUTDOWN_TIMEOUT", cfg.ShutdownTimeout), "종료 시 진행 중인 요청/작업을 기다리는 시간 (LOTTO_SHUTDOWN_TIMEOUT)")
+
+	fs.IntVar(&cfg.Jobs.Workers, "job-workers", env.int("LOTTO_JOB_WORKERS", cfg.Jobs.Workers), "동시에 실행하는 시뮬레이션 작업 수 (LOTTO_JOB_WORKERS)")
+	fs.IntVar(&cfg.Jobs.QueueSize, "job-queue", env.int("LOTTO_JOB_QUEUE", cfg.Jobs.QueueSize), "실행을 기다릴 수 있는 작업 수, 넘으면 429 (LOTTO_JOB_QUEUE)")
+	fs.DurationVar(&cfg.Jobs.Timeout, "job-timeout", env.duration("LOTTO_JOB_TIMEOUT", cfg.Jobs.Timeout), "작업 하나의 최대 실행 시간 (LOTTO_JOB_TIMEOUT)")
+
+	if env.err != nil {
+		return config{}, env.err
+	}
+	if err := fs.Parse(args); err != nil {
+		return config{}, err
+	}
+	cfg.Public = splitList(*public)
+	if cfg.LogFormat != logFormatText && cfg.LogFormat != logFormatJSON {
+		return config{}, fmt.Errorf("로그 형식은 %s 또는 %s여야 합니다: %q", logFormatText, logFormatJSON, cfg.LogFormat)
+	}
+	if cfg.MaxBody < 0 {
+		return config{}, fmt.Errorf("요청 본문 최대 바이트는 0 이상이어야 합니다: %d", cfg.MaxBody)
+	}
+
+	// 개발 모드가 아니면 바이너리에 포함된 파일을 쓰므로 디렉터리가 필요 없다
+	if !cfg.Dev {
+		cfg.Assets = ""
+		return cfg, nil
+	}
+	if cfg.Assets == "" {
+		cfg.Assets = findDir(defaultAssetsDir)
+	}
+	if cfg.Assets == "" {
+		return config{}, fmt.Errorf("웹 UI 디렉터리(%s)를 찾을 수 없습니다. -assets로 지정해 주세요", defaultAssetsDir)
+	}
+	return cfg, nil
+}
+
+// 쉼표로 구분한 목록 (빈 항목 제외)
+func splitList(s string) []string {
+	var list []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// 환경 변수 읽기. 잘못된 값이 있으면 첫 에러를 기억한다
+type envReader struct {
+	getenv func(string) string
+	err    error
+}
+
+func (e *envReader) string(name, def string) string {
+	if v := e.getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+func (e *envReader) bool(name string, def bool) bool {
+	v := e.getenv(name)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		e.fail(name, v, err)
+		return def
+	}
+	return b
+}
+
+func (e *envReader) int(name string, def int) int {
+	v := e.getenv(name)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		e.fail(name, v, err)
+		return def
+	}
+	return n
+}
+
+func (e *envReader) duration(name string, def time.Duration) time.Duration {
+	v := e.getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		e.fail(name, v, err)
+		return def
+	}
+	return d
+}
+
+func (e *envReader) fail(name, value string, err error) {
+	if e.err == nil {
+		e.err = fmt.Errorf("환경 변수 %s 값이 잘못되었습니다: %q: %w", name, value, err)
+	}
+}
+
+// 상대 경로 디렉터리를 현재 위치와 실행 파일 위치에서 위로 올라가며 찾는다
+// (저장소 밖이나 하위 디렉터리에서 실행해도 동작하도록). 없으면 ""
+func findDir(rel string) string {
+	var starts []string
+	if wd, err := os.Getwd(); err == nil {
+		starts = append(starts, wd)
+	}
+	if exe, err := os.Executable(); err == nil {
+		starts = append(starts, filepath.Dir(exe))
+	}
+
+	for _, dir := range starts {
+		for {
+			candidate := filepath.Join(dir, filepath.FromSlash(rel))
+			if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+				return candidate
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fakeEnv(vars map[string]string) func(string) string {
+	return func(name string) string { return vars[name] }
+}
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		env   map[string]string
+		check func(t *testing.T, cfg config)
+	}{
+		{
+			name: "기본값",
+			check: func(t *testing.T, cfg config) {
+				def := defaultConfig()
+				if cfg.Addr != def.Addr || cfg.MaxBody != def.MaxBody || cfg.WriteTimeout != def.WriteTimeout || !cfg.PublicWeb {
+					t.Errorf("기본 설정 = %+v", cfg)
+				}
+				if !slices.Equal(cfg.Public, def.Public) {
+					t.Errorf("공개 경로 = %v, want %v", cfg.Public, def.Public)
+				}
+				// 지표 수집기는 API 키를 보내지 않으므로 기본으로 열어 둔다
+				if !slices.Contains(cfg.Public, "/metrics") {
+					t.Errorf("기본 공개 경로에 /metrics가 없습니다: %v", cfg.Public)
+				}
+			},
+		},
+		{
+			name: "환경 변수가 기본값을 덮어씀",
+			env: map[string]string{
+				"LOTTO_ADDR":          ":9090",
+				"LOTTO_MAX_BODY":      "1024",
+				"LOTTO_WRITE_TIMEOUT": "1m",
+				"LOTTO_PUBLIC_WEB":    "false",
+				"LOTTO_JOB_WORKERS":   "2",
+			},
+			check: func(t *testing.T, cfg config) {
+				if cfg.Addr != ":9090" || cfg.MaxBody != 1024 || cfg.WriteTimeout != time.Minute || cfg.PublicWeb || cfg.Jobs.Workers != 2 {
+					t.Errorf("환경 변수 설정 = %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "플래그가 환경 변수를 덮어씀",
+			args: []string{"-addr", ":7070", "-max-body", "0", "-write-timeout", "5s", "-public-web=true"},
+			env: map[string]string{
+				"LOTTO_ADDR":          ":9090",
+				"LOTTO_MAX_BODY":      "1024",
+				"LOTTO_WRITE_TIMEOUT": "1m",
+				"LOTTO_PUBLIC_WEB":    "false",
+			},
+			check: func(t *testing.T, cfg config) {
+				if cfg.Addr != ":7070" || cfg.MaxBody != 0 || cfg.WriteTimeout != 5*time.Second || !cfg.PublicWeb {
+					t.Errorf("플래그 설정 = %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "공개 경로는 쉼표로 나누고 공백과 빈 항목을 버림",
+			args: []string{"-public", " /health, ,/api/openapi.json,/static/ ,"},
+			check: func(t *testing.T, cfg config) {
+				if want := []string{"/health", "/api/openapi.json", "/static/"}; !slices.Equal(cfg.Public, want) {
+					t.Errorf("공개 경로 = %q, want %q", cfg.Public, want)
+				}
+			},
+		},
+		{
+			name: "환경 변수 공개 경로",
+			env:  map[string]string{"LOTTO_PUBLIC": "/health"},
+			check: func(t *testing.T, cfg config) {
+				if !slices.Equal(cfg.Public, []string{"/health"}) {
+					t.Errorf("공개 경로 = %q", cfg.Public)
+				}
+			},
+		},
+		{
+			name: "개발 모드가 아니면 웹 UI 디렉터리를 쓰지 않음",
+			args: []string{"-assets", "/tmp/webui"},
+			check: func(t *testing.T, cfg config) {
+				if cfg.Assets != "" {
+					t.Errorf("Assets = %q, want 빈 값", cfg.Assets)
+				}
+			},
+		},
+		{
+			name: "개발 모드는 지정한 디렉터리 사용",
+			args: []string{"-dev", "-assets", "/tmp/webui"},
+			check: func(t *testing.T, cfg config) {
+				if !cfg.Dev || cfg.Assets != "/tmp/webui" {
+					t.Errorf("Dev = %v, Assets = %q", cfg.Dev, cfg.Assets)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseConfig(tt.args, fakeEnv(tt.env))
+			if err != nil {
+				t.Fatalf("설정을 읽지 못했습니다: %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "잘못된 시간 플래그", args: []string{"-read-timeout", "15"}, wantErr: "invalid value"},
+		{name: "잘못된 시간 환경 변수", env: map[string]string{"LOTTO_JOB_TIMEOUT": "soon"}, wantErr: "LOTTO_JOB_TIMEOUT"},
+		{name: "잘못된 크기 플래그", args: []string{"-max-body", "4MB"}, wantErr: "invalid value"},
+		{name: "잘못된 크기 환경 변수", env: map[string]string{"LOTTO_MAX_BODY": "4MB"}, wantErr: "LOTTO_MAX_BODY"},
+		{name: "음수 크기", args: []string{"-max-body", "-1"}, wantErr: "0 이상"},
+		{name: "잘못된 불리언 환경 변수", env: map[string]string{"LOTTO_DEV": "maybe"}, wantErr: "LOTTO_DEV"},
+		{name: "모르는 로그 형식", args: []string{"-log-format", "xml"}, wantErr: "로그 형식"},
+		{name: "모르는 플래그", args: []string{"-port", "80"}, wantErr: "not defined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig(tt.args, fakeEnv(tt.env))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("에러 = %v, want %q 포함", err, tt.wantErr)
+			}
+		})
+	}
+}
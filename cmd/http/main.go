@@ -1,36 +1,196 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"log/slog"
 	"net/http"
-	"path/filepath"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/meoraeng/lotto_simulator/internal/apikey"
 	"github.com/meoraeng/lotto_simulator/internal/httpapi"
+	"github.com/meoraeng/lotto_simulator/internal/job"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/session"
+	"github.com/meoraeng/lotto_simulator/internal/metrics"
+	"github.com/meoraeng/lotto_simulator/internal/middleware"
 	"github.com/meoraeng/lotto_simulator/internal/webui"
 )
 
 func main() {
+	cfg, err := parseConfig(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger := newLogger(cfg.LogFormat)
+	slog.SetDefault(logger) // log 패키지 출력도 같은 형식으로
+
+	profiles := mustLoadProfiles(cfg.Profiles)
+	jobs := job.NewManager(cfg.Jobs) // 웹 UI와 API가 같은 작업 실행기를 쓴다
+	registerJobMetrics(jobs)
 	mux := http.NewServeMux()
 
+	auth := newAuthenticator(cfg)
+
+	// 공통: 요청 ID -> 접근 로그 -> 지표 -> 패닉 복구 -> 본문 크기 제한 -> API 키 순으로 감싼다
+	// 에러 응답과 인증 방식은 등록기에 맞게: 웹 화면은 HTML/Basic 인증, API는 JSON/키 헤더
+	chain := func(onPanic middleware.ErrorWriter, onError middleware.StatusErrorWriter, authOpts *apikey.Options) []middleware.Middleware {
+		mws := []middleware.Middleware{
+			middleware.RequestID(),
+			middleware.AccessLog(logger),
+			middleware.Metrics(),
+			middleware.Recover(logger, onPanic),
+			middleware.LimitBody(int64(cfg.MaxBody), onError),
+		}
+		if auth != nil && authOpts != nil {
+			mws = append(mws, auth.Middleware(*authOpts))
+		}
+		return mws
+	}
+	webAuth := &apikey.Options{Classify: webui.QuotaClass, Basic: true}
+	if cfg.PublicWeb {
+		webAuth = nil
+	}
+	webMiddleware := chain(middleware.HTMLError, middleware.WriteHTMLError, webAuth)
+	apiMiddleware := chain(middleware.JSONError, middleware.WriteJSONError, &apikey.Options{Classify: httpapi.QuotaClass})
+
 	// 여러 핸들러 타입을 공통 인터페이스로 처리
-	registrars := []httpapi.RouteRegistrar{
-		mustNewWebUIHandler(),
-		httpapi.NewHandler(),
+	registrars := []struct {
+		registrar  httpapi.RouteRegistrar
+		middleware []middleware.Middleware
+	}{
+		{mustNewWebUIHandler(cfg, profiles, jobs), webMiddleware},
+		{httpapi.NewHandler(profiles), apiMiddleware},
+		{httpapi.NewV1Handler(profiles, session.NewMemoryStore()), apiMiddleware},
+		{httpapi.NewJobHandler(profiles, jobs), apiMiddleware},
 	}
 
-	for _, registrar := range registrars {
-		registrar.Register(mux)
+	for _, r := range registrars {
+		r.registrar.Register(middleware.Wrap(mux, r.middleware...))
 	}
 
-	log.Println("서버 실행중:  http://localhost:8080")
-	if err := http.ListenAndServe(":8080", mux); err != nil {
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if cfg.Dev {
+			log.Printf("개발 모드: %s에서 템플릿/정적 파일을 읽습니다", cfg.Assets)
+		}
+		log.Printf("서버 실행중: %s", cfg.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		log.Fatal(err)
+	case <-ctx.Done():
+	}
+	stop() // 한 번 더 누르면 바로 종료
+
+	log.Printf("종료 신호를 받았습니다. 진행 중인 요청과 시뮬레이션을 최대 %s 기다립니다", cfg.ShutdownTimeout)
+	if err := shutdown(srv, jobs, cfg.ShutdownTimeout); err != nil {
+		log.Printf("정상 종료하지 못했습니다: %v", err)
+		os.Exit(1)
+	}
+	log.Println("서버를 종료했습니다")
+}
+
+// 새 연결과 새 작업을 받지 않고, 진행 중인 요청과 작업(대기 중 포함)이 끝나기를 기다린다
+// 작업 이벤트 스트림은 작업이 끝나야 닫히므로 두 종료를 함께 진행한다
+// 시간이 지나면 남은 작업은 취소하고 연결은 끊는다
+func shutdown(srv *http.Server, jobs *job.Manager, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	jobsDone := make(chan error, 1)
+	go func() {
+		jobsDone <- jobs.Shutdown(ctx)
+	}()
+
+	err := srv.Shutdown(ctx)
+	if err != nil {
+		_ = srv.Close()
+	}
+	if jobsErr := <-jobsDone; err == nil {
+		err = jobsErr
+	}
+	return err
+}
+
+// -api-keys가 없으면 nil (인증 없음)
+func newAuthenticator(cfg config) *apikey.Authenticator {
+	if cfg.APIKeys == "" {
+		return nil
+	}
+	keys, err := apikey.LoadFile(cfg.APIKeys)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	public := cfg.Public
+	if cfg.PublicWeb {
+		// 웹 화면의 진행 페이지가 작업 상태/이벤트/취소 API를 직접 부른다
+		// 작업 ID는 추측할 수 없는 값이라 이미 시작한 작업만 열린다 (새 작업 시작은 키 필요)
+		public = append(slices.Clip(public), "/api/jobs/{id}", "/api/jobs/{id}/events")
+	}
+	log.Printf("API 키 %d개로 인증합니다 (공개 경로: %s, 웹 화면 공개: %t)", keys.Len(), strings.Join(public, ", "), cfg.PublicWeb)
+	return apikey.NewAuthenticator(keys, public)
+}
+
+// 작업 실행기 상태를 /metrics로 (읽을 때마다 Stats 호출)
+func registerJobMetrics(jobs *job.Manager) {
+	metrics.NewGaugeFunc("lotto_jobs_queued", "실행을 기다리는 작업 수", func() float64 {
+		return float64(jobs.Stats().Queued)
+	})
+	metrics.NewGaugeFunc("lotto_jobs_running", "실행 중인 작업 수", func() float64 {
+		return float64(jobs.Stats().Running)
+	})
+	metrics.NewGaugeFunc("lotto_job_workers", "동시에 실행할 수 있는 작업 수", func() float64 {
+		return float64(jobs.Stats().Workers)
+	})
+}
+
+func newLogger(format string) *slog.Logger {
+	if format == logFormatJSON {
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, nil))
+}
+
+func mustLoadProfiles(path string) *profile.Registry {
+	profiles := profile.NewRegistry()
+	if path == "" {
+		return profiles
+	}
+	if err := profiles.LoadFile(path); err != nil {
 		log.Fatal(err)
 	}
+	return profiles
 }
 
-func mustNewWebUIHandler() *webui.Handler {
-	templatesPath := filepath.Join("internal", "webui", "templates")
-	h, err := webui.NewHandler(templatesPath)
+func mustNewWebUIHandler(cfg config, profiles *profile.Registry, jobs *job.Manager) *webui.Handler {
+	h, err := webui.NewHandler(cfg.Assets, cfg.VendorCDN, profiles, jobs)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -1,3 +1,5 @@
 module github.com/meoraeng/lotto_simulator
 
 go 1.25.3
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,96 @@
+// Package apikey는 API 키 인증과 키별 요청 한도(토큰 버킷)를 제공한다
+//
+// 키는 로컬 파일(LoadFile)에서 읽고, 요청은 X-API-Key 헤더나
+// Authorization: Bearer <키> (웹 화면은 Basic 인증의 비밀번호)로 받는다
+package apikey
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// 요청 한도 종류. 큰 시뮬레이션(시리즈, 백테스트, 몬테카를로 등)은 따로 센다
+const (
+	ClassStandard = "standard"
+	ClassHeavy    = "heavy"
+)
+
+var Classes = []string{ClassStandard, ClassHeavy}
+
+var ErrInvalidFile = errors.New("API 키 파일이 잘못되었습니다")
+
+// 요청 한도: 분당 PerMinute개씩 채워지고 최대 Burst개까지 모아 둘 수 있다
+// PerMinute나 Burst가 0이면 그 종류의 API를 쓸 수 없다 (403)
+type Quota struct {
+	PerMinute float64 `json:"perMinute"`
+	Burst     int     `json:"burst"`
+}
+
+func (q Quota) allowed() bool {
+	return q.PerMinute > 0 && q.Burst > 0
+}
+
+// 파일에 기본값이 없을 때 쓰는 한도
+var DefaultQuotas = map[string]Quota{
+	ClassStandard: {PerMinute: 60, Burst: 30},
+	ClassHeavy:    {PerMinute: 6, Burst: 2},
+}
+
+// 등록된 키 하나
+type Key struct {
+	Name     string
+	Disabled bool
+	Quotas   map[string]Quota // 종류별 한도 (기본값이 이미 합쳐져 있음)
+}
+
+// 종류별 한도. 한도가 없는 종류는 쓸 수 없다 (0)
+func (k *Key) Quota(class string) Quota {
+	return k.Quotas[class]
+}
+
+// 키 목록. 원문 대신 SHA-256 해시로 찾는다 (비교 시간으로 키가 드러나지 않도록)
+type Store struct {
+	keys map[[sha256.Size]byte]*Key
+}
+
+func NewStore() *Store {
+	return &Store{keys: make(map[[sha256.Size]byte]*Key)}
+}
+
+func (s *Store) Add(secret string, key Key) error {
+	if secret == "" {
+		return fmt.Errorf("%w: %s: 키 값이 비어 있습니다", ErrInvalidFile, key.Name)
+	}
+	sum := sha256.Sum256([]byte(secret))
+	if prev, dup := s.keys[sum]; dup {
+		return fmt.Errorf("%w: %s, %s: 같은 키 값이 두 번 등록되었습니다", ErrInvalidFile, prev.Name, key.Name)
+	}
+	for _, k := range s.keys {
+		if k.Name == key.Name {
+			return fmt.Errorf("%w: 이름이 중복되었습니다: %s", ErrInvalidFile, key.Name)
+		}
+	}
+	s.keys[sum] = &key
+	return nil
+}
+
+func (s *Store) Lookup(secret string) (*Key, bool) {
+	k, ok := s.keys[sha256.Sum256([]byte(secret))]
+	return k, ok
+}
+
+func (s *Store) Len() int {
+	return len(s.keys)
+}
+
+// 등록된 키 이름 (정렬)
+func (s *Store) Names() []string {
+	names := make([]string, 0, len(s.keys))
+	for _, k := range s.keys {
+		names = append(names, k.Name)
+	}
+	slices.Sort(names)
+	return names
+}
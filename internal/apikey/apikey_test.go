@@ -0,0 +1,207 @@
+package apikey
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/middleware"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFileExample(t *testing.T) {
+	store, err := LoadFile(filepath.Join("..", "..", "configs", "apikeys.example.yaml"))
+	if err != nil {
+		t.Fatalf("예시 파일을 읽지 못했습니다: %v", err)
+	}
+
+	dashboard, ok := store.Lookup("change-me-dashboard")
+	if !ok {
+		t.Fatal("dashboard 키를 찾지 못했습니다")
+	}
+	// 키에 적은 값만 덮어쓰고 나머지는 파일 기본값
+	if got := dashboard.Quota(ClassStandard); got != (Quota{PerMinute: 600, Burst: 60}) {
+		t.Errorf("standard 한도 = %+v", got)
+	}
+	if dashboard.Quota(ClassHeavy).allowed() {
+		t.Errorf("heavy 한도 = %+v, 사용할 수 없어야 합니다", dashboard.Quota(ClassHeavy))
+	}
+	if retired, _ := store.Lookup("change-me-retired"); retired == nil || !retired.Disabled {
+		t.Errorf("retired 키가 사용 중지 상태가 아닙니다: %+v", retired)
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"알 수 없는 한도 종류", "keys.yaml", "keys:\n  - {name: a, key: k, quotas: {huge: {burst: 1}}}\n"},
+		{"중복된 키 값", "keys.yaml", "keys:\n  - {name: a, key: k}\n  - {name: b, key: k}\n"},
+		{"중복된 이름", "keys.yaml", "keys:\n  - {name: a, key: k1}\n  - {name: a, key: k2}\n"},
+		{"빈 키 값", "keys.yaml", "keys:\n  - {name: a}\n"},
+		{"음수 한도", "keys.yaml", "defaults:\n  heavy: {perMinute: -1}\nkeys:\n  - {name: a, key: k}\n"},
+		{"키 없음", "keys.yaml", "keys: []\n"},
+		{"JSON 모르는 필드", "keys.json", `{"keys": [{"name": "a", "key": "k", "secret": "x"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFile(writeFile(t, tt.file, tt.content))
+			if err == nil {
+				t.Fatal("에러가 나야 합니다")
+			}
+		})
+	}
+
+	if _, err := LoadFile(writeFile(t, "keys.yaml", "keys:\n  - {name: a, key: k, quotas: {huge: {}}}\n")); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("err = %v, want ErrInvalidFile", err)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewLimiter()
+	l.now = func() time.Time { return now }
+	q := Quota{PerMinute: 60, Burst: 2} // 초당 1개
+
+	steps := []struct {
+		advance   time.Duration
+		ok        bool
+		remaining int
+		retry     time.Duration
+	}{
+		{0, true, 1, 0},
+		{0, true, 0, 0},
+		{0, false, 0, time.Second},
+		{500 * time.Millisecond, false, 0, 500 * time.Millisecond},
+		{500 * time.Millisecond, true, 0, 0},
+		{time.Hour, true, 1, 0}, // 오래 쉬어도 burst까지만 찬다
+	}
+
+	for i, s := range steps {
+		now = now.Add(s.advance)
+		ok, remaining, retry := l.Allow("a", ClassStandard, q)
+		if ok != s.ok || remaining != s.remaining || retry != s.retry {
+			t.Errorf("%d번째 요청 = (%v, %d, %s), want (%v, %d, %s)", i+1, ok, remaining, retry, s.ok, s.remaining, s.retry)
+		}
+	}
+
+	// 키와 한도 종류마다 버킷이 따로
+	if ok, _, _ := l.Allow("a", ClassHeavy, q); !ok {
+		t.Error("다른 한도 종류는 따로 세야 합니다")
+	}
+	if ok, _, _ := l.Allow("b", ClassStandard, q); !ok {
+		t.Error("다른 키는 따로 세야 합니다")
+	}
+}
+
+func testAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	store := NewStore()
+	add := func(secret string, key Key) {
+		if err := store.Add(secret, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	add("good", Key{Name: "good", Quotas: map[string]Quota{
+		ClassStandard: {PerMinute: 60, Burst: 2},
+		ClassHeavy:    {PerMinute: 1, Burst: 1},
+	}})
+	add("light", Key{Name: "light", Quotas: map[string]Quota{ClassStandard: {PerMinute: 60, Burst: 5}}})
+	add("off", Key{Name: "off", Disabled: true, Quotas: DefaultQuotas})
+	return NewAuthenticator(store, []string{"/health", "/docs/"})
+}
+
+func TestMiddleware(t *testing.T) {
+	a := testAuthenticator(t)
+	mux := http.NewServeMux()
+	r := middleware.Wrap(mux, middleware.RequestID(), a.Middleware(Options{
+		Classify: func(r *http.Request) string {
+			if r.URL.Path == "/heavy" {
+				return ClassHeavy
+			}
+			return ClassStandard
+		},
+	}))
+	for _, p := range []string{"/health", "/docs/{page}", "/api", "/heavy"} {
+		r.HandleFunc(p, func(w http.ResponseWriter, r *http.Request) {})
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		header map[string]string
+		basic  string // Basic 인증 비밀번호
+		status int
+	}{
+		{name: "공개 경로", path: "/health", status: http.StatusOK},
+		{name: "공개 경로 (하위 전체)", path: "/docs/intro", status: http.StatusOK},
+		{name: "키 없음", path: "/api", status: http.StatusUnauthorized},
+		{name: "틀린 키", path: "/api", header: map[string]string{Header: "bad"}, status: http.StatusUnauthorized},
+		{name: "X-API-Key", path: "/api", header: map[string]string{Header: "good"}, status: http.StatusOK},
+		{name: "Bearer", path: "/api", header: map[string]string{"Authorization": "Bearer good"}, status: http.StatusOK},
+		{name: "standard 한도 초과", path: "/api", header: map[string]string{Header: "good"}, status: http.StatusTooManyRequests},
+		{name: "heavy 한도는 따로", path: "/heavy", header: map[string]string{Header: "good"}, status: http.StatusOK},
+		{name: "heavy 한도 초과", path: "/heavy", header: map[string]string{Header: "good"}, status: http.StatusTooManyRequests},
+		{name: "Basic 인증 비밀번호", path: "/api", basic: "light", status: http.StatusOK},
+		{name: "heavy를 쓸 수 없는 키", path: "/heavy", header: map[string]string{Header: "light"}, status: http.StatusForbidden},
+		{name: "사용 중지된 키", path: "/api", header: map[string]string{Header: "off"}, status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			if tt.basic != "" {
+				req.SetBasicAuth("user", tt.basic)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("상태 코드 = %d, want %d (%s)", rec.Code, tt.status, rec.Body.String())
+			}
+			if rec.Code == http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Error     string `json:"error"`
+				RequestID string `json:"requestId"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("JSON 에러가 아닙니다: %v (%s)", err, rec.Body.String())
+			}
+			if body.Error == "" || body.RequestID != rec.Header().Get(middleware.RequestIDHeader) {
+				t.Errorf("에러 본문 = %+v", body)
+			}
+			switch rec.Code {
+			case http.StatusUnauthorized:
+				if rec.Header().Get("WWW-Authenticate") == "" {
+					t.Error("401에는 WWW-Authenticate 헤더가 있어야 합니다")
+				}
+			case http.StatusTooManyRequests:
+				if rec.Header().Get("Retry-After") == "" {
+					t.Error("429에는 Retry-After 헤더가 있어야 합니다")
+				}
+			}
+		})
+	}
+}
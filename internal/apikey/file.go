@@ -0,0 +1,127 @@
+package apikey
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// API 키 파일 형식 (YAML/JSON 공통). 한도 종류는 standard, heavy
+//
+//	defaults:                 # 키에 한도가 없을 때 (없으면 DefaultQuotas)
+//	  standard: {perMinute: 60, burst: 30}
+//	  heavy: {perMinute: 6, burst: 2}
+//	keys:
+//	  - name: team-a
+//	    key: "..."
+//	    quotas:
+//	      heavy: {perMinute: 0}  # 큰 시뮬레이션 금지 (403)
+//	  - name: old
+//	    key: "..."
+//	    disabled: true           # 403
+type fileFormat struct {
+	Defaults map[string]fileQuota `json:"defaults" yaml:"defaults"`
+	Keys     []fileKey            `json:"keys" yaml:"keys"`
+}
+
+type fileKey struct {
+	Name     string               `json:"name" yaml:"name"`
+	Key      string               `json:"key" yaml:"key"`
+	Disabled bool                 `json:"disabled" yaml:"disabled"`
+	Quotas   map[string]fileQuota `json:"quotas" yaml:"quotas"`
+}
+
+// 적지 않은 값은 기본값을 쓰도록 포인터로 받는다
+type fileQuota struct {
+	PerMinute *float64 `json:"perMinute" yaml:"perMinute"`
+	Burst     *int     `json:"burst" yaml:"burst"`
+}
+
+func (fq fileQuota) apply(q Quota) Quota {
+	if fq.PerMinute != nil {
+		q.PerMinute = *fq.PerMinute
+	}
+	if fq.Burst != nil {
+		q.Burst = *fq.Burst
+	}
+	return q
+}
+
+// 파일의 키를 모두 읽는다. 확장자(.yaml/.yml/.json)로 형식 결정
+func LoadFile(path string) (*Store, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file fileFormat
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&file)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 API 키 파일 형식입니다: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	store, err := file.toStore()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return store, nil
+}
+
+func (f fileFormat) toStore() (*Store, error) {
+	defaults := maps.Clone(DefaultQuotas)
+	for class, fq := range f.Defaults {
+		if err := checkClass(class); err != nil {
+			return nil, err
+		}
+		defaults[class] = fq.apply(defaults[class])
+	}
+
+	store := NewStore()
+	for _, fk := range f.Keys {
+		if fk.Name == "" {
+			return nil, fmt.Errorf("%w: 이름이 없는 키가 있습니다", ErrInvalidFile)
+		}
+		key := Key{Name: fk.Name, Disabled: fk.Disabled, Quotas: maps.Clone(defaults)}
+		for class, fq := range fk.Quotas {
+			if err := checkClass(class); err != nil {
+				return nil, fmt.Errorf("%s: %w", fk.Name, err)
+			}
+			key.Quotas[class] = fq.apply(key.Quotas[class])
+		}
+		for class, q := range key.Quotas {
+			if q.PerMinute < 0 || q.Burst < 0 {
+				return nil, fmt.Errorf("%w: %s: %s 한도는 0 이상이어야 합니다", ErrInvalidFile, fk.Name, class)
+			}
+		}
+		if err := store.Add(fk.Key, key); err != nil {
+			return nil, err
+		}
+	}
+	if store.Len() == 0 {
+		return nil, fmt.Errorf("%w: 등록된 키가 없습니다", ErrInvalidFile)
+	}
+	return store, nil
+}
+
+func checkClass(class string) error {
+	if !slices.Contains(Classes, class) {
+		return fmt.Errorf("%w: 알 수 없는 한도 종류입니다: %q (%s)", ErrInvalidFile, class, strings.Join(Classes, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package apikey
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// 키 + 한도 종류별 토큰 버킷 (동시 사용 안전)
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+	now     func() time.Time
+}
+
+type bucketKey struct {
+	key   string // 키 이름
+	class string
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewLimiter() *Limiter {
+	return &Limiter{buckets: make(map[bucketKey]*bucket), now: time.Now}
+}
+
+// 토큰 하나를 쓴다. 허용되지 않으면 토큰이 하나 찰 때까지 기다릴 시간을 함께 돌려준다
+// remaining은 이번 요청 뒤 남은 토큰 수 (내림)
+func (l *Limiter) Allow(key, class string, q Quota) (ok bool, remaining int, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	burst := float64(q.Burst)
+	b, exists := l.buckets[bucketKey{key, class}]
+	if !exists {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[bucketKey{key, class}] = b
+	}
+
+	// 지난 시간만큼 채우되 최대 burst (파일을 바꿔 burst가 줄었을 때도 넘지 않게)
+	perSecond := q.PerMinute / 60
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*perSecond)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, int(b.tokens), 0
+	}
+	if perSecond <= 0 {
+		return false, 0, 0
+	}
+	wait := time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+	return false, 0, wait
+}
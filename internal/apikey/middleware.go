@@ -0,0 +1,135 @@
+package apikey
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/meoraeng/lotto_simulator/internal/metrics"
+	"github.com/meoraeng/lotto_simulator/internal/middleware"
+)
+
+// 키를 받는 헤더 (Authorization: Bearer <키>도 받음)
+const Header = "X-API-Key"
+
+const realm = "lotto-simulator"
+
+var (
+	keyRequests = metrics.NewCounterVec(
+		"lotto_api_key_requests_total",
+		"인증을 통과한 요청 수 (키 이름, 한도 종류별)",
+		"key", "class",
+	)
+	authRejected = metrics.NewCounterVec(
+		"lotto_auth_rejected_total",
+		"인증/한도 검사에서 거절한 요청 수",
+		"reason",
+	)
+)
+
+// 키 검사와 요청 한도 (등록기들이 같은 Authenticator를 쓰면 한도도 공유)
+type Authenticator struct {
+	keys    *Store
+	limiter *Limiter
+	public  []string
+}
+
+// public은 키 없이 열어 둘 경로 패턴 (예: /health). "/"로 끝나면 그 아래 전부
+func NewAuthenticator(keys *Store, public []string) *Authenticator {
+	return &Authenticator{keys: keys, limiter: NewLimiter(), public: public}
+}
+
+// 등록기별 설정
+type Options struct {
+	Classify func(*http.Request) string // 요청의 한도 종류. nil이면 모두 standard
+	Basic    bool                       // 401에서 브라우저가 Basic 인증 창을 띄우게 한다 (비밀번호 칸에 키)
+}
+
+// 키가 없거나 틀리면 401, 사용 중지됐거나 쓸 수 없는 종류면 403, 한도를 넘으면 429 (모두 JSON)
+func (a *Authenticator) Middleware(opts Options) middleware.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if a.isPublic(middleware.Route(r)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			secret := secretFrom(r)
+			if secret == "" {
+				a.unauthorized(w, r, opts, "API 키가 필요합니다 ("+Header+" 헤더 또는 Authorization: Bearer)")
+				return
+			}
+			key, ok := a.keys.Lookup(secret)
+			if !ok {
+				a.unauthorized(w, r, opts, "유효하지 않은 API 키입니다")
+				return
+			}
+			if key.Disabled {
+				reject(w, r, http.StatusForbidden, "disabled", "사용이 중지된 API 키입니다")
+				return
+			}
+
+			class := ClassStandard
+			if opts.Classify != nil {
+				class = opts.Classify(r)
+			}
+			quota := key.Quota(class)
+			if !quota.allowed() {
+				reject(w, r, http.StatusForbidden, "forbidden", fmt.Sprintf("이 API 키로는 %s 한도의 API를 쓸 수 없습니다", class))
+				return
+			}
+
+			allowed, remaining, retryAfter := a.limiter.Allow(key.Name, class, quota)
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(quota.Burst))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+			if !allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				reject(w, r, http.StatusTooManyRequests, "rate_limited",
+					fmt.Sprintf("요청 한도를 초과했습니다 (%s: 분당 %g회, 최대 %d회 연속)", class, quota.PerMinute, quota.Burst))
+				return
+			}
+
+			keyRequests.With(key.Name, class).Inc()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (a *Authenticator) isPublic(route string) bool {
+	for _, p := range a.public {
+		if route == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(route, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Authenticator) unauthorized(w http.ResponseWriter, r *http.Request, opts Options, msg string) {
+	challenge := `Bearer realm="` + realm + `"`
+	if opts.Basic {
+		challenge = `Basic realm="` + realm + `", charset="UTF-8"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	reject(w, r, http.StatusUnauthorized, "unauthorized", msg)
+}
+
+func reject(w http.ResponseWriter, r *http.Request, status int, reason, msg string) {
+	authRejected.With(reason).Inc()
+	middleware.WriteJSONError(w, r, status, msg)
+}
+
+// X-API-Key, Authorization: Bearer, Basic 인증 비밀번호 순으로 찾는다
+func secretFrom(r *http.Request) string {
+	if key := strings.TrimSpace(r.Header.Get(Header)); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	if _, password, ok := r.BasicAuth(); ok {
+		return password
+	}
+	return ""
+}
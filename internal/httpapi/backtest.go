@@ -0,0 +1,87 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/backtest"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/history"
+)
+
+// 공식 당첨금 백테스트 요청
+// history는 과거 기록 가져오기 JSON 형식 (sales, winners, prizes 포함)
+// optimize가 true면 grid(비우면 기본 격자)로 배정 비율 탐색 결과도 반환
+type backtestRequest struct {
+	Config   lotto.SeriesConfig `json:"config"`
+	History  json.RawMessage    `json:"history"`
+	Optimize bool               `json:"optimize"`
+	Grid     *backtest.Grid     `json:"grid"`
+	Top      int                `json:"top"`
+}
+
+type backtestResponse struct {
+	Report     backtest.Report      `json:"report"`
+	Candidates []backtest.Candidate `json:"candidates,omitempty"`
+}
+
+func (h *Handler) handleBacktest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorMsg(w, http.StatusMethodNotAllowed, "허용되지 않은 메서드입니다")
+		return
+	}
+
+	var req backtestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return
+	}
+
+	game, ok, err := queryProfile(h.profiles, r)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	if ok {
+		req.Config = game.SeriesConfig()
+	}
+
+	records, err := history.ReadJSON(bytes.NewReader(req.History), lotto.DefaultGameID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 과거 기록입니다", err)
+		return
+	}
+
+	report, err := backtest.Run(req.Config, records)
+	if errors.Is(err, backtest.ErrNoOfficialData) {
+		writeError(w, http.StatusBadRequest, "백테스트할 수 없습니다", err)
+		return
+	}
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	resp := backtestResponse{Report: report}
+	if req.Optimize {
+		grid := backtest.DefaultGrid()
+		if req.Grid != nil {
+			grid = *req.Grid
+		}
+		if resp.Candidates, err = backtest.Optimize(r.Context(), req.Config, records, grid, req.Top); err != nil {
+			if r.Context().Err() != nil {
+				return // 클라이언트가 끊었거나 서버가 종료 중
+			}
+			writeDomainError(w, err)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeError(w, http.StatusInternalServerError, "결과 인코딩에 실패했습니다", err)
+		return
+	}
+}
@@ -0,0 +1,99 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+)
+
+type commitRequest struct {
+	Round      string    `json:"round"`
+	SalesClose time.Time `json:"salesClose"` // 이 시각 전에는 공개할 수 없다
+}
+
+// POST /api/draws: 판매 마감 전에 커밋값만 공개한 추첨 등록
+func (h *Handler) handleDraws(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorMsg(w, http.StatusMethodNotAllowed, "허용되지 않은 메서드입니다")
+		return
+	}
+
+	var req commitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return
+	}
+
+	draw, err := h.draws.Commit(req.Round, req.SalesClose)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeDraw(w, http.StatusCreated, draw)
+}
+
+// GET /api/draws/{id}: 추첨 조회 (공개 전이면 커밋값만)
+// POST /api/draws/{id}/reveal: 판매 마감 뒤 시드 공개와 번호 확정
+func (h *Handler) handleDraw(w http.ResponseWriter, r *http.Request) {
+	id, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/draws/"), "/")
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		draw, err := h.draws.Get(id)
+		if err != nil {
+			writeDomainError(w, err)
+			return
+		}
+		writeDraw(w, http.StatusOK, draw)
+	case action == "reveal" && r.Method == http.MethodPost:
+		draw, err := h.draws.Reveal(id)
+		if err != nil {
+			writeDomainError(w, err)
+			return
+		}
+		writeDraw(w, http.StatusOK, draw)
+	case action == "" || action == "reveal":
+		writeErrorMsg(w, http.StatusMethodNotAllowed, "허용되지 않은 메서드입니다")
+	default:
+		writeErrorMsg(w, http.StatusNotFound, "존재하지 않는 경로입니다")
+	}
+}
+
+// POST /api/draws/verify: 공개된 시드로 커밋값과 번호를 다시 계산해 대조
+// 서버에 등록되지 않은 추첨도 커밋값/시드/회차만 있으면 검증할 수 있다
+func (h *Handler) handleDrawVerify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorMsg(w, http.StatusMethodNotAllowed, "허용되지 않은 메서드입니다")
+		return
+	}
+
+	var req commitreveal.VerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return
+	}
+
+	v, err := commitreveal.Verify(req)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		writeError(w, http.StatusInternalServerError, "결과 인코딩에 실패했습니다", err)
+		return
+	}
+}
+
+func writeDraw(w http.ResponseWriter, status int, draw commitreveal.Draw) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(draw); err != nil {
+		writeError(w, http.StatusInternalServerError, "결과 인코딩에 실패했습니다", err)
+		return
+	}
+}
@@ -1,19 +1,83 @@
 package httpapi
 
 import (
+	"errors"
 	"net/http"
+
+	"github.com/meoraeng/lotto_simulator/internal/job"
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/backtest"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/session"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/wheel"
+	"github.com/meoraeng/lotto_simulator/internal/middleware"
 )
 
 // 메시지 남기는 경우
 func writeErrorMsg(w http.ResponseWriter, status int, msg string) {
-	http.Error(w, "[ERROR] "+msg, status)
+	http.Error(w, errorBody(w, msg), status)
 }
 
 // 에러 객체 함께 남기는 경우
 func writeError(w http.ResponseWriter, status int, msg string, err error) {
 	if err != nil {
-		http.Error(w, "[ERROR] "+msg+": "+err.Error(), status)
-		return
+		msg += ": " + err.Error()
+	}
+	http.Error(w, errorBody(w, msg), status)
+}
+
+// 요청 ID 미들웨어를 거친 요청이면 응답 헤더의 ID를 본문에도 붙여 로그와 맞춰 볼 수 있게 한다
+func errorBody(w http.ResponseWriter, msg string) string {
+	if id := w.Header().Get(middleware.RequestIDHeader); id != "" {
+		return "[ERROR] " + msg + " (요청 ID: " + id + ")"
+	}
+	return "[ERROR] " + msg
+}
+
+// 도메인에서 넘어온 에러 종류에 따라 HTTP 상태코드 및 메시지 매핑
+func writeDomainError(w http.ResponseWriter, err error) {
+	domainErrors.With(domainErrorName(err)).Inc()
+
+	switch {
+	case errors.Is(err, commitreveal.ErrDrawNotFound),
+		errors.Is(err, job.ErrNotFound),
+		errors.Is(err, session.ErrSimulationNotFound),
+		errors.Is(err, session.ErrPlayerNotFound),
+		errors.Is(err, session.ErrRoundNotFound):
+		writeError(w, http.StatusNotFound, "찾을 수 없습니다", err)
+	case errors.Is(err, commitreveal.ErrAlreadyRevealed),
+		errors.Is(err, commitreveal.ErrSalesOpen),
+		errors.Is(err, job.ErrFinished),
+		errors.Is(err, session.ErrDuplicatePlayer),
+		errors.Is(err, session.ErrNoTickets):
+		writeError(w, http.StatusConflict, "처리할 수 없는 상태입니다", err)
+	case errors.Is(err, job.ErrQueueFull):
+		writeError(w, http.StatusTooManyRequests, "잠시 후 다시 시도해 주세요", err)
+	case errors.Is(err, job.ErrClosed):
+		writeError(w, http.StatusServiceUnavailable, "잠시 후 다시 시도해 주세요", err)
+	case errors.Is(err, lotto.ErrInvalidMode):
+		writeErrorMsg(w, http.StatusBadRequest, "잘못된 모드 값입니다")
+	case errors.Is(err, lotto.ErrNegativeSales),
+		errors.Is(err, lotto.ErrInvalidRollDown),
+		errors.Is(err, lotto.ErrInvalidRank),
+		errors.Is(err, profile.ErrUnknownProfile),
+		errors.Is(err, backtest.ErrGridTooLarge),
+		errors.Is(err, wheel.ErrInvalidNumbers),
+		errors.Is(err, wheel.ErrInvalidGuarantee),
+		errors.Is(err, wheel.ErrTooLarge),
+		errors.Is(err, wheel.ErrUnknownMethod),
+		errors.Is(err, commitreveal.ErrInvalidSeed),
+		errors.Is(err, commitreveal.ErrInvalidCommitment),
+		errors.Is(err, commitreveal.ErrInvalidRound),
+		errors.Is(err, commitreveal.ErrInvalidSalesClose),
+		errors.Is(err, session.ErrInvalidDrawSource),
+		errors.Is(err, session.ErrInvalidPlayer),
+		errors.Is(err, session.ErrInvalidOrder),
+		errors.Is(err, session.ErrInvalidDraw):
+		writeError(w, http.StatusBadRequest, "잘못된 입력입니다", err)
+	default:
+		// 예상 못한 도메인 에러 (원장 불일치 등)
+		writeError(w, http.StatusInternalServerError, "서버 내부 오류가 발생했습니다", err)
 	}
-	http.Error(w, "[ERROR] "+msg, status)
 }
@@ -2,25 +2,46 @@ package httpapi
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+	"github.com/meoraeng/lotto_simulator/internal/metrics"
+	"github.com/meoraeng/lotto_simulator/internal/middleware"
 )
 
-type Handler struct{}
+type Handler struct {
+	profiles *profile.Registry
+	draws    *commitreveal.Store // 커밋-공개 추첨 (서버 메모리)
+}
 
-func NewHandler() *Handler {
-	return &Handler{}
+func NewHandler(profiles *profile.Registry) *Handler {
+	return &Handler{profiles: profiles, draws: commitreveal.NewStore()}
 }
 
 // 인터페이스 composition을 통해 공통 등록 패턴 제공
-func (h *Handler) Register(mux *http.ServeMux) {
-	mux.HandleFunc("/api/round", h.handleCalculateRound)
+// 요청 본문은 API 명세(openapi.yaml)로 먼저 검증
+func (h *Handler) Register(mux middleware.Router) {
+	handle := func(pattern string, fn http.HandlerFunc) {
+		mux.HandleFunc(pattern, openAPI.validateRequest(fn))
+	}
+
+	handle("/api/round", h.handleCalculateRound)
+	handle("/api/series", h.handleSeries)
+	handle("/api/backtest", h.handleBacktest)
+	handle("/api/profiles", h.handleProfiles)
+	handle("/api/wheel", h.handleWheel)
+	handle("/api/wheel/verify", h.handleWheelVerify)
+	handle("/api/draws", h.handleDraws)
+	handle("/api/draws/", h.handleDraw)
+	handle("/api/draws/verify", h.handleDrawVerify)
+	mux.HandleFunc("/api/openapi.json", h.handleOpenAPI)
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
+	mux.Handle("/metrics", metrics.Default)
 }
 
 func (h *Handler) handleCalculateRound(w http.ResponseWriter, r *http.Request) {
@@ -36,18 +57,21 @@ func (h *Handler) handleCalculateRound(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// ?profile=이름 이면 분배 규칙은 프로필 값 사용 (판매액/당첨자 수/이월은 본문 값)
+	game, ok, err := queryProfile(h.profiles, r)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	if ok {
+		in = game.Apply(in)
+	}
+
 	out, err := lotto.CalculateRound(in) // 도메인 로직 호출
 
 	w.Header().Set("Content-Type", "application/json")
 	if err != nil {
-		// 도메인에서 넘어온 에러 종류에 따라 HTTP 상태코드 및 메시지 매핑
-		if errors.Is(err, lotto.ErrInvalidMode) {
-			writeErrorMsg(w, http.StatusBadRequest, "잘못된 모드 값입니다")
-			return
-		}
-
-		// 예상 못한 도메인 에러
-		writeError(w, http.StatusInternalServerError, "서버 내부 오류가 발생했습니다", err)
+		writeDomainError(w, err)
 		return
 	}
 
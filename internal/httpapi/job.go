@@ -0,0 +1,351 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/job"
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+	"github.com/meoraeng/lotto_simulator/internal/middleware"
+)
+
+const (
+	jobsPrefix = "/api/jobs"
+
+	// 몬테카를로 작업 한 번에 돌릴 수 있는 최대 회차 수 (이벤트를 모두 메모리에 보관하므로 제한)
+	MaxMonteCarloRounds = 10_000
+
+	// 연결을 유지하기 위해 이벤트가 없을 때 보내는 주석 간격
+	sseKeepAlive = 15 * time.Second
+)
+
+// 오래 걸리는 시뮬레이션을 비동기 작업으로 실행하는 API
+//
+//	GET    /api/jobs             실행기 현황과 보관 중인 작업 목록 (?state=로 거름)
+//	POST   /api/jobs/series      /api/series와 같은 본문으로 시리즈 작업 시작
+//	POST   /api/jobs/montecarlo  무작위 추첨을 여러 회차 반복하는 작업 시작
+//	GET    /api/jobs/{id}        작업 상태 (끝났으면 결과 포함)
+//	DELETE /api/jobs/{id}        작업 취소
+//	GET    /api/jobs/{id}/events 진행 상황 SSE 스트림
+type JobHandler struct {
+	profiles *profile.Registry
+	jobs     *job.Manager
+}
+
+func NewJobHandler(profiles *profile.Registry, jobs *job.Manager) *JobHandler {
+	return &JobHandler{profiles: profiles, jobs: jobs}
+}
+
+// 작업은 정해진 수만큼만 동시에 실행하고, 대기열이 차면 429로 거절한다
+//
+// 요청 본문은 API 명세(openapi.yaml)로 먼저 검증
+func (h *JobHandler) Register(mux middleware.Router) {
+	handle := func(pattern string, fn http.HandlerFunc) {
+		mux.HandleFunc(pattern, openAPI.validateRequest(fn))
+	}
+
+	handle("GET "+jobsPrefix, h.listJobs)
+	handle("POST "+jobsPrefix+"/series", h.startSeries)
+	handle("POST "+jobsPrefix+"/montecarlo", h.startMonteCarlo)
+	handle("GET "+jobsPrefix+"/{id}", h.getJob)
+	handle("DELETE "+jobsPrefix+"/{id}", h.cancelJob)
+	handle("GET "+jobsPrefix+"/{id}/events", h.streamEvents)
+}
+
+type jobListResponse struct {
+	Runner job.Stats    `json:"runner"`
+	Jobs   []job.Status `json:"jobs"` // 최근에 만든 것부터
+}
+
+type jobView struct {
+	job.Status
+	Result any               `json:"result,omitempty"` // 성공한 작업의 결과
+	Links  map[string]string `json:"links"`
+}
+
+// 몬테카를로 작업 요청: 같은 티켓으로 무작위 추첨을 rounds회 반복
+type monteCarloRequest struct {
+	Config  lotto.SeriesConfig `json:"config"`
+	Players []playerRequest    `json:"players"`
+	Rounds  int                `json:"rounds"`
+	CarryIn map[lotto.Rank]int `json:"carryIn"`
+	Seed    int64              `json:"seed"` // 0이 아니면 같은 추첨 결과를 재현
+}
+
+// 몬테카를로 회차 하나의 요약 (진행 이벤트로 내보냄)
+type monteCarloRound struct {
+	Meta           lotto.RoundMeta    `json:"meta"`
+	WinningNumbers []int              `json:"winningNumbers"`
+	BonusNumber    int                `json:"bonusNumber"`
+	Winners        map[lotto.Rank]int `json:"winners"`
+	Paid           int                `json:"paid"`
+	Payouts        map[string]int     `json:"payouts"`
+}
+
+type monteCarloResult struct {
+	Rounds   int                `json:"rounds"`
+	Sales    int                `json:"sales"`
+	Paid     int                `json:"paid"`
+	Winners  map[lotto.Rank]int `json:"winners"` // 등수별 누적 당첨 티켓 수
+	CarryOut map[lotto.Rank]int `json:"carryOut"`
+	Players  []monteCarloPlayer `json:"players"`
+}
+
+type monteCarloPlayer struct {
+	Name       string  `json:"name"`
+	Spent      int     `json:"spent"`
+	Won        int     `json:"won"`
+	ReturnRate float64 `json:"returnRate"` // 수령액 / 구매 금액
+}
+
+func (h *JobHandler) startSeries(w http.ResponseWriter, r *http.Request) {
+	s, ok := decodeSeries(w, r, h.profiles)
+	if !ok {
+		return
+	}
+
+	j, err := h.jobs.Start("series", func(ctx context.Context, progress *job.Progress) (any, error) {
+		progress.SetTotal(len(s.draws))
+
+		rounds := make([]lotto.SeriesRound, 0, len(s.draws))
+		err := lotto.RunDrawSeries(s.config, s.players, s.draws, s.carryIn, s.repurchase, func(round lotto.SeriesRound) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			rounds = append(rounds, round)
+			progress.Advance("round", round)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return seriesResponse{Rounds: rounds}, nil
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJobAccepted(w, j)
+}
+
+func (h *JobHandler) startMonteCarlo(w http.ResponseWriter, r *http.Request) {
+	var req monteCarloRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return
+	}
+	if req.Rounds < 1 || req.Rounds > MaxMonteCarloRounds {
+		writeErrorMsg(w, http.StatusBadRequest, fmt.Sprintf("회차 수는 1~%d 사이여야 합니다", MaxMonteCarloRounds))
+		return
+	}
+
+	game, ok, err := queryProfile(h.profiles, r)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	if ok {
+		req.Config = game.SeriesConfig()
+	}
+
+	players, err := seriesRequest{Players: req.Players}.players()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 티켓입니다", err)
+		return
+	}
+
+	var src lotto.NumberSource = lotto.DefaultSource
+	if req.Seed != 0 {
+		src = rand.New(rand.NewSource(req.Seed))
+	}
+	draws := make([]lotto.Draw, 0, req.Rounds)
+	for range req.Rounds {
+		draws = append(draws, lotto.RandomDraw(src, lotto.RoundMeta{}))
+	}
+
+	j, err := h.jobs.Start("montecarlo", func(ctx context.Context, progress *job.Progress) (any, error) {
+		return runMonteCarlo(ctx, progress, req.Config, players, draws, req.CarryIn)
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJobAccepted(w, j)
+}
+
+func runMonteCarlo(
+	ctx context.Context,
+	progress *job.Progress,
+	cfg lotto.SeriesConfig,
+	players []lotto.Player,
+	draws []lotto.Draw,
+	carryIn map[lotto.Rank]int,
+) (monteCarloResult, error) {
+	progress.SetTotal(len(draws))
+
+	result := monteCarloResult{Winners: make(map[lotto.Rank]int), CarryOut: carryIn}
+	won := make(map[string]int, len(players))
+
+	err := lotto.RunDrawSeries(cfg, players, draws, carryIn, nil, func(round lotto.SeriesRound) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		paid := 0
+		for name, v := range round.Payouts {
+			won[name] += v
+			paid += v
+		}
+		for rank, n := range round.Input.Winners {
+			result.Winners[rank] += n
+		}
+		result.Rounds++
+		result.Sales += round.Output.Sales
+		result.Paid += paid
+		result.CarryOut = round.Output.CarryOut
+
+		progress.Advance("round", monteCarloRound{
+			Meta:           round.Output.Meta,
+			WinningNumbers: round.Draw.WinningNumbers,
+			BonusNumber:    round.Draw.BonusNumber,
+			Winners:        round.Input.Winners,
+			Paid:           paid,
+			Payouts:        round.Payouts,
+		})
+		return nil
+	})
+	if err != nil {
+		return monteCarloResult{}, err
+	}
+
+	result.Players = make([]monteCarloPlayer, 0, len(players))
+	for _, p := range players {
+		mp := monteCarloPlayer{Name: p.Name, Spent: len(p.Tickets) * lotto.LottoPrice * result.Rounds, Won: won[p.Name]}
+		if mp.Spent > 0 {
+			mp.ReturnRate = float64(mp.Won) / float64(mp.Spent)
+		}
+		result.Players = append(result.Players, mp)
+	}
+	return result, nil
+}
+
+func (h *JobHandler) listJobs(w http.ResponseWriter, r *http.Request) {
+	jobs := h.jobs.List()
+	if state := job.State(r.URL.Query().Get("state")); state != "" {
+		jobs = slices.DeleteFunc(jobs, func(s job.Status) bool { return s.State != state })
+	}
+	writeJSON(w, http.StatusOK, jobListResponse{Runner: h.jobs.Stats(), Jobs: jobs})
+}
+
+func (h *JobHandler) getJob(w http.ResponseWriter, r *http.Request) {
+	j, err := h.jobs.Get(r.PathValue("id"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newJobView(j))
+}
+
+// 취소는 요청만 받고 바로 돌아온다 (작업이 멈추면 cancelled 이벤트)
+func (h *JobHandler) cancelJob(w http.ResponseWriter, r *http.Request) {
+	j, err := h.jobs.Cancel(r.PathValue("id"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, newJobView(j))
+}
+
+// GET /api/jobs/{id}/events: 지금까지의 이벤트를 먼저 보내고 작업이 끝날 때까지 이어서 보낸다
+// 다시 연결할 때는 Last-Event-ID(또는 ?after=) 다음 이벤트부터
+func (h *JobHandler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	j, err := h.jobs.Get(r.PathValue("id"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeErrorMsg(w, http.StatusInternalServerError, "스트리밍을 지원하지 않는 연결입니다")
+		return
+	}
+
+	after := lastEventID(r)
+
+	// 스트림은 작업이 끝날 때까지 이어지므로 서버의 WriteTimeout을 적용하지 않는다
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		events, changed, finished := j.Events(after)
+		for _, e := range events {
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+			after = e.ID
+		}
+		flusher.Flush()
+		if finished {
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func lastEventID(r *http.Request) int {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("after")
+	}
+	n, _ := strconv.Atoi(v)
+	return n
+}
+
+func writeEvent(w http.ResponseWriter, e job.Event) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
+
+func newJobView(j *job.Job) jobView {
+	self := jobPath(j.ID())
+	return jobView{
+		Status: j.Status(),
+		Result: j.Result(),
+		Links:  map[string]string{"self": self, "events": self + "/events"},
+	}
+}
+
+func writeJobAccepted(w http.ResponseWriter, j *job.Job) {
+	w.Header().Set("Location", jobPath(j.ID()))
+	writeJSON(w, http.StatusAccepted, newJobView(j))
+}
+
+func jobPath(id string) string {
+	return jobsPrefix + "/" + id
+}
@@ -0,0 +1,66 @@
+package httpapi
+
+import (
+	"errors"
+
+	"github.com/meoraeng/lotto_simulator/internal/job"
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/backtest"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/session"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/wheel"
+	"github.com/meoraeng/lotto_simulator/internal/metrics"
+)
+
+var domainErrors = metrics.NewCounterVec(
+	"lotto_domain_errors_total",
+	"API가 응답한 도메인 에러 수 (에러 종류별, 알 수 없는 에러는 other)",
+	"error",
+)
+
+// 지표 레이블로 쓸 도메인 에러 이름 (writeDomainError가 다루는 에러와 같게 유지)
+var domainErrorNames = []struct {
+	err  error
+	name string
+}{
+	{commitreveal.ErrDrawNotFound, "commitreveal.ErrDrawNotFound"},
+	{commitreveal.ErrAlreadyRevealed, "commitreveal.ErrAlreadyRevealed"},
+	{commitreveal.ErrSalesOpen, "commitreveal.ErrSalesOpen"},
+	{commitreveal.ErrInvalidSalesClose, "commitreveal.ErrInvalidSalesClose"},
+	{commitreveal.ErrInvalidSeed, "commitreveal.ErrInvalidSeed"},
+	{commitreveal.ErrInvalidCommitment, "commitreveal.ErrInvalidCommitment"},
+	{commitreveal.ErrInvalidRound, "commitreveal.ErrInvalidRound"},
+	{job.ErrNotFound, "job.ErrNotFound"},
+	{job.ErrFinished, "job.ErrFinished"},
+	{job.ErrQueueFull, "job.ErrQueueFull"},
+	{job.ErrClosed, "job.ErrClosed"},
+	{session.ErrSimulationNotFound, "session.ErrSimulationNotFound"},
+	{session.ErrPlayerNotFound, "session.ErrPlayerNotFound"},
+	{session.ErrRoundNotFound, "session.ErrRoundNotFound"},
+	{session.ErrDuplicatePlayer, "session.ErrDuplicatePlayer"},
+	{session.ErrNoTickets, "session.ErrNoTickets"},
+	{session.ErrInvalidPlayer, "session.ErrInvalidPlayer"},
+	{session.ErrInvalidOrder, "session.ErrInvalidOrder"},
+	{session.ErrInvalidDraw, "session.ErrInvalidDraw"},
+	{session.ErrInvalidDrawSource, "session.ErrInvalidDrawSource"},
+	{lotto.ErrInvalidMode, "lotto.ErrInvalidMode"},
+	{lotto.ErrNegativeSales, "lotto.ErrNegativeSales"},
+	{lotto.ErrInvalidRollDown, "lotto.ErrInvalidRollDown"},
+	{lotto.ErrInvalidRank, "lotto.ErrInvalidRank"},
+	{profile.ErrUnknownProfile, "profile.ErrUnknownProfile"},
+	{backtest.ErrGridTooLarge, "backtest.ErrGridTooLarge"},
+	{wheel.ErrInvalidNumbers, "wheel.ErrInvalidNumbers"},
+	{wheel.ErrInvalidGuarantee, "wheel.ErrInvalidGuarantee"},
+	{wheel.ErrTooLarge, "wheel.ErrTooLarge"},
+	{wheel.ErrUnknownMethod, "wheel.ErrUnknownMethod"},
+}
+
+func domainErrorName(err error) string {
+	for _, e := range domainErrorNames {
+		if errors.Is(err, e.err) {
+			return e.name
+		}
+	}
+	return "other"
+}
@@ -0,0 +1,432 @@
+package httpapi
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/meoraeng/lotto_simulator/internal/apikey"
+	"github.com/meoraeng/lotto_simulator/internal/middleware"
+)
+
+// API 명세 원본. 사람이 읽기 쉬운 YAML로 관리하고 /api/openapi.json에서는 JSON으로 제공
+//
+//go:embed openapi.yaml
+var openAPIYAML []byte
+
+// 서버에 내장된 API 명세 (패키지 초기화 때 한 번 읽음, 읽지 못하면 프로그램 오류)
+var openAPI = mustLoadSpec(openAPIYAML)
+
+type apiSpec struct {
+	doc        map[string]any
+	json       []byte
+	operations []specOperation
+}
+
+// 명세의 경로 + 메서드 하나
+type specOperation struct {
+	method       string
+	path         string // 명세 경로 템플릿 (예: /api/draws/{id})
+	segments     []string
+	body         map[string]any // application/json 요청 본문 스키마 (없으면 nil)
+	bodyRequired bool
+	quota        string // x-quota: 요청 한도 종류 (없으면 standard)
+}
+
+func mustLoadSpec(src []byte) *apiSpec {
+	spec, err := loadSpec(src)
+	if err != nil {
+		panic(fmt.Sprintf("API 명세를 읽을 수 없습니다: %v", err))
+	}
+	return spec
+}
+
+func loadSpec(src []byte) (*apiSpec, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(src, &doc); err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &apiSpec{doc: doc, json: encoded}
+	paths, _ := doc["paths"].(map[string]any)
+	for path, item := range paths {
+		methods, _ := item.(map[string]any)
+		for method, v := range methods {
+			if method == "parameters" {
+				continue
+			}
+			op, _ := v.(map[string]any)
+			if op == nil {
+				return nil, fmt.Errorf("%s %s: 잘못된 operation입니다", method, path)
+			}
+
+			so := specOperation{
+				method:   strings.ToUpper(method),
+				path:     path,
+				segments: strings.Split(strings.TrimPrefix(path, "/"), "/"),
+				quota:    apikey.ClassStandard,
+			}
+			if quota, ok := op["x-quota"].(string); ok {
+				if !slices.Contains(apikey.Classes, quota) {
+					return nil, fmt.Errorf("%s %s: 알 수 없는 x-quota입니다: %q", method, path, quota)
+				}
+				so.quota = quota
+			}
+			if body, ok := op["requestBody"].(map[string]any); ok {
+				so.bodyRequired, _ = body["required"].(bool)
+				so.body = dig(body, "content", "application/json", "schema")
+			}
+			if so.body != nil {
+				if err := spec.checkRefs(so.body); err != nil {
+					return nil, fmt.Errorf("%s %s: %w", so.method, path, err)
+				}
+			}
+			spec.operations = append(spec.operations, so)
+		}
+	}
+
+	sort.Slice(spec.operations, func(i, j int) bool {
+		a, b := spec.operations[i], spec.operations[j]
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		return a.method < b.method
+	})
+	return spec, nil
+}
+
+// API 요청의 한도 종류 (명세의 x-quota). 명세에 없는 요청은 standard
+func QuotaClass(r *http.Request) string {
+	if op, ok := openAPI.operation(r.Method, r.URL.EscapedPath()); ok {
+		return op.quota
+	}
+	return apikey.ClassStandard
+}
+
+// 요청과 맞는 operation. 같은 경로에 여러 템플릿이 맞으면 고정 세그먼트가 많은 쪽
+// (/api/draws/verify가 /api/draws/{id}보다 우선)
+func (s *apiSpec) operation(method, path string) (specOperation, bool) {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	best, bestLiterals := specOperation{}, -1
+	for _, op := range s.operations {
+		if op.method != method || len(op.segments) != len(segments) {
+			continue
+		}
+		literals, ok := matchSegments(op.segments, segments)
+		if ok && literals > bestLiterals {
+			best, bestLiterals = op, literals
+		}
+	}
+	return best, bestLiterals >= 0
+}
+
+func matchSegments(template, segments []string) (int, bool) {
+	literals := 0
+	for i, t := range template {
+		if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") {
+			if segments[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+		if t != segments[i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, true
+}
+
+// 요청 본문을 명세로 검증한 뒤 핸들러 호출 (본문은 그대로 다시 읽을 수 있게 되돌려 놓음)
+// 명세에 없는 경로/메서드는 검증 없이 넘겨 핸들러의 404/405 처리를 따른다
+func (s *apiSpec) validateRequest(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		op, ok := s.operation(r.Method, r.URL.EscapedPath())
+		if !ok || op.body == nil {
+			next(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if middleware.IsBodyTooLarge(err) {
+			writeError(w, http.StatusRequestEntityTooLarge, "요청 본문이 너무 큽니다", err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "요청 본문을 읽을 수 없습니다", err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		if len(bytes.TrimSpace(body)) == 0 {
+			if op.bodyRequired {
+				writeErrorMsg(w, http.StatusBadRequest, "요청 본문이 필요합니다")
+				return
+			}
+			next(w, r)
+			return
+		}
+
+		if err := s.validateJSON(op.body, body); err != nil {
+			writeError(w, http.StatusBadRequest, "요청 본문이 API 명세와 맞지 않습니다", err)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *apiSpec) validateJSON(schema map[string]any, data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("유효하지 않은 JSON입니다: %w", err)
+	}
+	return s.validate(schema, v, "$")
+}
+
+// JSON 값 하나를 스키마로 검증 (이 명세가 쓰는 키워드만 지원)
+func (s *apiSpec) validate(schema map[string]any, v any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := s.resolve(ref)
+		if err != nil {
+			return err
+		}
+		return s.validate(target, v, path)
+	}
+
+	if types := schemaTypes(schema); len(types) > 0 && !typeAllowed(types, jsonType(v)) {
+		return fmt.Errorf("%s: %s 타입이어야 합니다 (%s)", path, strings.Join(types, " 또는 "), jsonType(v))
+	}
+	if enum, ok := schema["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return enumEqual(e, v) }) {
+		return fmt.Errorf("%s: 허용되지 않은 값입니다: %v (가능한 값: %v)", path, v, enum)
+	}
+
+	switch val := v.(type) {
+	case json.Number:
+		f, _ := val.Float64()
+		if min, ok := number(schema["minimum"]); ok && f < min {
+			return fmt.Errorf("%s: %v 이상이어야 합니다: %s", path, schema["minimum"], val)
+		}
+		if max, ok := number(schema["maximum"]); ok && f > max {
+			return fmt.Errorf("%s: %v 이하여야 합니다: %s", path, schema["maximum"], val)
+		}
+	case string:
+		if min, ok := number(schema["minLength"]); ok && float64(len([]rune(val))) < min {
+			return fmt.Errorf("%s: %v자 이상이어야 합니다", path, schema["minLength"])
+		}
+	case []any:
+		if min, ok := number(schema["minItems"]); ok && float64(len(val)) < min {
+			return fmt.Errorf("%s: 항목이 %v개 이상이어야 합니다 (%d개)", path, schema["minItems"], len(val))
+		}
+		if max, ok := number(schema["maxItems"]); ok && float64(len(val)) > max {
+			return fmt.Errorf("%s: 항목이 %v개 이하여야 합니다 (%d개)", path, schema["maxItems"], len(val))
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range val {
+				if err := s.validate(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]any:
+		return s.validateObject(schema, val, path)
+	}
+	return nil
+}
+
+func (s *apiSpec) validateObject(schema map[string]any, obj map[string]any, path string) error {
+	required, _ := schema["required"].([]any)
+	for _, name := range required {
+		if _, ok := obj[name.(string)]; !ok {
+			return fmt.Errorf("%s.%s: 필수 필드입니다", path, name)
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	names, _ := schema["propertyNames"].(map[string]any)
+	for _, key := range sortedKeys(obj) {
+		if names != nil {
+			if err := s.validate(names, key, path); err != nil {
+				return fmt.Errorf("%s: 허용되지 않은 키입니다: %q", path, key)
+			}
+		}
+
+		child := path + "." + key
+		if prop, ok := properties[key].(map[string]any); ok {
+			if err := s.validate(prop, obj[key], child); err != nil {
+				return err
+			}
+			continue
+		}
+		switch extra := schema["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				return fmt.Errorf("%s: 알 수 없는 필드입니다", child)
+			}
+		case map[string]any:
+			if err := s.validate(extra, obj[key], child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// "#/components/schemas/이름" 형식의 내부 참조
+func (s *apiSpec) resolve(ref string) (map[string]any, error) {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		return nil, fmt.Errorf("지원하지 않는 참조입니다: %s", ref)
+	}
+	schema := dig(s.doc, "components", "schemas", name)
+	if schema == nil {
+		return nil, fmt.Errorf("명세에 없는 스키마입니다: %s", ref)
+	}
+	return schema, nil
+}
+
+// 스키마가 참조하는 스키마가 모두 명세에 있는지 (로드 시점 확인)
+func (s *apiSpec) checkRefs(schema map[string]any) error {
+	seen := make(map[string]bool)
+
+	var walk func(v any) error
+	walk = func(v any) error {
+		switch node := v.(type) {
+		case map[string]any:
+			if ref, ok := node["$ref"].(string); ok {
+				if seen[ref] {
+					return nil
+				}
+				seen[ref] = true
+				target, err := s.resolve(ref)
+				if err != nil {
+					return err
+				}
+				return walk(target)
+			}
+			for _, child := range node {
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		case []any:
+			for _, child := range node {
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return walk(schema)
+}
+
+func dig(m map[string]any, keys ...string) map[string]any {
+	for _, key := range keys {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			return nil
+		}
+		m = next
+	}
+	return m
+}
+
+func schemaTypes(schema map[string]any) []string {
+	switch t := schema["type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, v := range t {
+			types = append(types, fmt.Sprint(v))
+		}
+		return types
+	}
+	return nil
+}
+
+// integer는 number에도 해당
+func typeAllowed(types []string, actual string) bool {
+	return slices.Contains(types, actual) || (actual == "integer" && slices.Contains(types, "number"))
+}
+
+func jsonType(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func enumEqual(e, v any) bool {
+	switch val := v.(type) {
+	case json.Number:
+		f, err := val.Float64()
+		n, ok := number(e)
+		return err == nil && ok && f == n
+	case string:
+		s, ok := e.(string)
+		return ok && s == val
+	}
+	return e == v
+}
+
+// YAML에서 읽은 숫자 키워드 값
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GET /api/openapi.json: 이 서버의 API 명세
+func (h *Handler) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorMsg(w, http.StatusMethodNotAllowed, "허용되지 않은 메서드입니다")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openAPI.json)
+}
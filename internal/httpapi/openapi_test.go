@@ -0,0 +1,558 @@
+package httpapi
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/apikey"
+	"github.com/meoraeng/lotto_simulator/internal/job"
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/backtest"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/session"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/wheel"
+)
+
+// 명세 스키마 이름 → 그 스키마로 주고받는 Go 타입
+var specTypes = map[string]reflect.Type{
+	"RoundMeta":               reflect.TypeFor[lotto.RoundMeta](),
+	"Allocation":              reflect.TypeFor[lotto.Allocation](),
+	"RollDownTarget":          reflect.TypeFor[lotto.RollDownTarget](),
+	"Draw":                    reflect.TypeFor[lotto.Draw](),
+	"RoundInput":              reflect.TypeFor[lotto.RoundInput](),
+	"LedgerEntry":             reflect.TypeFor[lotto.LedgerEntry](),
+	"Ledger":                  reflect.TypeFor[lotto.Ledger](),
+	"RoundOutput":             reflect.TypeFor[lotto.RoundOutput](),
+	"SeriesConfig":            reflect.TypeFor[lotto.SeriesConfig](),
+	"PlayerTickets":           reflect.TypeFor[playerRequest](),
+	"SeriesRequest":           reflect.TypeFor[seriesRequest](),
+	"SeriesRound":             reflect.TypeFor[lotto.SeriesRound](),
+	"SeriesResponse":          reflect.TypeFor[seriesResponse](),
+	"BacktestGrid":            reflect.TypeFor[backtest.Grid](),
+	"BacktestRequest":         reflect.TypeFor[backtestRequest](),
+	"RankDeviation":           reflect.TypeFor[backtest.RankDeviation](),
+	"BacktestRound":           reflect.TypeFor[backtest.RoundResult](),
+	"BacktestReport":          reflect.TypeFor[backtest.Report](),
+	"BacktestCandidate":       reflect.TypeFor[backtest.Candidate](),
+	"BacktestResponse":        reflect.TypeFor[backtestResponse](),
+	"Profile":                 reflect.TypeFor[profile.Profile](),
+	"ProfilesResponse":        reflect.TypeFor[profilesResponse](),
+	"Guarantee":               reflect.TypeFor[wheel.Guarantee](),
+	"WheelRequest":            reflect.TypeFor[wheel.Request](),
+	"WheelCoverage":           reflect.TypeFor[wheel.Coverage](),
+	"WheelResponse":           reflect.TypeFor[wheelResponse](),
+	"WheelVerifyRequest":      reflect.TypeFor[wheelVerifyRequest](),
+	"WheelVerifyResponse":     reflect.TypeFor[wheelVerifyResponse](),
+	"CommitRequest":           reflect.TypeFor[commitRequest](),
+	"CommitRevealDraw":        reflect.TypeFor[commitreveal.Draw](),
+	"VerifyRequest":           reflect.TypeFor[commitreveal.VerifyRequest](),
+	"Verification":            reflect.TypeFor[commitreveal.Verification](),
+	"CreateSimulationRequest": reflect.TypeFor[createSimulationRequest](),
+	"SealedDraw":              reflect.TypeFor[commitreveal.Sealed](),
+	"DrawProof":               reflect.TypeFor[commitreveal.Proof](),
+	"Simulation":              reflect.TypeFor[simulationView](),
+	"AddPlayerRequest":        reflect.TypeFor[addPlayerRequest](),
+	"Player":                  reflect.TypeFor[playerView](),
+	"TicketOrder":             reflect.TypeFor[session.Order](),
+	"TicketsResponse":         reflect.TypeFor[ticketsResponse](),
+	"DrawRequest":             reflect.TypeFor[drawRequest](),
+	"Round":                   reflect.TypeFor[session.Round](),
+	"RoundSummary":            reflect.TypeFor[roundSummary](),
+	"PlayerSettlement":        reflect.TypeFor[session.PlayerSettlement](),
+	"Settlement":              reflect.TypeFor[session.Settlement](),
+	"SimulationPage":          reflect.TypeFor[pageResponse[simulationView]](),
+	"PlayerPage":              reflect.TypeFor[pageResponse[playerView]](),
+	"RoundSummaryPage":        reflect.TypeFor[pageResponse[roundSummary]](),
+	"JobRunnerStats":          reflect.TypeFor[job.Stats](),
+	"JobList":                 reflect.TypeFor[jobListResponse](),
+	"JobStatus":               reflect.TypeFor[job.Status](),
+	"Job":                     reflect.TypeFor[jobView](),
+	"JobStep":                 reflect.TypeFor[job.Step](),
+	"MonteCarloRequest":       reflect.TypeFor[monteCarloRequest](),
+	"MonteCarloRound":         reflect.TypeFor[monteCarloRound](),
+	"MonteCarloPlayer":        reflect.TypeFor[monteCarloPlayer](),
+	"MonteCarloResult":        reflect.TypeFor[monteCarloResult](),
+}
+
+// Go 타입이 다른 패키지의 비공개 타입이라 비교하지 않는 스키마 (응답 검증으로 대신 확인)
+var specTypesSkipped = []string{"HistoryRecord"}
+
+func TestOpenAPISchemasMatchGoTypes(t *testing.T) {
+	schemas := dig(openAPI.doc, "components", "schemas")
+
+	for name := range schemas {
+		schema := dig(schemas, name)
+		if schema["additionalProperties"] != false || slices.Contains(specTypesSkipped, name) {
+			continue
+		}
+		if _, ok := specTypes[name]; !ok {
+			t.Errorf("스키마 %s에 대응하는 Go 타입이 specTypes에 없습니다", name)
+		}
+	}
+
+	for name, typ := range specTypes {
+		schema := dig(schemas, name)
+		if schema == nil {
+			t.Errorf("명세에 스키마 %s가 없습니다", name)
+			continue
+		}
+		properties := dig(schema, "properties")
+
+		fields := jsonFields(typ)
+		for field, ft := range fields {
+			prop := dig(properties, field)
+			if prop == nil {
+				t.Errorf("%s: Go 필드 %q가 명세에 없습니다 (%s)", name, field, typ)
+				continue
+			}
+			if want := kindTypes(ft); want != "" && !slices.Contains(openAPI.resolvedTypes(t, prop), want) {
+				t.Errorf("%s.%s: 명세 타입 %v에 Go 타입 %s(%s)가 없습니다", name, field, openAPI.resolvedTypes(t, prop), ft, want)
+			}
+		}
+		for prop := range properties {
+			if _, ok := fields[prop]; !ok {
+				t.Errorf("%s: 명세 필드 %q가 Go 타입 %s에 없습니다", name, prop, typ)
+			}
+		}
+	}
+}
+
+// 명세의 모든 경로/메서드가 실제로 등록돼 있는지 (mux의 404/405가 아닌지)
+func TestOpenAPIRoutesRegistered(t *testing.T) {
+	mux := newTestMux()
+
+	for _, op := range openAPI.operations {
+		path := strings.NewReplacer("{id}", "missing", "{name}", "missing", "{number}", "1").Replace(op.path)
+		rec := serve(mux, op.method, path, "")
+
+		if rec.Code == http.StatusMethodNotAllowed || rec.Body.String() == "404 page not found\n" {
+			t.Errorf("%s %s: 등록되지 않은 경로입니다 (%d %q)", op.method, op.path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+// 실제 응답 본문이 명세의 응답 스키마와 맞는지
+func TestOpenAPIResponsesMatchSpec(t *testing.T) {
+	mux := newTestMux()
+	history := `[
+		{"round": 1, "date": "2024-06-01", "numbers": [1, 2, 3, 4, 5, 6], "bonus": 7, "sales": 10000000000,
+		 "winners": {"1": 10, "2": 60, "3": 3000, "4": 150000, "5": 2500000},
+		 "prizes": {"1": 2000000000, "2": 50000000, "3": 1500000, "4": 50000, "5": 5000}}
+	]`
+
+	steps := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"GET", "/health", "", 200},
+		{"GET", "/metrics", "", 200},
+		{"GET", "/api/openapi.json", "", 200},
+		{"GET", "/api/profiles", "", 200},
+		{"POST", "/api/round?profile=" + profile.KR645Parimutuel, `{"sales": 1000000, "winners": {"5": 1, "1": 10}}`, 200},
+		{"POST", "/api/series?profile=" + profile.KR645Parimutuel, `{"players": [{"name": "A", "tickets": [[1, 2, 3, 4, 5, 6]]}], "draws": ` + history + `}`, 200},
+		{"POST", "/api/backtest?profile=" + profile.KR645Parimutuel, `{"history": ` + history + `, "optimize": true, "grid": {"rank1": [7500]}, "top": 1}`, 200},
+		{"POST", "/api/wheel", `{"method": "full", "numbers": [1, 2, 3, 4, 5, 6, 7], "guarantee": {"match": 3, "ifDrawn": 3}}`, 200},
+		{"POST", "/api/wheel/verify", `{"numbers": [1, 2, 3, 4, 5, 6, 7], "tickets": [[1, 2, 3, 4, 5, 6]], "guarantee": {"match": 3, "ifDrawn": 3}}`, 200},
+		{"POST", "/api/draws", `{"round": "LOTTO-1", "salesClose": "2099-01-01T00:00:00Z"}`, 201},
+		{"POST", "/api/draws", `{"round": "LOTTO-1", "salesClose": "2000-01-01T00:00:00Z"}`, 400},
+		{"GET", "/api/draws/missing", "", 404},
+		{"POST", "/api/v1/simulations", `{"firstRound": 1000, "firstDraw": "2024-06-01"}`, 201},
+		{"GET", "/api/v1/simulations", "", 200},
+		{"GET", "/api/v1/simulations/1", "", 200},
+		{"POST", "/api/v1/simulations/1/players", `{"name": "A"}`, 201},
+		{"GET", "/api/v1/simulations/1/players", "", 200},
+		{"POST", "/api/v1/simulations/1/players/A/tickets", `{"amount": 2000, "numbers": [[1, 2, 3, 4, 5, 6]]}`, 201},
+		{"GET", "/api/v1/simulations/1/players/A", "", 200},
+		{"POST", "/api/v1/simulations/1/rounds", `{"winningNumbers": [1, 2, 3, 4, 5, 6], "bonusNumber": 7}`, 201},
+		{"GET", "/api/v1/simulations/1/rounds", "", 200},
+		{"GET", "/api/v1/simulations/1/rounds/1", "", 200},
+		{"GET", "/api/v1/simulations/1/settlement", "", 200},
+		{"POST", "/api/v1/simulations/1/rounds", "", 409},
+		{"POST", "/api/v1/simulations", `{"drawSource": "commit-reveal"}`, 201},
+		{"POST", "/api/v1/simulations/2/players", `{"name": "A"}`, 201},
+		{"POST", "/api/v1/simulations/2/players/A/tickets", `{"amount": 1000}`, 201},
+		{"POST", "/api/v1/simulations/2/rounds", `{"winningNumbers": [1, 2, 3, 4, 5, 6], "bonusNumber": 7}`, 400},
+		{"POST", "/api/v1/simulations/2/rounds", "", 201},
+		{"POST", "/api/v1/simulations", `{"drawSource": "magic"}`, 400},
+	}
+
+	for _, st := range steps {
+		rec := serve(mux, st.method, st.path, st.body)
+		if rec.Code != st.status {
+			t.Fatalf("%s %s: 상태 코드 = %d, want %d (%s)", st.method, st.path, rec.Code, st.status, rec.Body.String())
+		}
+		checkResponse(t, st.method, st.path, rec)
+	}
+
+	// 추첨 ID는 응답에서 받아 공개/검증까지 이어 간다
+	// 판매 마감 전 공개는 409, 마감 뒤에는 공개할 수 있다
+	salesClose := time.Now().Add(50 * time.Millisecond)
+	rec := serve(mux, "POST", "/api/draws", `{"round": "LOTTO-2", "salesClose": "`+salesClose.Format(time.RFC3339Nano)+`"}`)
+	var draw commitreveal.Draw
+	if err := json.Unmarshal(rec.Body.Bytes(), &draw); err != nil {
+		t.Fatal(err)
+	}
+	if rec := serve(mux, "POST", "/api/draws/"+draw.ID+"/reveal", ""); rec.Code != http.StatusConflict {
+		t.Fatalf("마감 전 공개 상태 코드 = %d (%s)", rec.Code, rec.Body.String())
+	}
+	time.Sleep(time.Until(salesClose))
+	for _, path := range []string{"/api/draws/" + draw.ID, "/api/draws/" + draw.ID + "/reveal"} {
+		method := "GET"
+		if strings.HasSuffix(path, "/reveal") {
+			method = "POST"
+		}
+		rec := serve(mux, method, path, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s %s: 상태 코드 = %d (%s)", method, path, rec.Code, rec.Body.String())
+		}
+		checkResponse(t, method, path, rec)
+		if err := json.Unmarshal(rec.Body.Bytes(), &draw); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	verify, _ := json.Marshal(commitreveal.VerifyRequest{Round: draw.Round, Commitment: draw.Commitment, Seed: draw.Seed})
+	rec = serve(mux, "POST", "/api/draws/verify", string(verify))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("검증 상태 코드 = %d (%s)", rec.Code, rec.Body.String())
+	}
+	checkResponse(t, "POST", "/api/draws/verify", rec)
+
+	// 작업은 시작 응답의 ID로 이벤트 스트림(끝날 때까지)과 상태를 확인
+	starts := []struct{ path, body string }{
+		{"/api/jobs/series", `{"players": [{"name": "A", "tickets": [[1, 2, 3, 4, 5, 6]]}], "draws": ` + history + `}`},
+		{"/api/jobs/montecarlo?profile=" + profile.KR645Parimutuel, `{"players": [{"name": "A", "tickets": [[1, 2, 3, 4, 5, 6]]}], "rounds": 3, "seed": 1}`},
+	}
+	for _, st := range starts {
+		rec := serve(mux, "POST", st.path, st.body)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("POST %s: 상태 코드 = %d (%s)", st.path, rec.Code, rec.Body.String())
+		}
+		checkResponse(t, "POST", st.path, rec)
+
+		var started jobView
+		if err := json.Unmarshal(rec.Body.Bytes(), &started); err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range []string{started.Links["events"], started.Links["self"]} {
+			rec := serve(mux, "GET", path, "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("GET %s: 상태 코드 = %d (%s)", path, rec.Code, rec.Body.String())
+			}
+			checkResponse(t, "GET", path, rec)
+		}
+		rec = serve(mux, "DELETE", started.Links["self"], "")
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("끝난 작업 취소 상태 코드 = %d, want 409 (%s)", rec.Code, rec.Body.String())
+		}
+		checkResponse(t, "DELETE", started.Links["self"], rec)
+	}
+
+	for _, path := range []string{"/api/jobs", "/api/jobs?state=done"} {
+		rec := serve(mux, "GET", path, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: 상태 코드 = %d (%s)", path, rec.Code, rec.Body.String())
+		}
+		checkResponse(t, "GET", path, rec)
+	}
+}
+
+// 종료 중인 실행기는 새 작업을 503으로 거절한다
+func TestJobStartRejected(t *testing.T) {
+	jobs := job.NewManager(job.Config{})
+	if err := jobs.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	NewJobHandler(profile.NewRegistry(), jobs).Register(mux)
+
+	path := "/api/jobs/montecarlo"
+	rec := serve(mux, "POST", path, `{"rounds": 1}`)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("상태 코드 = %d, want 503 (%s)", rec.Code, rec.Body.String())
+	}
+	checkResponse(t, "POST", path, rec)
+}
+
+func TestValidateRequestBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		wantErr string // 비어 있으면 통과
+	}{
+		{"회차 입력", "POST", "/api/round", `{"mode": 1, "sales": 1000, "winners": {"5": 1}, "capPerRank": null}`, ""},
+		{"이름 표기", "POST", "/api/round", `{"mode": "parimutuel", "winners": {"rank1": 1, "none": 5}, "rollDownMethod": "equal", "allocations": [{"Rank": "rank1", "BasisPoints": 10000}]}`, ""},
+		{"알 수 없는 필드", "POST", "/api/round", `{"sale": 1000}`, "$.sale: 알 수 없는 필드"},
+		{"타입 불일치", "POST", "/api/round", `{"sales": "1000"}`, "$.sales: integer 타입"},
+		{"정수가 아닌 숫자", "POST", "/api/round", `{"sales": 1.5}`, "$.sales: integer 타입"},
+		{"없는 등수 키", "POST", "/api/round", `{"winners": {"6": 1}}`, `$.winners: 허용되지 않은 키입니다: "6"`},
+		{"허용되지 않은 모드", "POST", "/api/round", `{"mode": 2}`, "$.mode: 허용되지 않은 값"},
+		{"배정 비율 범위", "POST", "/api/round", `{"allocations": [{"Rank": 5, "BasisPoints": 10001}]}`, "$.allocations[0].BasisPoints: 10000 이하"},
+		{"필수 필드 누락", "POST", "/api/v1/simulations/1/players", `{}`, "$.name: 필수 필드"},
+		{"번호 범위", "POST", "/api/v1/simulations/1/players/A/tickets", `{"numbers": [[1, 2, 3, 4, 5, 46]]}`, "$.numbers[0][5]: 45 이하"},
+		{"번호 개수", "POST", "/api/v1/simulations/1/rounds", `{"winningNumbers": [1, 2, 3]}`, "$.winningNumbers: 항목이 6개 이상"},
+		{"휠 방식", "POST", "/api/wheel", `{"method": "random", "numbers": [1, 2, 3, 4, 5, 6, 7]}`, "$.method: 허용되지 않은 값"},
+		{"과거 기록 등수 키", "POST", "/api/series", `{"draws": [{"round": 1, "date": "2024-06-01", "numbers": [1, 2, 3, 4, 5, 6], "bonus": 7, "winners": {"0": 1}}]}`, `$.draws[0].winners: 허용되지 않은 키입니다: "0"`},
+		{"루트가 객체가 아님", "POST", "/api/draws", `[]`, "$: object 타입"},
+		{"빈 회차 이름", "POST", "/api/draws/verify", `{"round": "", "commitment": "", "seed": ""}`, "$.round: 1자 이상"},
+		{"몬테카를로 회차 수", "POST", "/api/jobs/montecarlo", `{"rounds": 10001}`, "$.rounds: 10000 이하"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, ok := openAPI.operation(tt.method, tt.path)
+			if !ok || op.body == nil {
+				t.Fatalf("%s %s: 요청 본문이 있는 operation을 찾지 못했습니다", tt.method, tt.path)
+			}
+
+			err := openAPI.validateJSON(op.body, []byte(tt.body))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("에러 = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("에러 = %v, want %q 포함", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRequestMiddleware(t *testing.T) {
+	mux := newTestMux()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"명세 위반은 핸들러 전에 400", "POST", "/api/draws", `{"round": "A", "salesClose": "2099-01-01T00:00:00Z", "extra": 1}`, 400, "[ERROR] 요청 본문이 API 명세와 맞지 않습니다: $.extra"},
+		{"필수 본문 누락", "POST", "/api/wheel", "", 400, "[ERROR] 요청 본문이 필요합니다"},
+		{"선택 본문 생략", "POST", "/api/v1/simulations", "", 201, ""},
+		{"검증 후 핸들러가 본문을 다시 읽음", "POST", "/api/v1/simulations", `{"gameId": "test"}`, 201, `"gameId":"test"`},
+		{"명세에 없는 메서드는 핸들러의 405", "PUT", "/api/round", `{"x": 1}`, 405, "[ERROR] 허용되지 않은 메서드입니다"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(mux, tt.method, tt.path, tt.body)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("상태 코드 = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("본문 = %q, want %q 포함", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestSpecOperation(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string // 비어 있으면 없음
+	}{
+		{"POST", "/api/draws/verify", "/api/draws/verify"},
+		{"GET", "/api/draws/abc", "/api/draws/{id}"},
+		{"POST", "/api/draws/abc/reveal", "/api/draws/{id}/reveal"},
+		{"POST", "/api/v1/simulations/1/players/%EA%B9%80/tickets", "/api/v1/simulations/{id}/players/{name}/tickets"},
+		{"GET", "/api/v1/simulations/1/rounds/3", "/api/v1/simulations/{id}/rounds/{number}"},
+		{"POST", "/api/jobs/series", "/api/jobs/series"},
+		{"GET", "/api/jobs/abc/events", "/api/jobs/{id}/events"},
+		{"DELETE", "/api/v1/simulations/1", ""},
+		{"GET", "/api/v1/simulations/", ""},
+	}
+
+	for _, tt := range tests {
+		op, ok := openAPI.operation(tt.method, tt.path)
+		if got := op.path; !ok && tt.want != "" || ok && got != tt.want {
+			t.Errorf("operation(%s %s) = %q, %v, want %q", tt.method, tt.path, got, ok, tt.want)
+		}
+	}
+}
+
+func newTestMux() *http.ServeMux {
+	profiles := profile.NewRegistry()
+	mux := http.NewServeMux()
+	NewHandler(profiles).Register(mux)
+	NewV1Handler(profiles, session.NewMemoryStore()).Register(mux)
+	NewJobHandler(profiles, job.NewManager(job.Config{})).Register(mux)
+	return mux
+}
+
+func serve(mux http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+// 응답 상태 코드에 해당하는 명세 응답의 JSON 스키마로 본문 검증
+func checkResponse(t *testing.T, method, path string, rec *httptest.ResponseRecorder) {
+	t.Helper()
+
+	route, _, _ := strings.Cut(path, "?")
+	op, ok := openAPI.operation(method, route)
+	if !ok {
+		t.Fatalf("%s %s: 명세에 없는 요청입니다", method, path)
+	}
+	responses := dig(openAPI.doc, "paths", op.path, strings.ToLower(method), "responses")
+	resp := dig(responses, strconv.Itoa(rec.Code))
+	if resp == nil {
+		t.Fatalf("%s %s: 명세에 %d 응답이 없습니다", method, op.path, rec.Code)
+	}
+	if ref, ok := resp["$ref"].(string); ok {
+		resp = dig(openAPI.doc, "components", "responses", strings.TrimPrefix(ref, "#/components/responses/"))
+	}
+
+	schema := dig(resp, "content", "application/json", "schema")
+	if schema == nil {
+		ct := rec.Header().Get("Content-Type")
+		if ct == "" {
+			ct = http.DetectContentType(rec.Body.Bytes()) // 실제 서버가 채우는 값
+		}
+		mediaType, _, _ := strings.Cut(ct, ";")
+		if dig(resp, "content", strings.TrimSpace(mediaType)) == nil {
+			t.Errorf("%s %s: %d 응답의 본문 형식 %q가 명세에 없습니다", method, op.path, rec.Code, mediaType)
+		}
+		return
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s %s: Content-Type = %q, want application/json", method, path, ct)
+	}
+	if err := openAPI.validateJSON(schema, rec.Body.Bytes()); err != nil {
+		t.Errorf("%s %s: 응답이 명세와 맞지 않습니다: %v", method, path, err)
+	}
+	headers := dig(resp, "headers")
+	for name := range headers {
+		header := dig(headers, name)
+		if ref, ok := header["$ref"].(string); ok {
+			header = dig(openAPI.doc, "components", "headers", strings.TrimPrefix(ref, "#/components/headers/"))
+		}
+		if header["required"] == true && rec.Header().Get(name) == "" {
+			t.Errorf("%s %s: 명세의 %s 헤더가 없습니다", method, path, name)
+		}
+	}
+}
+
+// JSON 필드 이름 → 필드 타입 (태그가 없으면 필드 이름, "-"는 제외, 태그 없이 임베드한 구조체는 펼침)
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			maps.Copy(fields, jsonFields(f.Type))
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// Go 타입이 직렬화되는 JSON 타입 (비교하지 않을 타입은 "")
+func kindTypes(typ reflect.Type) string {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch {
+	case typ == reflect.TypeFor[json.RawMessage]():
+		return ""
+	case typ.Implements(reflect.TypeFor[encoding.TextMarshaler]()):
+		return "string"
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return ""
+}
+
+// $ref를 따라간 스키마의 type 목록
+func (s *apiSpec) resolvedTypes(t *testing.T, schema map[string]any) []string {
+	t.Helper()
+	for {
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			break
+		}
+		target, err := s.resolve(ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		schema = target
+	}
+	types := schemaTypes(schema)
+	sort.Strings(types)
+	return types
+}
+
+// CPU를 많이 쓰는 API는 명세의 x-quota로 한도를 따로 센다
+func TestQuotaClass(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{"POST", "/api/round", apikey.ClassStandard},
+		{"POST", "/api/series", apikey.ClassHeavy},
+		{"POST", "/api/backtest", apikey.ClassHeavy},
+		{"POST", "/api/jobs/montecarlo", apikey.ClassHeavy},
+		{"POST", "/api/wheel", apikey.ClassHeavy},
+		{"POST", "/api/wheel/verify", apikey.ClassHeavy},
+		{"POST", "/api/v1/simulations/abc/players/kim/tickets", apikey.ClassHeavy},
+		{"POST", "/api/v1/simulations/abc/rounds", apikey.ClassHeavy},
+		{"GET", "/api/v1/simulations/abc/rounds", apikey.ClassStandard},
+		{"POST", "/api/draws/verify", apikey.ClassStandard},
+		{"GET", "/api/jobs/abc", apikey.ClassStandard},
+		{"GET", "/not-in-spec", apikey.ClassStandard},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if got := QuotaClass(req); got != tt.want {
+			t.Errorf("%s %s: 한도 종류 = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package httpapi
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// ?page=1&pageSize=20 (page는 1부터)
+type pageQuery struct {
+	Page     int
+	PageSize int
+}
+
+func (p pageQuery) offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// 목록 응답 공통 형식
+type pageResponse[T any] struct {
+	Items      []T `json:"items"`
+	Page       int `json:"page"`
+	PageSize   int `json:"pageSize"`
+	Total      int `json:"total"`
+	TotalPages int `json:"totalPages"`
+}
+
+func parsePageQuery(r *http.Request) (pageQuery, error) {
+	p := pageQuery{Page: 1, PageSize: defaultPageSize}
+	q := r.URL.Query()
+
+	if v := q.Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return pageQuery{}, fmt.Errorf("page는 1 이상의 정수여야 합니다: %q", v)
+		}
+		p.Page = n
+	}
+	if v := q.Get("pageSize"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageSize {
+			return pageQuery{}, fmt.Errorf("pageSize는 1~%d 사이의 정수여야 합니다: %q", maxPageSize, v)
+		}
+		p.PageSize = n
+	}
+	// 시작 위치 (page-1)*pageSize가 int를 넘지 않게
+	if p.Page > math.MaxInt/p.PageSize {
+		return pageQuery{}, fmt.Errorf("page가 너무 큽니다: %d", p.Page)
+	}
+	return p, nil
+}
+
+// 이미 메모리에 있는 목록을 잘라 한 페이지로
+func paginate[T any](items []T, p pageQuery) pageResponse[T] {
+	start := min(max(p.offset(), 0), len(items))
+	end := min(start+p.PageSize, len(items))
+	return newPage(items[start:end], p, len(items))
+}
+
+func newPage[T any](items []T, p pageQuery, total int) pageResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return pageResponse[T]{
+		Items:      items,
+		Page:       p.Page,
+		PageSize:   p.PageSize,
+		Total:      total,
+		TotalPages: (total + p.PageSize - 1) / p.PageSize,
+	}
+}
+
+// 목록 응답을 쓰면서 Link(rel=prev/next)와 X-Total-Count 헤더를 붙인다
+func writePage[T any](w http.ResponseWriter, r *http.Request, page pageResponse[T]) {
+	var links []string
+	if page.Page > 1 {
+		links = append(links, pageLink(r.URL, page.Page-1, page.PageSize, "prev"))
+	}
+	if page.Page < page.TotalPages {
+		links = append(links, pageLink(r.URL, page.Page+1, page.PageSize, "next"))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+	writeJSON(w, http.StatusOK, page)
+}
+
+func pageLink(u *url.URL, page, pageSize int, rel string) string {
+	q := u.Query()
+	q.Set("page", strconv.Itoa(page))
+	q.Set("pageSize", strconv.Itoa(pageSize))
+	return fmt.Sprintf("<%s?%s>; rel=%q", u.Path, q.Encode(), rel)
+}
@@ -0,0 +1,60 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// 시작 위치가 int를 넘는 page는 400으로 거절하고, 범위 밖 page는 빈 페이지를 돌려주는지 검증
+func TestPageQueryOverflow(t *testing.T) {
+	mux := newTestMux()
+	setup := []struct{ method, path, body string }{
+		{"POST", "/api/v1/simulations", `{}`},
+		{"POST", "/api/v1/simulations/1/players", `{"name": "A"}`},
+		{"POST", "/api/v1/simulations/1/players/A/tickets", `{"amount": 1000}`},
+		{"POST", "/api/v1/simulations/1/rounds", `{"winningNumbers": [1, 2, 3, 4, 5, 6], "bonusNumber": 7}`},
+	}
+	for _, st := range setup {
+		if rec := serve(mux, st.method, st.path, st.body); rec.Code >= 300 {
+			t.Fatalf("%s %s: 상태 코드 = %d (%s)", st.method, st.path, rec.Code, rec.Body.String())
+		}
+	}
+
+	huge := strconv.Itoa(math.MaxInt)
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"int 최댓값 page", "?page=" + huge, http.StatusBadRequest},
+		{"pageSize를 곱하면 넘치는 page", "?pageSize=100&page=" + strconv.Itoa(math.MaxInt/100+1), http.StatusBadRequest},
+		{"넘치지 않는 가장 큰 page", "?pageSize=100&page=" + strconv.Itoa(math.MaxInt/100), http.StatusOK},
+		{"목록보다 뒤의 page", "?page=1000", http.StatusOK},
+	}
+
+	for _, path := range []string{"/api/v1/simulations", "/api/v1/simulations/1/players", "/api/v1/simulations/1/rounds"} {
+		for _, tt := range tests {
+			t.Run(path+" "+tt.name, func(t *testing.T) {
+				rec := serve(mux, "GET", path+tt.query, "")
+				if rec.Code != tt.status {
+					t.Fatalf("상태 코드 = %d, want %d (%s)", rec.Code, tt.status, rec.Body.String())
+				}
+				checkResponse(t, "GET", path, rec)
+				if tt.status != http.StatusOK {
+					return
+				}
+
+				var page pageResponse[json.RawMessage]
+				if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+					t.Fatal(err)
+				}
+				if len(page.Items) != 0 {
+					t.Errorf("범위 밖 page는 비어 있어야 합니다. got=%d개", len(page.Items))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+)
+
+type profilesResponse struct {
+	Profiles []profile.Profile `json:"profiles"`
+}
+
+// 선택 가능한 게임 규칙 프로필 목록
+func (h *Handler) handleProfiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorMsg(w, http.StatusMethodNotAllowed, "허용되지 않은 메서드입니다")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(profilesResponse{Profiles: h.profiles.List()}); err != nil {
+		writeError(w, http.StatusInternalServerError, "결과 인코딩에 실패했습니다", err)
+		return
+	}
+}
+
+// ?profile=이름 으로 지정한 프로필. 지정하지 않았으면 ok=false
+func queryProfile(profiles *profile.Registry, r *http.Request) (profile.Profile, bool, error) {
+	name := r.URL.Query().Get("profile")
+	if name == "" {
+		return profile.Profile{}, false, nil
+	}
+
+	game, err := profiles.Get(name)
+	if err != nil {
+		return profile.Profile{}, false, err
+	}
+	return game, true, nil
+}
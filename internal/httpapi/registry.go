@@ -1,8 +1,9 @@
 package httpapi
 
-import "net/http"
+import "github.com/meoraeng/lotto_simulator/internal/middleware"
 
 // HTTP 핸들러 등록을 위한 공통 인터페이스
+// mux는 *http.ServeMux 또는 미들웨어를 씌운 middleware.Wrap(mux, ...)
 type RouteRegistrar interface {
-	Register(mux *http.ServeMux)
+	Register(mux middleware.Router)
 }
@@ -0,0 +1,127 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/history"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+)
+
+// 여러 회차 시리즈 요청
+// draws는 과거 기록 가져오기 JSON 형식과 같다 (round, date, numbers, bonus)
+// 플레이어는 둘째 회차부터 같은 장수를 자동 번호로 다시 산다. keepNumbers면 첫 회차 번호를 계속 쓴다
+type seriesRequest struct {
+	Config      lotto.SeriesConfig `json:"config"`
+	Players     []playerRequest    `json:"players"`
+	Draws       json.RawMessage    `json:"draws"`
+	CarryIn     map[lotto.Rank]int `json:"carryIn"`
+	KeepNumbers bool               `json:"keepNumbers"`
+	Seed        int64              `json:"seed"` // 0이 아니면 같은 자동 번호를 재현
+}
+
+type playerRequest struct {
+	Name    string  `json:"name"`
+	Tickets [][]int `json:"tickets"`
+}
+
+type seriesResponse struct {
+	Rounds []lotto.SeriesRound `json:"rounds"`
+}
+
+func (h *Handler) handleSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorMsg(w, http.StatusMethodNotAllowed, "허용되지 않은 메서드입니다")
+		return
+	}
+
+	s, ok := decodeSeries(w, r, h.profiles)
+	if !ok {
+		return
+	}
+
+	rounds, err := lotto.SimulateDrawSeries(s.config, s.players, s.draws, s.carryIn, s.repurchase)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(seriesResponse{Rounds: rounds}); err != nil {
+		writeError(w, http.StatusInternalServerError, "결과 인코딩에 실패했습니다", err)
+		return
+	}
+}
+
+// 해석을 마친 시리즈 입력 (동기 실행과 비동기 작업이 함께 사용)
+type seriesInput struct {
+	config     lotto.SeriesConfig
+	players    []lotto.Player
+	draws      []lotto.Draw
+	carryIn    map[lotto.Rank]int
+	repurchase lotto.Repurchase // nil이면 첫 회차 번호 유지
+}
+
+// 본문과 ?profile=을 시리즈 입력으로 해석. 실패하면 에러 응답을 쓰고 ok=false
+func decodeSeries(w http.ResponseWriter, r *http.Request, profiles *profile.Registry) (seriesInput, bool) {
+	var req seriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return seriesInput{}, false
+	}
+
+	game, ok, err := queryProfile(profiles, r)
+	if err != nil {
+		writeDomainError(w, err)
+		return seriesInput{}, false
+	}
+	if ok {
+		req.Config = game.SeriesConfig()
+	}
+
+	players, err := req.players()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 티켓입니다", err)
+		return seriesInput{}, false
+	}
+
+	records, err := history.ReadJSON(bytes.NewReader(req.Draws), lotto.DefaultGameID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 추첨 결과입니다", err)
+		return seriesInput{}, false
+	}
+
+	s := seriesInput{config: req.Config, players: players, draws: history.Draws(records), carryIn: req.CarryIn}
+	if !req.KeepNumbers {
+		var src lotto.NumberSource = lotto.DefaultSource
+		if req.Seed != 0 {
+			src = rand.New(rand.NewSource(req.Seed))
+		}
+		s.repurchase = lotto.RandomRepurchase(src)
+	}
+	return s, true
+}
+
+func (req seriesRequest) players() ([]lotto.Player, error) {
+	players := make([]lotto.Player, 0, len(req.Players))
+	for _, p := range req.Players {
+		if p.Name == "" {
+			return nil, errors.New("플레이어 이름은 비울 수 없습니다")
+		}
+
+		tickets := make([]lotto.Lotto, 0, len(p.Tickets))
+		for _, nums := range p.Tickets {
+			ticket, err := lotto.NewLotto(nums)
+			if err != nil {
+				return nil, err
+			}
+			tickets = append(tickets, ticket)
+		}
+		players = append(players, lotto.Player{Name: p.Name, Tickets: tickets})
+	}
+	return players, nil
+}
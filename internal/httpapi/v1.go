@@ -0,0 +1,423 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/session"
+	"github.com/meoraeng/lotto_simulator/internal/middleware"
+)
+
+const v1Prefix = "/api/v1"
+
+// /api/v1 시뮬레이션 리소스 API
+//
+//	POST /api/v1/simulations                              시뮬레이션 생성
+//	GET  /api/v1/simulations                              목록 (페이지)
+//	GET  /api/v1/simulations/{id}                         조회
+//	POST /api/v1/simulations/{id}/players                 플레이어 등록
+//	GET  /api/v1/simulations/{id}/players                 플레이어 목록 (페이지)
+//	GET  /api/v1/simulations/{id}/players/{name}          플레이어 조회
+//	POST /api/v1/simulations/{id}/players/{name}/tickets  다음 회차 티켓 구매
+//	POST /api/v1/simulations/{id}/rounds                  추첨 실행
+//	GET  /api/v1/simulations/{id}/rounds                  회차 목록 (페이지)
+//	GET  /api/v1/simulations/{id}/rounds/{number}         회차 조회
+//	GET  /api/v1/simulations/{id}/settlement              정산
+type V1Handler struct {
+	profiles *profile.Registry
+	store    session.Store
+}
+
+func NewV1Handler(profiles *profile.Registry, store session.Store) *V1Handler {
+	return &V1Handler{profiles: profiles, store: store}
+}
+
+// 요청 본문은 API 명세(openapi.yaml)로 먼저 검증
+func (h *V1Handler) Register(mux middleware.Router) {
+	handle := func(pattern string, fn http.HandlerFunc) {
+		mux.HandleFunc(pattern, openAPI.validateRequest(fn))
+	}
+
+	handle("POST "+v1Prefix+"/simulations", h.createSimulation)
+	handle("GET "+v1Prefix+"/simulations", h.listSimulations)
+	handle("GET "+v1Prefix+"/simulations/{id}", h.getSimulation)
+	handle("POST "+v1Prefix+"/simulations/{id}/players", h.addPlayer)
+	handle("GET "+v1Prefix+"/simulations/{id}/players", h.listPlayers)
+	handle("GET "+v1Prefix+"/simulations/{id}/players/{name}", h.getPlayer)
+	handle("POST "+v1Prefix+"/simulations/{id}/players/{name}/tickets", h.buyTickets)
+	handle("POST "+v1Prefix+"/simulations/{id}/rounds", h.runDraw)
+	handle("GET "+v1Prefix+"/simulations/{id}/rounds", h.listRounds)
+	handle("GET "+v1Prefix+"/simulations/{id}/rounds/{number}", h.getRound)
+	handle("GET "+v1Prefix+"/simulations/{id}/settlement", h.getSettlement)
+}
+
+type createSimulationRequest struct {
+	Profile    string     `json:"profile"` // 비어 있으면 모드별 기본 프로필
+	Mode       lotto.Mode `json:"mode"`
+	GameID     string     `json:"gameId"`
+	FirstRound int        `json:"firstRound"`
+	FirstDraw  string     `json:"firstDraw"` // YYYY-MM-DD 또는 YYYY-MM-DD HH:MM (매주 반복)
+
+	DrawSource session.DrawSource `json:"drawSource"` // 비어 있으면 random
+}
+
+type simulationView struct {
+	ID             string             `json:"id"`
+	Profile        string             `json:"profile"`
+	Mode           lotto.Mode         `json:"mode"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	Players        []string           `json:"players"`
+	Rounds         int                `json:"rounds"`
+	PendingTickets int                `json:"pendingTickets"`
+	NextRound      lotto.RoundMeta    `json:"nextRound"`
+	CarryOut       map[lotto.Rank]int `json:"carryOut"`
+	Links          map[string]string  `json:"links"`
+
+	DrawSource     session.DrawSource   `json:"drawSource"`
+	NextCommitment *commitreveal.Sealed `json:"nextCommitment,omitempty"` // 다음 회차 판매 전에 공개하는 커밋값
+}
+
+type playerView struct {
+	Name    string  `json:"name"`
+	Tickets [][]int `json:"tickets"` // 다음 추첨에 참여할 티켓
+	Spent   int     `json:"spent"`
+	Won     int     `json:"won"`
+}
+
+type ticketsResponse struct {
+	Tickets [][]int    `json:"tickets"` // 이번에 구매한 티켓
+	Cost    int        `json:"cost"`
+	Player  playerView `json:"player"`
+}
+
+type roundSummary struct {
+	Number         int                `json:"number"`
+	Meta           lotto.RoundMeta    `json:"meta"`
+	WinningNumbers []int              `json:"winningNumbers"`
+	BonusNumber    int                `json:"bonusNumber"`
+	Sales          int                `json:"sales"`
+	Winners        map[lotto.Rank]int `json:"winners"`
+	Paid           int                `json:"paid"`
+	Link           string             `json:"link"`
+}
+
+func (h *V1Handler) createSimulation(w http.ResponseWriter, r *http.Request) {
+	var req createSimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return
+	}
+
+	game, err := h.profiles.Resolve(req.Profile, req.Mode)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	var firstDraw time.Time
+	if req.FirstDraw != "" {
+		if firstDraw, err = lotto.ParseDrawDate(req.FirstDraw); err != nil {
+			writeError(w, http.StatusBadRequest, "잘못된 입력입니다", err)
+			return
+		}
+	}
+	gameID := req.GameID
+	if gameID == "" {
+		gameID = lotto.DefaultGameID
+	}
+
+	sim := session.New(game, lotto.WeeklyCalendar(gameID, req.FirstRound, firstDraw))
+	if err := sim.UseDrawSource(req.DrawSource); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	sim, err = h.store.Create(sim)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", simulationPath(sim.ID))
+	writeJSON(w, http.StatusCreated, newSimulationView(sim))
+}
+
+func (h *V1Handler) listSimulations(w http.ResponseWriter, r *http.Request) {
+	p, err := parsePageQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "잘못된 페이지 요청입니다", err)
+		return
+	}
+
+	sims, total, err := h.store.List(p.offset(), p.PageSize)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	views := make([]simulationView, 0, len(sims))
+	for _, sim := range sims {
+		views = append(views, newSimulationView(sim))
+	}
+	writePage(w, r, newPage(views, p, total))
+}
+
+func (h *V1Handler) getSimulation(w http.ResponseWriter, r *http.Request) {
+	sim, err := h.store.Get(r.PathValue("id"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSimulationView(sim))
+}
+
+type addPlayerRequest struct {
+	Name string `json:"name"`
+}
+
+func (h *V1Handler) addPlayer(w http.ResponseWriter, r *http.Request) {
+	var req addPlayerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return
+	}
+
+	var added session.Player
+	sim, err := h.store.Update(r.PathValue("id"), func(sim *session.Simulation) error {
+		var err error
+		added, err = sim.AddPlayer(req.Name)
+		return err
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", playerPath(sim.ID, added.Name))
+	writeJSON(w, http.StatusCreated, newPlayerView(added))
+}
+
+func (h *V1Handler) listPlayers(w http.ResponseWriter, r *http.Request) {
+	p, err := parsePageQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "잘못된 페이지 요청입니다", err)
+		return
+	}
+	sim, err := h.store.Get(r.PathValue("id"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	views := make([]playerView, 0, len(sim.Players))
+	for _, player := range sim.Players {
+		views = append(views, newPlayerView(player))
+	}
+	writePage(w, r, paginate(views, p))
+}
+
+func (h *V1Handler) getPlayer(w http.ResponseWriter, r *http.Request) {
+	sim, err := h.store.Get(r.PathValue("id"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	player, err := sim.Player(r.PathValue("name"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newPlayerView(player))
+}
+
+func (h *V1Handler) buyTickets(w http.ResponseWriter, r *http.Request) {
+	var order session.Order
+	if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return
+	}
+
+	name := r.PathValue("name")
+	var bought []lotto.Lotto
+	sim, err := h.store.Update(r.PathValue("id"), func(sim *session.Simulation) error {
+		var err error
+		bought, err = sim.BuyTickets(name, order)
+		return err
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	player, _ := sim.Player(name)
+
+	w.Header().Set("Location", playerPath(sim.ID, name))
+	writeJSON(w, http.StatusCreated, ticketsResponse{
+		Tickets: ticketNumbers(bought),
+		Cost:    len(bought) * lotto.LottoPrice,
+		Player:  newPlayerView(player),
+	})
+}
+
+// 본문이 없으면 무작위 추첨, 있으면 그 번호로 추첨
+type drawRequest struct {
+	WinningNumbers []int `json:"winningNumbers"`
+	BonusNumber    int   `json:"bonusNumber"`
+}
+
+func (h *V1Handler) runDraw(w http.ResponseWriter, r *http.Request) {
+	var req drawRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return
+	}
+	var numbers *lotto.Draw
+	if len(req.WinningNumbers) > 0 || req.BonusNumber != 0 {
+		numbers = &lotto.Draw{WinningNumbers: req.WinningNumbers, BonusNumber: req.BonusNumber}
+	}
+
+	var round session.Round
+	sim, err := h.store.Update(r.PathValue("id"), func(sim *session.Simulation) error {
+		var err error
+		round, err = sim.Draw(numbers)
+		return err
+	})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", roundPath(sim.ID, round.Number))
+	writeJSON(w, http.StatusCreated, round)
+}
+
+func (h *V1Handler) listRounds(w http.ResponseWriter, r *http.Request) {
+	p, err := parsePageQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "잘못된 페이지 요청입니다", err)
+		return
+	}
+	sim, err := h.store.Get(r.PathValue("id"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	summaries := make([]roundSummary, 0, len(sim.Rounds))
+	for _, round := range sim.Rounds {
+		summaries = append(summaries, newRoundSummary(sim.ID, round))
+	}
+	writePage(w, r, paginate(summaries, p))
+}
+
+func (h *V1Handler) getRound(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "찾을 수 없습니다", session.ErrRoundNotFound)
+		return
+	}
+	sim, err := h.store.Get(r.PathValue("id"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	round, err := sim.Round(number)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, round)
+}
+
+func (h *V1Handler) getSettlement(w http.ResponseWriter, r *http.Request) {
+	sim, err := h.store.Get(r.PathValue("id"))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sim.Settlement())
+}
+
+func newSimulationView(sim *session.Simulation) simulationView {
+	names := make([]string, 0, len(sim.Players))
+	for _, p := range sim.Players {
+		names = append(names, p.Name)
+	}
+
+	self := simulationPath(sim.ID)
+	return simulationView{
+		ID:             sim.ID,
+		Profile:        sim.Profile.Name,
+		Mode:           sim.Profile.Mode,
+		CreatedAt:      sim.CreatedAt,
+		Players:        names,
+		Rounds:         len(sim.Rounds),
+		PendingTickets: sim.PendingTickets(),
+		NextRound:      sim.NextRound(),
+		CarryOut:       sim.CarryOut,
+		DrawSource:     sim.DrawSource,
+		NextCommitment: sim.NextCommitment,
+		Links: map[string]string{
+			"self":       self,
+			"players":    self + "/players",
+			"rounds":     self + "/rounds",
+			"settlement": self + "/settlement",
+		},
+	}
+}
+
+func newPlayerView(p session.Player) playerView {
+	return playerView{Name: p.Name, Tickets: ticketNumbers(p.Tickets), Spent: p.Spent, Won: p.Won}
+}
+
+func newRoundSummary(simID string, round session.Round) roundSummary {
+	paid := 0
+	for _, v := range round.Payouts {
+		paid += v
+	}
+	return roundSummary{
+		Number:         round.Number,
+		Meta:           round.Draw.Meta,
+		WinningNumbers: round.Draw.WinningNumbers,
+		BonusNumber:    round.Draw.BonusNumber,
+		Sales:          round.Sales,
+		Winners:        round.Winners,
+		Paid:           paid,
+		Link:           roundPath(simID, round.Number),
+	}
+}
+
+func ticketNumbers(tickets []lotto.Lotto) [][]int {
+	numbers := make([][]int, 0, len(tickets))
+	for _, t := range tickets {
+		numbers = append(numbers, t.Numbers)
+	}
+	return numbers
+}
+
+func simulationPath(id string) string {
+	return v1Prefix + "/simulations/" + url.PathEscape(id)
+}
+
+func playerPath(id, name string) string {
+	return simulationPath(id) + "/players/" + url.PathEscape(name)
+}
+
+func roundPath(id string, number int) string {
+	return simulationPath(id) + "/rounds/" + strconv.Itoa(number)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		writeError(w, http.StatusInternalServerError, "결과 인코딩에 실패했습니다", err)
+		return
+	}
+}
@@ -0,0 +1,111 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/wheel"
+)
+
+// 휠 생성 결과. guarantee를 보냈으면 그 조건의 검증 결과도 포함
+type wheelResponse struct {
+	Tickets    [][]int           `json:"tickets"`
+	Cost       int               `json:"cost"`
+	Coverage   *wheel.Coverage   `json:"coverage,omitempty"`
+	Guarantees []wheel.Guarantee `json:"guarantees"` // 당첨수 3~6개별 보장 일치수
+}
+
+// 이미 가진 티켓 묶음의 보장 조건 검증 요청
+type wheelVerifyRequest struct {
+	Numbers   []int           `json:"numbers"`
+	Tickets   [][]int         `json:"tickets"`
+	Guarantee wheel.Guarantee `json:"guarantee"`
+}
+
+type wheelVerifyResponse struct {
+	Coverage   wheel.Coverage    `json:"coverage"`
+	Guarantees []wheel.Guarantee `json:"guarantees"`
+}
+
+func (h *Handler) handleWheel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorMsg(w, http.StatusMethodNotAllowed, "허용되지 않은 메서드입니다")
+		return
+	}
+
+	var req wheel.Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return
+	}
+
+	tickets, err := wheel.Generate(req)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	resp := wheelResponse{Cost: len(tickets) * lotto.LottoPrice}
+	for _, t := range tickets {
+		resp.Tickets = append(resp.Tickets, t.Numbers)
+	}
+	if req.Guarantee != (wheel.Guarantee{}) {
+		coverage, err := wheel.Verify(tickets, req.Numbers, req.Guarantee)
+		if err != nil {
+			writeDomainError(w, err)
+			return
+		}
+		resp.Coverage = &coverage
+	}
+	if resp.Guarantees, err = wheel.Guarantees(tickets, req.Numbers); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeError(w, http.StatusInternalServerError, "결과 인코딩에 실패했습니다", err)
+		return
+	}
+}
+
+func (h *Handler) handleWheelVerify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorMsg(w, http.StatusMethodNotAllowed, "허용되지 않은 메서드입니다")
+		return
+	}
+
+	var req wheelVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "유효하지 않은 JSON입니다", err)
+		return
+	}
+
+	tickets := make([]lotto.Lotto, 0, len(req.Tickets))
+	for _, numbers := range req.Tickets {
+		t, err := lotto.NewLotto(numbers)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "유효하지 않은 티켓입니다", err)
+			return
+		}
+		tickets = append(tickets, t)
+	}
+
+	coverage, err := wheel.Verify(tickets, req.Numbers, req.Guarantee)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	guarantees, err := wheel.Guarantees(tickets, req.Numbers)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(wheelVerifyResponse{Coverage: coverage, Guarantees: guarantees}); err != nil {
+		writeError(w, http.StatusInternalServerError, "결과 인코딩에 실패했습니다", err)
+		return
+	}
+}
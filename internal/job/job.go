@@ -0,0 +1,217 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("작업을 찾을 수 없습니다")
+	ErrFinished = errors.New("이미 끝난 작업입니다")
+)
+
+// 작업 하나가 보관하는 최대 이벤트 수. 넘치면 오래된 것부터 버린다 (이어 받기는 남아 있는 것부터)
+const MaxEvents = 1_000
+
+// 작업 상태. 이벤트 종류 이름으로도 쓴다 (상태가 바뀔 때 같은 이름의 이벤트 발생)
+type State string
+
+const (
+	StateQueued    State = "queued"
+	StateRunning   State = "running"
+	StateDone      State = "done"
+	StateFailed    State = "failed"
+	StateCancelled State = "cancelled"
+)
+
+func (s State) Finished() bool {
+	return s == StateDone || s == StateFailed || s == StateCancelled
+}
+
+// 작업 상태 요약
+type Status struct {
+	ID         string     `json:"id"`
+	Kind       string     `json:"kind"`
+	State      State      `json:"state"`
+	Done       int        `json:"done"`
+	Total      int        `json:"total"`
+	Percent    float64    `json:"percent"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// 작업 이벤트 한 건. ID는 작업 안에서 1부터 증가 (SSE Last-Event-ID로 이어 받기)
+type Event struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+// 진행 이벤트 데이터 (Progress.Advance 한 번)
+type Step struct {
+	Done    int     `json:"done"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+	Result  any     `json:"result,omitempty"` // 이번 단계의 결과 (예: 회차 결과)
+}
+
+// 작업 본문. ctx가 취소되면 되도록 빨리 ctx.Err()를 돌려줘야 한다
+type Func func(ctx context.Context, progress *Progress) (any, error)
+
+// 실행 중인 작업 하나 (동시 사용 안전)
+type Job struct {
+	mu      sync.Mutex
+	status  Status
+	result  any
+	events  []Event
+	dropped int           // MaxEvents를 넘어 버린 이벤트 수 (events[0].ID == dropped+1)
+	changed chan struct{} // 이벤트가 추가될 때마다 닫고 새로 만든다
+	cancel  context.CancelFunc
+	now     func() time.Time
+}
+
+func (j *Job) ID() string {
+	return j.status.ID // 만든 뒤 바뀌지 않음
+}
+
+func (j *Job) Status() Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// 성공한 작업의 결과 (그 밖에는 nil)
+func (j *Job) Result() any {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.result
+}
+
+// ID가 after보다 큰 이벤트, 다음 이벤트가 추가되면 닫히는 채널, 작업이 끝났는지
+// 끝난 작업은 마지막 이벤트까지 돌려주므로 finished면 더 기다릴 필요가 없다
+// 이미 버린 이벤트는 건너뛰고 남아 있는 가장 오래된 것부터 돌려준다
+func (j *Job) Events(after int) (events []Event, changed <-chan struct{}, finished bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	start := max(after-j.dropped, 0)
+	if start < len(j.events) {
+		events = append(events, j.events[start:]...)
+	}
+	return events, j.changed, j.status.State.Finished()
+}
+
+// 잠금을 잡은 상태에서 호출
+func (j *Job) emit(typ string, data any) {
+	j.events = append(j.events, Event{ID: j.dropped + len(j.events) + 1, Type: typ, Data: data})
+	if over := len(j.events) - MaxEvents; over > 0 {
+		j.events = append(j.events[:0], j.events[over:]...)
+		j.dropped += over
+	}
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// 끝난 작업의 진행 이벤트에서 단계 결과를 지운다 (결과는 Result로 받을 수 있다)
+// 잠금을 잡은 상태에서 호출
+func (j *Job) dropStepResults() {
+	for i, e := range j.events {
+		if step, ok := e.Data.(Step); ok && step.Result != nil {
+			step.Result = nil
+			j.events[i].Data = step
+		}
+	}
+}
+
+func (j *Job) setState(state State, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.transition(state, err)
+}
+
+// 잠금을 잡은 상태에서 호출
+func (j *Job) transition(state State, err error) {
+	now := j.now()
+	j.status.State = state
+	switch {
+	case state == StateRunning:
+		j.status.StartedAt = &now
+	case state.Finished():
+		j.status.FinishedAt = &now
+		j.dropStepResults()
+		jobsFinished.With(j.status.Kind, string(state)).Inc()
+		if j.status.StartedAt != nil {
+			jobDuration.With(j.status.Kind).Observe(now.Sub(*j.status.StartedAt).Seconds())
+		}
+	}
+	if err != nil {
+		j.status.Error = err.Error()
+	}
+	j.emit(string(state), j.status)
+}
+
+// 기다리던 작업을 running으로 바꾼다. 이미 취소됐으면 false
+func (j *Job) begin() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.status.State != StateQueued {
+		return false
+	}
+	j.transition(StateRunning, nil)
+	return true
+}
+
+// 아직 기다리던 작업이면 바로 cancelled로 끝낸다 (워커가 꺼내면 건너뜀)
+func (j *Job) cancelQueued() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.status.State == StateQueued {
+		j.transition(StateCancelled, nil)
+	}
+}
+
+// 취소된 뒤에 돌아온 에러는 원인과 상관없이 취소로 본다
+func (j *Job) finish(ctx context.Context, result any, err error) {
+	switch {
+	case err == nil:
+		j.mu.Lock()
+		j.result = result
+		j.mu.Unlock()
+		j.setState(StateDone, nil)
+	case ctx.Err() != nil:
+		j.setState(StateCancelled, nil)
+	default:
+		j.setState(StateFailed, err)
+	}
+}
+
+// 작업 함수가 진행 상황을 알리는 통로
+type Progress struct {
+	job *Job
+}
+
+// 전체 단계 수 (모르면 0으로 두고 Advance만 호출)
+func (p *Progress) SetTotal(total int) {
+	p.job.mu.Lock()
+	defer p.job.mu.Unlock()
+	p.job.status.Total = total
+}
+
+// 한 단계를 마쳤음을 알리고 eventType 이벤트로 단계 결과를 내보낸다
+func (p *Progress) Advance(eventType string, result any) {
+	j := p.job
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.status.Done++
+	if j.status.Total > 0 {
+		j.status.Percent = float64(j.status.Done) / float64(j.status.Total) * 100
+	}
+	j.emit(eventType, Step{Done: j.status.Done, Total: j.status.Total, Percent: j.status.Percent, Result: result})
+}
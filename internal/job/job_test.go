@@ -0,0 +1,311 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// 작업이 끝날 때까지 이벤트를 모두 받는다
+func waitEvents(t *testing.T, j *Job) []Event {
+	t.Helper()
+
+	var all []Event
+	timeout := time.After(5 * time.Second)
+	for {
+		events, changed, finished := j.Events(len(all))
+		all = append(all, events...)
+		if finished {
+			return all
+		}
+		select {
+		case <-changed:
+		case <-timeout:
+			t.Fatalf("작업이 끝나지 않았습니다: %+v", j.Status())
+		}
+	}
+}
+
+func eventTypes(events []Event) []string {
+	types := make([]string, 0, len(events))
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+// 테스트용 작업 시작 (큐가 차는 경우는 따로 확인)
+func mustStart(t *testing.T, m *Manager, fn Func) *Job {
+	t.Helper()
+	j, err := m.Start("test", fn)
+	if err != nil {
+		t.Fatalf("작업 시작 에러: %v", err)
+	}
+	return j
+}
+
+// ctx가 끝날 때까지 기다리는 작업
+func blockUntilDone(ctx context.Context, p *Progress) (any, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestJobLifecycle(t *testing.T) {
+	m := NewManager(Config{})
+	j := mustStart(t, m, func(ctx context.Context, p *Progress) (any, error) {
+		p.SetTotal(4)
+		for i := 1; i <= 4; i++ {
+			p.Advance("step", i)
+		}
+		return "done", nil
+	})
+
+	events := waitEvents(t, j)
+	want := []string{"queued", "running", "step", "step", "step", "step", "done"}
+	if got := eventTypes(events); len(got) != len(want) {
+		t.Fatalf("이벤트 종류 = %v, want %v", got, want)
+	}
+	for i, e := range events {
+		if e.Type != want[i] || e.ID != i+1 {
+			t.Errorf("%d번째 이벤트 = %d/%s, want %d/%s", i, e.ID, e.Type, i+1, want[i])
+		}
+	}
+	if step := events[3].Data.(Step); step.Done != 2 || step.Total != 4 || step.Percent != 50 {
+		t.Errorf("진행 이벤트 = %+v", step)
+	}
+
+	st := j.Status()
+	if st.State != StateDone || st.Percent != 100 || st.StartedAt == nil || st.FinishedAt == nil {
+		t.Errorf("끝난 상태 = %+v", st)
+	}
+	if j.Result() != "done" {
+		t.Errorf("결과 = %v, want done", j.Result())
+	}
+
+	// 끝난 작업도 처음부터/중간부터 다시 받을 수 있다
+	if again, _, finished := j.Events(5); len(again) != 2 || again[0].ID != 6 || !finished {
+		t.Errorf("Events(5) = %v, finished=%v", again, finished)
+	}
+
+	// 끝난 작업의 진행 이벤트에는 단계 결과를 남기지 않는다
+	all, _, _ := j.Events(0)
+	for _, e := range all {
+		if step, ok := e.Data.(Step); ok && step.Result != nil {
+			t.Errorf("끝난 작업의 진행 이벤트 %d에 결과가 남아 있습니다: %+v", e.ID, step)
+		}
+	}
+
+	if _, err := m.Cancel(j.ID()); !errors.Is(err, ErrFinished) {
+		t.Errorf("끝난 작업 취소 에러 = %v, want ErrFinished", err)
+	}
+}
+
+func TestJobEndStates(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		fn        Func
+		cancel    bool
+		wantState State
+		wantError string
+	}{
+		{
+			name:      "실패",
+			fn:        func(ctx context.Context, p *Progress) (any, error) { return nil, errors.New("잘못된 입력") },
+			wantState: StateFailed,
+			wantError: "잘못된 입력",
+		},
+		{
+			name:      "패닉",
+			fn:        func(ctx context.Context, p *Progress) (any, error) { panic("버그") },
+			wantState: StateFailed,
+			wantError: "작업 중 패닉이 발생했습니다: 버그",
+		},
+		{
+			name:      "취소",
+			fn:        blockUntilDone,
+			cancel:    true,
+			wantState: StateCancelled,
+		},
+		{
+			name:      "시간 초과",
+			cfg:       Config{Timeout: 10 * time.Millisecond},
+			fn:        blockUntilDone,
+			wantState: StateFailed,
+			wantError: "작업 시간이 초과되었습니다 (10ms)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(tt.cfg)
+			j := mustStart(t, m, tt.fn)
+			if tt.cancel {
+				if _, err := m.Cancel(j.ID()); err != nil {
+					t.Fatalf("취소 에러: %v", err)
+				}
+			}
+
+			events := waitEvents(t, j)
+			st := j.Status()
+			if st.State != tt.wantState || st.Error != tt.wantError {
+				t.Errorf("상태 = %s %q, want %s %q", st.State, st.Error, tt.wantState, tt.wantError)
+			}
+			if last := events[len(events)-1]; last.Type != string(tt.wantState) {
+				t.Errorf("마지막 이벤트 = %s, want %s", last.Type, tt.wantState)
+			}
+			if j.Result() != nil {
+				t.Errorf("성공하지 않은 작업의 결과는 nil이어야 합니다: %v", j.Result())
+			}
+		})
+	}
+}
+
+func TestManagerGetAndPrune(t *testing.T) {
+	m := NewManager(Config{})
+	if _, err := m.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("없는 작업 조회 에러 = %v, want ErrNotFound", err)
+	}
+	if _, err := m.Cancel("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("없는 작업 취소 에러 = %v, want ErrNotFound", err)
+	}
+
+	var first *Job
+	for i := 0; i < KeepFinished+5; i++ {
+		j := mustStart(t, m, func(ctx context.Context, p *Progress) (any, error) { return i, nil })
+		waitEvents(t, j)
+		if first == nil {
+			first = j
+		}
+	}
+
+	if _, err := m.Get(first.ID()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("오래된 작업이 정리되지 않았습니다: %v", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.jobs) > KeepFinished+1 {
+		t.Errorf("보관 중인 작업 = %d, want ≤ %d", len(m.jobs), KeepFinished+1)
+	}
+}
+
+func TestManagerQueueLimit(t *testing.T) {
+	m := NewManager(Config{Workers: 1, QueueSize: 1})
+
+	running := mustStart(t, m, blockUntilDone)
+	waitState(t, running, StateRunning)
+	queued := mustStart(t, m, blockUntilDone)
+
+	if _, err := m.Start("test", blockUntilDone); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("큐가 찼을 때 에러 = %v, want ErrQueueFull", err)
+	}
+	if st := m.Stats(); st.Running != 1 || st.Queued != 1 {
+		t.Errorf("현황 = %+v, want 실행 1, 대기 1", st)
+	}
+	if list := m.List(); len(list) != 2 || list[0].ID != queued.ID() {
+		t.Errorf("목록 = %+v, want 최근 작업부터 2개", list)
+	}
+
+	// 기다리던 작업은 워커를 기다리지 않고 바로 취소된다
+	if _, err := m.Cancel(queued.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if st := queued.Status(); st.State != StateCancelled || st.StartedAt != nil {
+		t.Errorf("대기 중 취소한 작업 = %+v", st)
+	}
+
+	if _, err := m.Cancel(running.ID()); err != nil {
+		t.Fatal(err)
+	}
+	waitEvents(t, running)
+
+	// 워커가 취소된 작업을 건너뛰고 다음 작업을 실행한다
+	next := mustStart(t, m, func(ctx context.Context, p *Progress) (any, error) { return "next", nil })
+	waitEvents(t, next)
+	if next.Result() != "next" {
+		t.Errorf("다음 작업 결과 = %v", next.Result())
+	}
+	if got := eventTypes(waitEvents(t, queued)); len(got) != 2 || got[1] != "cancelled" {
+		t.Errorf("대기 중 취소한 작업 이벤트 = %v, want [queued cancelled]", got)
+	}
+}
+
+func TestManagerShutdown(t *testing.T) {
+	t.Run("기다리던 작업까지 끝낸다", func(t *testing.T) {
+		m := NewManager(Config{Workers: 1})
+		var jobs []*Job
+		for range 3 {
+			jobs = append(jobs, mustStart(t, m, func(ctx context.Context, p *Progress) (any, error) {
+				time.Sleep(time.Millisecond)
+				return "ok", nil
+			}))
+		}
+
+		if err := m.Shutdown(context.Background()); err != nil {
+			t.Fatalf("종료 에러: %v", err)
+		}
+		for _, j := range jobs {
+			if st := j.Status(); st.State != StateDone {
+				t.Errorf("종료 후 작업 상태 = %s, want done", st.State)
+			}
+		}
+		if _, err := m.Start("test", blockUntilDone); !errors.Is(err, ErrClosed) {
+			t.Errorf("종료 후 시작 에러 = %v, want ErrClosed", err)
+		}
+	})
+
+	t.Run("시간이 지나면 남은 작업을 취소한다", func(t *testing.T) {
+		m := NewManager(Config{Workers: 1})
+		running := mustStart(t, m, blockUntilDone)
+		queued := mustStart(t, m, blockUntilDone)
+		waitState(t, running, StateRunning)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := m.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("종료 에러 = %v, want DeadlineExceeded", err)
+		}
+		for _, j := range []*Job{running, queued} {
+			if st := j.Status(); st.State != StateCancelled {
+				t.Errorf("종료 후 작업 상태 = %s, want cancelled", st.State)
+			}
+		}
+	})
+}
+
+// 이벤트는 최근 MaxEvents개만 보관하고, 번호는 버린 뒤에도 이어진다
+func TestJobEventsLimit(t *testing.T) {
+	m := NewManager(Config{})
+	j := mustStart(t, m, func(ctx context.Context, p *Progress) (any, error) {
+		for i := 0; i < MaxEvents+10; i++ {
+			p.Advance("step", i)
+		}
+		return nil, nil
+	})
+	waitState(t, j, StateDone)
+
+	total := MaxEvents + 13 // queued, running, step..., done
+	events, _, finished := j.Events(0)
+	if len(events) != MaxEvents || !finished {
+		t.Fatalf("보관 중인 이벤트 = %d, want %d (finished=%v)", len(events), MaxEvents, finished)
+	}
+	if first, last := events[0].ID, events[len(events)-1].ID; first != total-MaxEvents+1 || last != total {
+		t.Errorf("이벤트 번호 = %d~%d, want %d~%d", first, last, total-MaxEvents+1, total)
+	}
+	if again, _, _ := j.Events(total - 2); len(again) != 2 || again[0].ID != total-1 {
+		t.Errorf("Events(%d) = %v", total-2, again)
+	}
+}
+
+func waitState(t *testing.T, j *Job, want State) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for j.Status().State != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("작업 상태 = %s, want %s", j.Status().State, want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
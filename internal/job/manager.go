@@ -0,0 +1,284 @@
+package job
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 끝난 작업을 몇 개까지 보관하는지 (넘으면 오래된 것부터 지운다)
+const KeepFinished = 100
+
+const (
+	DefaultWorkers   = 4  // 동시에 실행하는 작업 수
+	DefaultQueueSize = 16 // 실행을 기다릴 수 있는 작업 수
+	DefaultTimeout   = 5 * time.Minute
+)
+
+var (
+	ErrQueueFull = errors.New("대기 중인 작업이 너무 많습니다")
+	ErrClosed    = errors.New("작업을 더 받지 않습니다 (서버 종료 중)")
+	ErrTimeout   = errors.New("작업 시간이 초과되었습니다")
+)
+
+// 작업 실행기 설정. 0이면 기본값
+type Config struct {
+	Workers   int
+	QueueSize int
+	Timeout   time.Duration // 작업 하나의 최대 실행 시간 (대기 시간 제외)
+}
+
+func (c Config) withDefaults() Config {
+	if c.Workers <= 0 {
+		c.Workers = DefaultWorkers
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = DefaultQueueSize
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+	return c
+}
+
+// 실행기 현황
+type Stats struct {
+	Workers   int    `json:"workers"`
+	QueueSize int    `json:"queueSize"`
+	Timeout   string `json:"timeout"`
+	Queued    int    `json:"queued"`  // 실행을 기다리는 작업 수
+	Running   int    `json:"running"` // 실행 중인 작업 수
+	Closed    bool   `json:"closed"`  // 종료 중이라 새 작업을 받지 않음
+}
+
+type queuedJob struct {
+	job *Job
+	ctx context.Context
+	fn  Func
+}
+
+// 정해진 수의 워커로 작업을 실행하고 보관하는 관리자 (동시 사용 안전)
+// 워커가 모두 바쁘면 큐에서 기다리고, 큐가 차면 ErrQueueFull로 거절한다
+type Manager struct {
+	cfg     Config
+	queue   chan queuedJob
+	workers sync.WaitGroup
+
+	mu     sync.Mutex
+	jobs   map[string]*Job
+	order  []string // 만든 순서
+	closed bool
+	now    func() time.Time
+}
+
+func NewManager(cfg Config) *Manager {
+	cfg = cfg.withDefaults()
+	m := &Manager{
+		cfg:   cfg,
+		queue: make(chan queuedJob, cfg.QueueSize),
+		jobs:  make(map[string]*Job),
+		now:   time.Now,
+	}
+
+	m.workers.Add(cfg.Workers)
+	for range cfg.Workers {
+		go m.work()
+	}
+	return m
+}
+
+// 작업을 큐에 넣는다. 큐가 차 있으면 ErrQueueFull, 종료 중이면 ErrClosed
+func (m *Manager) Start(kind string, fn Func) (*Job, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	j := &Job{
+		status:  Status{ID: newID(), Kind: kind, State: StateQueued, CreatedAt: m.now()},
+		changed: make(chan struct{}),
+		cancel:  cancel,
+		now:     m.now,
+	}
+	j.emit(string(StateQueued), j.status)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		cancel()
+		jobsRejected.With(kind, "closed").Inc()
+		return nil, ErrClosed
+	}
+	select {
+	case m.queue <- queuedJob{job: j, ctx: ctx, fn: fn}:
+	default:
+		cancel()
+		jobsRejected.With(kind, "queue_full").Inc()
+		return nil, fmt.Errorf("%w (최대 %d개)", ErrQueueFull, m.cfg.QueueSize)
+	}
+
+	m.jobs[j.ID()] = j
+	m.order = append(m.order, j.ID())
+	return j, nil
+}
+
+// 큐가 닫힐 때까지 작업을 하나씩 꺼내 실행
+func (m *Manager) work() {
+	defer m.workers.Done()
+	for q := range m.queue {
+		m.run(q.ctx, q.job, q.fn)
+	}
+}
+
+func (m *Manager) run(ctx context.Context, j *Job, fn Func) {
+	defer j.cancel()
+	defer m.prune()
+
+	// 기다리는 동안 취소된 작업은 건너뛴다
+	if !j.begin() {
+		return
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+
+	result, err := call(runCtx, j, fn)
+	if err != nil && ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w (%s)", ErrTimeout, m.cfg.Timeout)
+	}
+	j.finish(ctx, result, err)
+}
+
+// 작업 함수의 패닉은 실패로 기록한다 (서버 전체가 죽지 않도록)
+func call(ctx context.Context, j *Job, fn Func) (result any, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("작업 중 패닉이 발생했습니다: %v", v)
+		}
+	}()
+	return fn(ctx, &Progress{job: j})
+}
+
+func (m *Manager) Get(id string) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return j, nil
+}
+
+// 보관 중인 작업 상태 (최근에 만든 것부터)
+func (m *Manager) List() []Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list := make([]Status, 0, len(m.order))
+	for i := len(m.order) - 1; i >= 0; i-- {
+		list = append(list, m.jobs[m.order[i]].Status())
+	}
+	return list
+}
+
+func (m *Manager) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := Stats{
+		Workers:   m.cfg.Workers,
+		QueueSize: m.cfg.QueueSize,
+		Timeout:   m.cfg.Timeout.String(),
+		Closed:    m.closed,
+	}
+	for _, id := range m.order {
+		switch m.jobs[id].Status().State {
+		case StateQueued:
+			stats.Queued++
+		case StateRunning:
+			stats.Running++
+		}
+	}
+	return stats
+}
+
+// 작업 취소 요청. 기다리던 작업은 바로 cancelled가 되고,
+// 실행 중인 작업은 작업 함수가 ctx 취소를 확인하고 돌아오면 cancelled가 된다
+func (m *Manager) Cancel(id string) (*Job, error) {
+	j, err := m.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if j.Status().State.Finished() {
+		return j, fmt.Errorf("%w: %s", ErrFinished, id)
+	}
+	j.cancel()
+	j.cancelQueued()
+	return j, nil
+}
+
+// 새 작업을 더 받지 않고, 기다리던 작업까지 모두 끝나기를 기다린다
+// ctx가 먼저 끝나면 남은 작업을 모두 취소하고 멈출 때까지 기다린 뒤 ctx.Err()를 돌려준다
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.closed {
+		m.closed = true
+		close(m.queue)
+	}
+	m.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		m.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+	}
+
+	m.mu.Lock()
+	for _, j := range m.jobs {
+		j.cancel()
+		j.cancelQueued()
+	}
+	m.mu.Unlock()
+
+	<-drained
+	return ctx.Err()
+}
+
+// 끝난 작업이 KeepFinished개를 넘으면 오래된 것부터 지운다
+func (m *Manager) prune() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	finished := 0
+	for _, id := range m.order {
+		if m.jobs[id].Status().State.Finished() {
+			finished++
+		}
+	}
+
+	kept := m.order[:0]
+	for _, id := range m.order {
+		if finished > KeepFinished && m.jobs[id].Status().State.Finished() {
+			delete(m.jobs, id)
+			finished--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	m.order = kept
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b) // crypto/rand.Read는 실패하지 않는다
+	return hex.EncodeToString(b)
+}
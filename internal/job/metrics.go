@@ -0,0 +1,23 @@
+package job
+
+import "github.com/meoraeng/lotto_simulator/internal/metrics"
+
+// 서버 /metrics로 내보내는 작업 지표 (대기/실행 중 개수는 Manager.Stats로 따로 노출)
+var (
+	jobsFinished = metrics.NewCounterVec(
+		"lotto_jobs_finished_total",
+		"끝난 작업 수 (종류, 최종 상태별)",
+		"kind", "state",
+	)
+	jobsRejected = metrics.NewCounterVec(
+		"lotto_jobs_rejected_total",
+		"큐가 차거나 종료 중이라 받지 못한 작업 수",
+		"kind", "reason",
+	)
+	jobDuration = metrics.NewHistogramVec(
+		"lotto_job_duration_seconds",
+		"실행을 시작한 작업이 끝날 때까지 걸린 시간 (초)",
+		[]float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600},
+		"kind",
+	)
+)
@@ -0,0 +1,115 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/history"
+)
+
+var ErrNoOfficialData = errors.New("공식 판매액/당첨자 수/당첨금이 있는 회차가 없습니다")
+
+var rankOrder = []lotto.Rank{lotto.Rank1, lotto.Rank2, lotto.Rank3, lotto.Rank4, lotto.Rank5}
+
+// 등수별 공식 1인당 당첨금과 엔진 계산값 비교
+type RankDeviation struct {
+	Rank      lotto.Rank `json:"rank"`
+	Winners   int        `json:"winners"`
+	Official  int        `json:"official"`  // 공식 1인당 당첨금
+	Simulated int        `json:"simulated"` // 엔진이 계산한 1인당 지급액
+	Diff      int        `json:"diff"`      // Simulated - Official
+}
+
+// 한 회차 비교 결과
+type RoundResult struct {
+	Meta            lotto.RoundMeta `json:"meta"`
+	Ranks           []RankDeviation `json:"ranks"`
+	AbsError        int             `json:"absError"`        // Σ 당첨자 수 × |차이| (잘못 나간 총액)
+	CumulativeError int             `json:"cumulativeError"` // 첫 회차부터 누적
+}
+
+type Report struct {
+	Config          lotto.SeriesConfig `json:"config"`
+	Rounds          []RoundResult      `json:"rounds"`
+	Skipped         []int              `json:"skipped"` // 공식 결과가 없어 건너뛴 회차 번호
+	CumulativeError int                `json:"cumulativeError"`
+	OfficialPaid    int                `json:"officialPaid"`  // 공식 총 지급액
+	RelativeError   float64            `json:"relativeError"` // CumulativeError / OfficialPaid
+}
+
+// 과거 기록의 판매액/당첨자 수를 분배 엔진에 넣고 공식 당첨금과 비교한다.
+// 이월은 엔진 계산값을 다음 회차로 넘긴다
+func Run(cfg lotto.SeriesConfig, records []history.Record) (Report, error) {
+	cfg.Mode = lotto.ModeParimutuel
+
+	report := Report{Config: cfg}
+	carry := make(map[lotto.Rank]int)
+
+	for _, rec := range records {
+		if !rec.HasOfficialResult() || rec.Sales <= 0 || len(rec.PrizePerWin) == 0 {
+			report.Skipped = append(report.Skipped, rec.Meta.Sequence)
+			continue
+		}
+
+		out, err := lotto.CalculateRound(lotto.RoundInput{
+			Meta:            rec.Meta,
+			Mode:            cfg.Mode,
+			Sales:           rec.Sales,
+			Winners:         rec.Winners,
+			CarryIn:         carry,
+			Allocations:     cfg.Allocations,
+			CapPerRank:      cfg.CapPerRank,
+			RoundingUnit:    cfg.RoundingUnit,
+			RollDownMethod:  cfg.RollDownMethod,
+			RollDownTargets: cfg.RollDownTargets,
+		})
+		if err != nil {
+			return Report{}, fmt.Errorf("%s: %w", rec.Meta.Label(), err)
+		}
+		carry = out.CarryOut
+
+		round := compareRound(rec, out)
+		report.CumulativeError += round.AbsError
+		round.CumulativeError = report.CumulativeError
+		report.Rounds = append(report.Rounds, round)
+
+		for r, w := range rec.Winners {
+			report.OfficialPaid += w * rec.PrizePerWin[r]
+		}
+	}
+
+	if len(report.Rounds) == 0 {
+		return Report{}, ErrNoOfficialData
+	}
+	if report.OfficialPaid > 0 {
+		report.RelativeError = float64(report.CumulativeError) / float64(report.OfficialPaid)
+	}
+	return report, nil
+}
+
+func compareRound(rec history.Record, out lotto.RoundOutput) RoundResult {
+	round := RoundResult{Meta: rec.Meta}
+
+	for _, r := range rankOrder {
+		winners := rec.Winners[r]
+		dev := RankDeviation{
+			Rank:      r,
+			Winners:   winners,
+			Official:  rec.PrizePerWin[r],
+			Simulated: out.PaidPerWin[r],
+		}
+		dev.Diff = dev.Simulated - dev.Official
+		round.Ranks = append(round.Ranks, dev)
+
+		round.AbsError += winners * abs(dev.Diff)
+	}
+	return round
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,146 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/history"
+)
+
+func testRecord(seq, sales int, winners, prizes map[lotto.Rank]int) history.Record {
+	return history.Record{
+		Draw:        lotto.Draw{Meta: lotto.RoundMeta{GameID: lotto.DefaultGameID, Sequence: seq}},
+		Sales:       sales,
+		Winners:     winners,
+		PrizePerWin: prizes,
+	}
+}
+
+func testConfig(rank1, rank2 int) lotto.SeriesConfig {
+	return lotto.SeriesConfig{
+		Allocations: []lotto.Allocation{
+			{Rank: lotto.Rank1, BasisPoints: rank1},
+			{Rank: lotto.Rank2, BasisPoints: rank2},
+		},
+		RoundingUnit: 1,
+	}
+}
+
+func TestRun(t *testing.T) {
+	records := []history.Record{
+		// 1등 2명이 판매액의 50%를 나눠 가짐, 2등 1명이 25%
+		testRecord(1, 1_000_000,
+			map[lotto.Rank]int{lotto.Rank1: 2, lotto.Rank2: 1},
+			map[lotto.Rank]int{lotto.Rank1: 250_000, lotto.Rank2: 250_000}),
+		testRecord(2, 0, nil, nil),
+		// 공식 2등 당첨금이 엔진보다 10,000원 많음
+		testRecord(3, 1_000_000,
+			map[lotto.Rank]int{lotto.Rank1: 1, lotto.Rank2: 2},
+			map[lotto.Rank]int{lotto.Rank1: 500_000, lotto.Rank2: 135_000}),
+	}
+
+	report, err := Run(testConfig(5_000, 2_500), records)
+	if err != nil {
+		t.Fatalf("백테스트 중 에러가 발생했습니다: %v", err)
+	}
+
+	if len(report.Rounds) != 2 {
+		t.Fatalf("비교 회차 수가 예상과 다릅니다. got=%d, want=%d", len(report.Rounds), 2)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0] != 2 {
+		t.Errorf("공식 결과가 없는 회차는 건너뛰어야 합니다. got=%v", report.Skipped)
+	}
+	if report.Rounds[0].AbsError != 0 {
+		t.Errorf("첫 회차 오차가 예상과 다릅니다. got=%d, want=0", report.Rounds[0].AbsError)
+	}
+
+	second := report.Rounds[1]
+	if second.Ranks[1].Diff != -10_000 {
+		t.Errorf("2등 차이가 예상과 다릅니다. got=%d, want=%d", second.Ranks[1].Diff, -10_000)
+	}
+	if report.CumulativeError != 20_000 || second.CumulativeError != 20_000 {
+		t.Errorf("누적 오차가 예상과 다릅니다. got=%d", report.CumulativeError)
+	}
+	if report.OfficialPaid != 1_520_000 {
+		t.Errorf("공식 총 지급액이 예상과 다릅니다. got=%d", report.OfficialPaid)
+	}
+}
+
+func TestRun_NoOfficialData(t *testing.T) {
+	_, err := Run(testConfig(5_000, 2_500), []history.Record{testRecord(1, 0, nil, nil)})
+	if !errors.Is(err, ErrNoOfficialData) {
+		t.Errorf("공식 결과가 없으면 ErrNoOfficialData여야 합니다. got=%v", err)
+	}
+}
+
+func TestOptimize(t *testing.T) {
+	records := []history.Record{
+		testRecord(1, 1_000_000,
+			map[lotto.Rank]int{lotto.Rank1: 1, lotto.Rank2: 1},
+			map[lotto.Rank]int{lotto.Rank1: 600_000, lotto.Rank2: 200_000}),
+	}
+	grid := Grid{
+		Rank1: []int{5_000, 6_000, 7_000},
+		Rank2: []int{1_000, 2_000, 5_000},
+	}
+
+	candidates, err := Optimize(context.Background(), testConfig(0, 0), records, grid, 2)
+	if err != nil {
+		t.Fatalf("최적화 중 에러가 발생했습니다: %v", err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("상위 후보 수가 예상과 다릅니다. got=%d, want=%d", len(candidates), 2)
+	}
+
+	best := candidates[0]
+	if best.CumulativeError != 0 {
+		t.Errorf("최적 후보의 오차는 0이어야 합니다. got=%d", best.CumulativeError)
+	}
+	if best.Allocations[0].BasisPoints != 6_000 || best.Allocations[1].BasisPoints != 2_000 {
+		t.Errorf("최적 배정 비율이 예상과 다릅니다. got=%v", best.Allocations)
+	}
+	if candidates[1].CumulativeError < best.CumulativeError {
+		t.Errorf("후보는 오차 오름차순이어야 합니다")
+	}
+}
+
+// 조합이 너무 많거나 요청이 취소되면 백테스트를 돌리지 않는다
+func TestOptimize_Limits(t *testing.T) {
+	records := []history.Record{
+		testRecord(1, 1_000_000,
+			map[lotto.Rank]int{lotto.Rank1: 1},
+			map[lotto.Rank]int{lotto.Rank1: 600_000}),
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		grid Grid
+		want error
+	}{
+		{"조합 수 초과", context.Background(), Grid{Rank1: bpsRange(1, 100, 1), Rank2: bpsRange(1, 100, 1)}, ErrGridTooLarge},
+		{"취소된 요청 (기본 격자는 제한 이내)", canceled, DefaultGrid(), context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Optimize(tt.ctx, testConfig(0, 0), records, tt.grid, 1)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("에러가 예상과 다릅니다. got=%v, want=%v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGridCombinations_SkipsOverAllocated(t *testing.T) {
+	grid := Grid{Rank1: []int{6_000, 9_000}, Rank2: []int{1_000, 2_000}}
+
+	combos := grid.combinations(nil)
+	if len(combos) != 3 {
+		t.Errorf("합계가 100%%를 넘는 조합은 제외해야 합니다. got=%d, want=%d", len(combos), 3)
+	}
+}
@@ -0,0 +1,138 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/history"
+)
+
+// 한 번에 훑을 수 있는 최대 배정 조합 수 (조합마다 전체 백테스트를 한 번씩 돌린다)
+const MaxCombinations = 5_000
+
+var ErrGridTooLarge = errors.New("배정 비율 조합이 너무 많습니다")
+
+// 등수별 배정 비율(bps) 후보. 비어 있으면 기본 설정 값으로 고정
+type Grid struct {
+	Rank1 []int `json:"rank1"`
+	Rank2 []int `json:"rank2"`
+	Rank3 []int `json:"rank3"`
+	Rank4 []int `json:"rank4"`
+	Rank5 []int `json:"rank5"`
+}
+
+// 1~3등 비율을 훑는 기본 격자 (4/5등은 기본 설정 유지)
+func DefaultGrid() Grid {
+	return Grid{
+		Rank1: bpsRange(2_000, 7_500, 250),
+		Rank2: bpsRange(250, 1_500, 125),
+		Rank3: bpsRange(250, 1_500, 125),
+	}
+}
+
+// 한 배정 조합과 그 조합의 누적 오차
+type Candidate struct {
+	Allocations     []lotto.Allocation `json:"allocations"`
+	CumulativeError int                `json:"cumulativeError"`
+	RelativeError   float64            `json:"relativeError"`
+}
+
+// 격자의 모든 배정 조합(합계 100% 이하)을 돌려 누적 오차가 작은 순으로 상위 top개 반환
+// 조합이 MaxCombinations개를 넘으면 돌리기 전에 ErrGridTooLarge, ctx가 취소되면 ctx.Err()
+func Optimize(ctx context.Context, base lotto.SeriesConfig, records []history.Record, grid Grid, top int) ([]Candidate, error) {
+	if n, ok := grid.size(MaxCombinations); !ok {
+		return nil, fmt.Errorf("%w: %d개 이상 (최대 %d개)", ErrGridTooLarge, n, MaxCombinations)
+	}
+
+	var candidates []Candidate
+
+	for _, allocs := range grid.combinations(base.Allocations) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		cfg := base
+		cfg.Allocations = allocs
+
+		report, err := Run(cfg, records)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, Candidate{
+			Allocations:     allocs,
+			CumulativeError: report.CumulativeError,
+			RelativeError:   report.RelativeError,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].CumulativeError < candidates[j].CumulativeError
+	})
+	if top > 0 && len(candidates) > top {
+		candidates = candidates[:top]
+	}
+	return candidates, nil
+}
+
+// 합계 검사 전 조합 수 (비어 있는 등수는 1). limit을 넘으면 거기서 멈추고 false
+func (g Grid) size(limit int) (int, bool) {
+	n := 1
+	for _, axis := range [][]int{g.Rank1, g.Rank2, g.Rank3, g.Rank4, g.Rank5} {
+		n *= max(len(axis), 1)
+		if n > limit {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (g Grid) combinations(base []lotto.Allocation) [][]lotto.Allocation {
+	baseBps := make(map[lotto.Rank]int)
+	for _, a := range base {
+		baseBps[a.Rank] = a.BasisPoints
+	}
+
+	axes := [][]int{g.Rank1, g.Rank2, g.Rank3, g.Rank4, g.Rank5}
+	for i, r := range rankOrder {
+		if len(axes[i]) == 0 {
+			axes[i] = []int{baseBps[r]}
+		}
+	}
+
+	var result [][]lotto.Allocation
+	picked := make([]int, len(rankOrder))
+
+	var walk func(depth, total int)
+	walk = func(depth, total int) {
+		if total > lotto.BasisPoints {
+			return
+		}
+		if depth == len(rankOrder) {
+			allocs := make([]lotto.Allocation, len(rankOrder))
+			for i, r := range rankOrder {
+				allocs[i] = lotto.Allocation{Rank: r, BasisPoints: picked[i]}
+			}
+			result = append(result, allocs)
+			return
+		}
+		for _, bps := range axes[depth] {
+			picked[depth] = bps
+			walk(depth+1, total+bps)
+		}
+	}
+	walk(0, 0)
+
+	return result
+}
+
+func bpsRange(from, to, step int) []int {
+	var out []int
+	for v := from; v <= to; v += step {
+		out = append(out, v)
+	}
+	return out
+}
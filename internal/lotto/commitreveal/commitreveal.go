@@ -0,0 +1,195 @@
+package commitreveal
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+// 커밋-공개 방식 추첨
+//  1. 판매 마감 전: 비밀 시드를 만들고 커밋값 SHA-256("lotto645:<회차>:" || 시드)만 공개한다
+//     회차를 함께 묶으므로 한 회차의 커밋값을 다른 회차에 다시 쓸 수 없다
+//  2. 마감 후: 시드를 공개한다
+//  3. 누구나 시드로 커밋값과 당첨 번호를 다시 계산해 판매 뒤에 번호를 고르지 않았음을 확인한다
+//
+// 번호 유도: HMAC-SHA256(키=시드, 메시지="lotto645:<회차>:<블록 번호>")을 블록 번호 0부터 이어 붙인
+// 바이트열을 4바이트(빅 엔디언 uint32)씩 읽는다. 45로 나눠떨어지는 범위를 넘는 값은 버리고(거부 표본추출)
+// 나머지+1을 번호로 쓴다. 중복을 건너뛰고 처음 6개가 당첨 번호(오름차순), 다음 1개가 보너스 번호다
+var (
+	ErrInvalidSeed       = errors.New("시드가 올바르지 않습니다")
+	ErrInvalidCommitment = errors.New("커밋값이 올바르지 않습니다")
+	ErrInvalidRound      = errors.New("회차 이름이 비어 있습니다")
+)
+
+const (
+	SeedSize = 32 // 바이트
+
+	domain = "lotto645"
+	span   = lotto.LottoMaxNum - lotto.LottoMinNum + 1
+	limit  = math.MaxUint32 - (math.MaxUint32%span+1)%span // 이 값 이하만 사용 (45의 배수 개 구간)
+)
+
+type Seed []byte
+
+// crypto/rand로 만든 새 시드
+func NewSeed() (Seed, error) {
+	seed := make(Seed, SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, fmt.Errorf("시드를 만들지 못했습니다: %w", err)
+	}
+	return seed, nil
+}
+
+// 16진수 문자열 시드
+func ParseSeed(s string) (Seed, error) {
+	seed, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(seed) != SeedSize {
+		return nil, fmt.Errorf("%w: %d바이트 16진수(%d자)여야 합니다", ErrInvalidSeed, SeedSize, SeedSize*2)
+	}
+	return seed, nil
+}
+
+func (s Seed) String() string {
+	return hex.EncodeToString(s)
+}
+
+// 공개할 커밋값: SHA-256("lotto645:<회차>:" || 시드)의 16진수
+// 시드 길이가 고정이라 회차 이름에 ':'가 있어도 경계가 모호하지 않다
+func (s Seed) Commitment(round string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s:%s:", domain, round)
+	h.Write(s)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// 시드와 회차 이름으로 당첨 번호 6개와 보너스 번호를 결정적으로 계산한다
+func Derive(seed Seed, round string) lotto.Draw {
+	stream := byteStream{seed: seed, round: round}
+	picked := make([]int, 0, lotto.LottoSize+1)
+
+	for len(picked) < lotto.LottoSize+1 {
+		v := stream.uint32()
+		if v > limit {
+			continue
+		}
+		n := int(v%span) + lotto.LottoMinNum
+		if !slices.Contains(picked, n) {
+			picked = append(picked, n)
+		}
+	}
+
+	winning := picked[:lotto.LottoSize]
+	slices.Sort(winning)
+	return lotto.Draw{WinningNumbers: winning, BonusNumber: picked[lotto.LottoSize]}
+}
+
+// HMAC 블록을 이어 붙인 바이트열
+type byteStream struct {
+	seed  Seed
+	round string
+	block uint64
+	buf   []byte
+}
+
+func (b *byteStream) uint32() uint32 {
+	if len(b.buf) < 4 {
+		mac := hmac.New(sha256.New, b.seed)
+		fmt.Fprintf(mac, "%s:%s:%d", domain, b.round, b.block)
+		b.buf = mac.Sum(nil)
+		b.block++
+	}
+	v := binary.BigEndian.Uint32(b.buf)
+	b.buf = b.buf[4:]
+	return v
+}
+
+// 검증 요청. 당첨 번호를 보내면 재계산한 번호와도 비교한다
+type VerifyRequest struct {
+	Round          string `json:"round"`
+	Commitment     string `json:"commitment"`
+	Seed           string `json:"seed"`
+	WinningNumbers []int  `json:"winningNumbers,omitempty"`
+	BonusNumber    int    `json:"bonusNumber,omitempty"`
+}
+
+type Verification struct {
+	Round           string `json:"round"`
+	CommitmentValid bool   `json:"commitmentValid"` // SHA-256(회차, 시드) == 커밋값
+	WinningNumbers  []int  `json:"winningNumbers"`  // 시드로 다시 계산한 번호
+	BonusNumber     int    `json:"bonusNumber"`
+	NumbersChecked  bool   `json:"numbersChecked"` // 요청에 당첨 번호가 있었는지
+	NumbersMatch    bool   `json:"numbersMatch"`
+	Valid           bool   `json:"valid"` // 커밋값 일치 && (번호를 보냈으면) 번호 일치
+}
+
+// 공개된 시드로 커밋값과 추첨 번호를 다시 계산해 대조한다
+func Verify(req VerifyRequest) (Verification, error) {
+	if req.Round == "" {
+		return Verification{}, ErrInvalidRound
+	}
+	seed, err := ParseSeed(req.Seed)
+	if err != nil {
+		return Verification{}, err
+	}
+	commitment := strings.ToLower(strings.TrimSpace(req.Commitment))
+	if len(commitment) != sha256.Size*2 {
+		return Verification{}, fmt.Errorf("%w: SHA-256 16진수(%d자)여야 합니다", ErrInvalidCommitment, sha256.Size*2)
+	}
+
+	draw := Derive(seed, req.Round)
+	v := Verification{
+		Round:           req.Round,
+		CommitmentValid: hmac.Equal([]byte(seed.Commitment(req.Round)), []byte(commitment)),
+		WinningNumbers:  draw.WinningNumbers,
+		BonusNumber:     draw.BonusNumber,
+	}
+
+	if len(req.WinningNumbers) > 0 {
+		claimed := slices.Sorted(slices.Values(req.WinningNumbers))
+		v.NumbersChecked = true
+		v.NumbersMatch = slices.Equal(claimed, draw.WinningNumbers) && req.BonusNumber == draw.BonusNumber
+	}
+	v.Valid = v.CommitmentValid && (!v.NumbersChecked || v.NumbersMatch)
+	return v, nil
+}
+
+// 공개한 추첨을 누구나 다시 계산할 수 있는 값 (검증 요청에 그대로 쓸 수 있다)
+type Proof struct {
+	Round      string `json:"round"`
+	Commitment string `json:"commitment"`
+	Seed       string `json:"seed"`
+}
+
+// 판매 마감과 추첨이 한 번에 일어나는 시뮬레이션용 커밋-공개 추첨
+// 회차 판매 전에 Seal로 커밋값을 공개하고, 마감(추첨) 때 Reveal로 시드와 번호를 확정한다
+// 시드는 내보내지 않으므로 JSON으로 써도 회차와 커밋값만 나간다
+type Sealed struct {
+	Round      string `json:"round"`
+	Commitment string `json:"commitment"`
+	seed       Seed
+}
+
+func Seal(round string) (*Sealed, error) {
+	if round == "" {
+		return nil, ErrInvalidRound
+	}
+	seed, err := NewSeed()
+	if err != nil {
+		return nil, err
+	}
+	return &Sealed{Round: round, Commitment: seed.Commitment(round), seed: seed}, nil
+}
+
+// 시드를 공개하고 이 회차 번호를 계산한다
+func (s *Sealed) Reveal() (lotto.Draw, Proof) {
+	return Derive(s.seed, s.Round), Proof{Round: s.Round, Commitment: s.Commitment, Seed: s.seed.String()}
+}
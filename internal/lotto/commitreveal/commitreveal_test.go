@@ -0,0 +1,238 @@
+package commitreveal
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto/fairness"
+)
+
+const (
+	testSeed       = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	testCommitment = "fa56a3878951394a626839799680933f6ff2ba62aa7815804124ecc2f1b911f3" // LOTTO-1123
+)
+
+// 다른 언어로 구현한 검증기와 맞춰 볼 수 있는 고정 벡터
+func TestDerive_KnownVectors(t *testing.T) {
+	seed, err := ParseSeed(testSeed)
+	if err != nil {
+		t.Fatalf("시드 해석 중 에러가 발생했습니다: %v", err)
+	}
+	if got := seed.Commitment("LOTTO-1123"); got != testCommitment {
+		t.Errorf("커밋값이 예상과 다릅니다. got=%s, want=%s", got, testCommitment)
+	}
+
+	tests := []struct {
+		round   string
+		winning []int
+		bonus   int
+	}{
+		{"LOTTO-1123", []int{12, 15, 28, 31, 39, 42}, 21},
+		{"LOTTO-1124", []int{11, 13, 16, 18, 24, 25}, 29},
+	}
+	for _, tt := range tests {
+		got := Derive(seed, tt.round)
+		if !slices.Equal(got.WinningNumbers, tt.winning) || got.BonusNumber != tt.bonus {
+			t.Errorf("%s 번호가 예상과 다릅니다. got=%v+%d, want=%v+%d",
+				tt.round, got.WinningNumbers, got.BonusNumber, tt.winning, tt.bonus)
+		}
+	}
+}
+
+// 회차 이름을 바꿔 가며 유도한 번호도 공정성 검정을 통과해야 한다
+func TestDerive_Fairness(t *testing.T) {
+	seed, _ := ParseSeed(testSeed)
+
+	round := 0
+	gen := func() []int {
+		round++
+		d := Derive(seed, strconv.Itoa(round))
+		if slices.Contains(d.WinningNumbers, d.BonusNumber) {
+			t.Fatalf("보너스 번호가 당첨 번호와 겹칩니다. got=%v+%d", d.WinningNumbers, d.BonusNumber)
+		}
+		return d.WinningNumbers
+	}
+
+	r, err := fairness.Run(gen, fairness.Options{Draws: 20_000})
+	if err != nil {
+		t.Fatalf("검정 중 에러가 발생했습니다: %v", err)
+	}
+	if !r.Passed() {
+		t.Errorf("유도한 번호가 공정성 검정을 통과하지 못했습니다. got=%+v", r.Failed())
+	}
+}
+
+func TestVerify(t *testing.T) {
+	otherSeed := strings.Repeat("ff", SeedSize)
+
+	tests := []struct {
+		name       string
+		req        VerifyRequest
+		commitment bool
+		checked    bool
+		valid      bool
+	}{
+		{"번호 없이 커밋값만", VerifyRequest{Round: "LOTTO-1123", Commitment: testCommitment, Seed: testSeed}, true, false, true},
+		{"번호 일치 (순서 무관)", VerifyRequest{Round: "LOTTO-1123", Commitment: strings.ToUpper(testCommitment), Seed: testSeed,
+			WinningNumbers: []int{42, 39, 31, 28, 15, 12}, BonusNumber: 21}, true, true, true},
+		{"보너스 번호 불일치", VerifyRequest{Round: "LOTTO-1123", Commitment: testCommitment, Seed: testSeed,
+			WinningNumbers: []int{12, 15, 28, 31, 39, 42}, BonusNumber: 1}, true, true, false},
+		{"다른 회차 번호", VerifyRequest{Round: "LOTTO-1123", Commitment: testCommitment, Seed: testSeed,
+			WinningNumbers: []int{11, 13, 16, 18, 24, 25}, BonusNumber: 29}, true, true, false},
+		{"다른 회차 커밋값 재사용", VerifyRequest{Round: "LOTTO-1124", Commitment: testCommitment, Seed: testSeed}, false, false, false},
+		{"커밋 후 시드 바꿔치기", VerifyRequest{Round: "LOTTO-1123", Commitment: testCommitment, Seed: otherSeed}, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Verify(tt.req)
+			if err != nil {
+				t.Fatalf("검증 중 에러가 발생했습니다: %v", err)
+			}
+			if got.CommitmentValid != tt.commitment || got.NumbersChecked != tt.checked || got.Valid != tt.valid {
+				t.Errorf("검증 결과가 예상과 다릅니다. got=%+v, want commitment=%v checked=%v valid=%v",
+					got, tt.commitment, tt.checked, tt.valid)
+			}
+		})
+	}
+}
+
+func TestVerify_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		req  VerifyRequest
+		want error
+	}{
+		{"회차 없음", VerifyRequest{Commitment: testCommitment, Seed: testSeed}, ErrInvalidRound},
+		{"짧은 시드", VerifyRequest{Round: "1", Commitment: testCommitment, Seed: "abcd"}, ErrInvalidSeed},
+		{"16진수가 아닌 시드", VerifyRequest{Round: "1", Commitment: testCommitment, Seed: strings.Repeat("zz", SeedSize)}, ErrInvalidSeed},
+		{"짧은 커밋값", VerifyRequest{Round: "1", Commitment: "abcd", Seed: testSeed}, ErrInvalidCommitment},
+	}
+	for _, tt := range tests {
+		if _, err := Verify(tt.req); !errors.Is(err, tt.want) {
+			t.Errorf("%s: 에러가 예상과 다릅니다. got=%v, want=%v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestStore_CommitReveal(t *testing.T) {
+	now := time.Date(2024, 6, 1, 19, 0, 0, 0, time.UTC)
+	s := NewStore()
+	s.now = func() time.Time { return now }
+	salesClose := now.Add(time.Hour)
+
+	committed, err := s.Commit("LOTTO-1", salesClose)
+	if err != nil {
+		t.Fatalf("커밋 중 에러가 발생했습니다: %v", err)
+	}
+	if committed.Seed != "" || committed.WinningNumbers != nil || committed.Revealed || !committed.SalesClose.Equal(salesClose) {
+		t.Errorf("공개 전에는 시드와 번호가 없고 마감 시각이 기록되어야 합니다. got=%+v", committed)
+	}
+
+	if _, err := s.Reveal(committed.ID); !errors.Is(err, ErrSalesOpen) {
+		t.Errorf("판매 마감 전 공개는 ErrSalesOpen이어야 합니다. got=%v", err)
+	}
+
+	now = salesClose
+	revealed, err := s.Reveal(committed.ID)
+	if err != nil {
+		t.Fatalf("공개 중 에러가 발생했습니다: %v", err)
+	}
+
+	v, err := Verify(VerifyRequest{
+		Round:          revealed.Round,
+		Commitment:     committed.Commitment,
+		Seed:           revealed.Seed,
+		WinningNumbers: revealed.WinningNumbers,
+		BonusNumber:    revealed.BonusNumber,
+	})
+	if err != nil || !v.Valid {
+		t.Errorf("공개한 추첨이 검증을 통과하지 못했습니다. got=%+v, err=%v", v, err)
+	}
+
+	if got, _ := s.Get(committed.ID); !got.Revealed || got.Seed != revealed.Seed {
+		t.Errorf("조회 결과에 공개 상태가 반영되지 않았습니다. got=%+v", got)
+	}
+	if _, err := s.Reveal(committed.ID); !errors.Is(err, ErrAlreadyRevealed) {
+		t.Errorf("두 번 공개하면 ErrAlreadyRevealed여야 합니다. got=%v", err)
+	}
+	if _, err := s.CloseSales(committed.ID); !errors.Is(err, ErrAlreadyRevealed) {
+		t.Errorf("공개한 추첨은 마감할 수 없어야 합니다. got=%v", err)
+	}
+	if _, err := s.Get("없는ID"); !errors.Is(err, ErrDrawNotFound) {
+		t.Errorf("없는 추첨은 ErrDrawNotFound여야 합니다. got=%v", err)
+	}
+}
+
+func TestStore_InvalidCommit(t *testing.T) {
+	now := time.Date(2024, 6, 1, 19, 0, 0, 0, time.UTC)
+	s := NewStore()
+	s.now = func() time.Time { return now }
+
+	tests := []struct {
+		name       string
+		round      string
+		salesClose time.Time
+		want       error
+	}{
+		{"회차 없음", "", now.Add(time.Hour), ErrInvalidRound},
+		{"마감 시각 없음", "LOTTO-1", time.Time{}, ErrInvalidSalesClose},
+		{"이미 지난 마감", "LOTTO-1", now.Add(-time.Minute), ErrInvalidSalesClose},
+		{"커밋과 같은 시각 마감", "LOTTO-1", now, ErrInvalidSalesClose},
+	}
+
+	for _, tt := range tests {
+		if _, err := s.Commit(tt.round, tt.salesClose); !errors.Is(err, tt.want) {
+			t.Errorf("%s: 에러가 예상과 다릅니다. got=%v, want=%v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// 시뮬레이션에서 추첨을 요청하면 예정보다 일찍 마감하고 바로 공개할 수 있다
+func TestStore_CloseSales(t *testing.T) {
+	now := time.Date(2024, 6, 1, 19, 0, 0, 0, time.UTC)
+	s := NewStore()
+	s.now = func() time.Time { return now }
+
+	committed, _ := s.Commit("LOTTO-1", now.Add(time.Hour))
+	now = now.Add(time.Minute)
+
+	closed, err := s.CloseSales(committed.ID)
+	if err != nil || !closed.SalesClose.Equal(now) {
+		t.Fatalf("마감 시각이 지금으로 당겨져야 합니다. got=%+v, err=%v", closed, err)
+	}
+	if _, err := s.Reveal(committed.ID); err != nil {
+		t.Errorf("마감한 뒤에는 공개할 수 있어야 합니다. err=%v", err)
+	}
+}
+
+func TestSealed(t *testing.T) {
+	sealed, err := Seal("kr-645-1")
+	if err != nil {
+		t.Fatalf("커밋 중 에러가 발생했습니다: %v", err)
+	}
+	if data, _ := json.Marshal(sealed); strings.Contains(string(data), "seed") {
+		t.Errorf("공개 전에는 시드를 내보내지 않아야 합니다. got=%s", data)
+	}
+
+	draw, proof := sealed.Reveal()
+	v, err := Verify(VerifyRequest{
+		Round:          proof.Round,
+		Commitment:     proof.Commitment,
+		Seed:           proof.Seed,
+		WinningNumbers: draw.WinningNumbers,
+		BonusNumber:    draw.BonusNumber,
+	})
+	if err != nil || !v.Valid {
+		t.Errorf("공개한 추첨이 검증을 통과하지 못했습니다. got=%+v, err=%v", v, err)
+	}
+
+	if _, err := Seal(""); !errors.Is(err, ErrInvalidRound) {
+		t.Errorf("회차 이름이 없으면 ErrInvalidRound여야 합니다. got=%v", err)
+	}
+}
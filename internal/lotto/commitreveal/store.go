@@ -0,0 +1,155 @@
+package commitreveal
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrDrawNotFound      = errors.New("등록되지 않은 추첨입니다")
+	ErrAlreadyRevealed   = errors.New("이미 공개된 추첨입니다")
+	ErrSalesOpen         = errors.New("판매 마감 전에는 공개할 수 없습니다")
+	ErrInvalidSalesClose = errors.New("판매 마감 시각이 올바르지 않습니다")
+)
+
+// 보관하는 최대 추첨 수. 넘치면 오래된 것부터 지운다
+const MaxDraws = 10_000
+
+// 서버가 관리하는 커밋-공개 추첨. 공개 전에는 시드와 번호를 비워 둔다
+type Draw struct {
+	ID          string     `json:"id"`
+	Round       string     `json:"round"`
+	Commitment  string     `json:"commitment"`
+	CommittedAt time.Time  `json:"committedAt"`
+	SalesClose  time.Time  `json:"salesClose"` // 이 시각 전에는 공개할 수 없다
+	Revealed    bool       `json:"revealed"`
+	RevealedAt  *time.Time `json:"revealedAt,omitempty"`
+	Seed        string     `json:"seed,omitempty"`
+
+	WinningNumbers []int `json:"winningNumbers,omitempty"`
+	BonusNumber    int   `json:"bonusNumber,omitempty"`
+}
+
+type entry struct {
+	draw Draw
+	seed Seed
+}
+
+// 메모리에 보관하는 추첨 목록 (동시 사용 안전)
+type Store struct {
+	mu    sync.Mutex
+	draws map[string]*entry
+	order []string // 등록 순서 (오래된 것부터 지우기 위함)
+	now   func() time.Time
+}
+
+func NewStore() *Store {
+	return &Store{draws: make(map[string]*entry), now: time.Now}
+}
+
+// 새 시드를 만들고 커밋값만 공개한 추첨을 등록한다
+// 판매 마감 시각은 지금 이후여야 하고, 그 전에는 Reveal이 거부된다
+func (s *Store) Commit(round string, salesClose time.Time) (Draw, error) {
+	if round == "" {
+		return Draw{}, ErrInvalidRound
+	}
+	if !salesClose.After(s.now()) {
+		return Draw{}, fmt.Errorf("%w: 커밋 시각 이후여야 합니다 (%s)", ErrInvalidSalesClose, salesClose.Format(time.RFC3339))
+	}
+	seed, err := NewSeed()
+	if err != nil {
+		return Draw{}, err
+	}
+	id, err := newID()
+	if err != nil {
+		return Draw{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := &entry{
+		seed: seed,
+		draw: Draw{ID: id, Round: round, Commitment: seed.Commitment(round), CommittedAt: s.now(), SalesClose: salesClose},
+	}
+	s.draws[id] = e
+	s.order = append(s.order, id)
+	if len(s.order) > MaxDraws {
+		delete(s.draws, s.order[0])
+		s.order = s.order[1:]
+	}
+	return e.draw, nil
+}
+
+func (s *Store) Get(id string) (Draw, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.draws[id]
+	if !ok {
+		return Draw{}, fmt.Errorf("%w: %s", ErrDrawNotFound, id)
+	}
+	return e.draw, nil
+}
+
+// 예정보다 일찍 판매를 마감한다 (시뮬레이션에서 추첨을 요청한 시점이 곧 마감)
+func (s *Store) CloseSales(id string) (Draw, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.pending(id)
+	if err != nil {
+		return Draw{}, err
+	}
+	if now := s.now(); now.Before(e.draw.SalesClose) {
+		e.draw.SalesClose = now
+	}
+	return e.draw, nil
+}
+
+// 시드를 공개하고 번호를 확정한다. 판매 마감 뒤 한 번만 가능하다
+func (s *Store) Reveal(id string) (Draw, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.pending(id)
+	if err != nil {
+		return Draw{}, err
+	}
+
+	now := s.now()
+	if now.Before(e.draw.SalesClose) {
+		return Draw{}, fmt.Errorf("%w: %s (마감 %s)", ErrSalesOpen, id, e.draw.SalesClose.Format(time.RFC3339))
+	}
+	derived := Derive(e.seed, e.draw.Round)
+	e.draw.Revealed = true
+	e.draw.RevealedAt = &now
+	e.draw.Seed = e.seed.String()
+	e.draw.WinningNumbers = derived.WinningNumbers
+	e.draw.BonusNumber = derived.BonusNumber
+	return e.draw, nil
+}
+
+// 아직 공개하지 않은 추첨 (호출자가 잠금을 잡고 있어야 한다)
+func (s *Store) pending(id string) (*entry, error) {
+	e, ok := s.draws[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrDrawNotFound, id)
+	}
+	if e.draw.Revealed {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyRevealed, id)
+	}
+	return e, nil
+}
+
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("추첨 ID를 만들지 못했습니다: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
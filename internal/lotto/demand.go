@@ -0,0 +1,117 @@
+package lotto
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// 회차별 판매 티켓 수를 정하는 수요 모델
+type DemandModel interface {
+	Baseline() int                 // 이월이 없을 때의 기본 티켓 수
+	Tickets(round DemandRound) int // 직전 회차 이월 상태를 보고 이번 회차 티켓 수 결정
+}
+
+// 수요 모델에 넘기는 이번 회차 상태
+type DemandRound struct {
+	Index       int          // 0부터 시작하는 회차 순번
+	CarryIn     map[Rank]int // 직전 회차에서 넘어온 이월 금액
+	BaseJackpot int          // 기본 판매량일 때 1등 배정액 (판매액 × 1등 bps)
+	Jackpot     int          // 광고되는 1등 금액 추정 (이월 + BaseJackpot)
+}
+
+// 이월 상태와 1등 배정 비율로 이번 회차 수요 상태 계산
+func NewDemandRound(index int, carryIn map[Rank]int, allocations []Allocation, model DemandModel) DemandRound {
+	base := model.Baseline() * LottoPrice * buildAllocationMap(allocations)[Rank1] / BasisPoints
+	return DemandRound{
+		Index:       index,
+		CarryIn:     carryIn,
+		BaseJackpot: base,
+		Jackpot:     base + carryIn[Rank1],
+	}
+}
+
+// 판매량이 항상 같은 모델 (기존 동작)
+type ConstantDemand struct {
+	N int
+}
+
+func (d ConstantDemand) Baseline() int           { return d.N }
+func (d ConstantDemand) Tickets(DemandRound) int { return d.N }
+
+// 기본 판매량 × (광고 1등 금액 / 기본 1등 금액)^탄력성 × (1 + 잡음)
+// 이월이 쌓일수록 판매가 늘어난다
+type JackpotDemand struct {
+	Base       int        // 이월이 없을 때의 티켓 수
+	Elasticity float64    // 1등 금액이 2배가 되면 판매량은 2^Elasticity배
+	Noise      float64    // 회차별 무작위 변동의 표준편차 비율 (0.05 = 5%)
+	Rng        *rand.Rand // Noise > 0이면 필요
+}
+
+func (d JackpotDemand) Baseline() int { return d.Base }
+
+func (d JackpotDemand) Tickets(round DemandRound) int {
+	multiplier := 1.0
+	if round.BaseJackpot > 0 && round.Jackpot > 0 {
+		multiplier = math.Pow(float64(round.Jackpot)/float64(round.BaseJackpot), d.Elasticity)
+	}
+	if d.Noise > 0 && d.Rng != nil {
+		multiplier *= 1 + d.Noise*d.Rng.NormFloat64()
+	}
+	return max(int(math.Round(float64(d.Base)*multiplier)), 0)
+}
+
+// 티켓 수에 따른 이번 회차 당첨자 수 (확률 표본 등)
+type WinnerSampler func(round int, tickets int) map[Rank]int
+
+// 수요 모델로 회차마다 판매량을 정하고, 그 판매량으로 당첨자를 뽑아 시리즈 실행
+// 판매량은 직전 회차의 이월 상태에 따라 달라진다
+func SimulateDemandSeries(
+	cfg SeriesConfig,
+	rounds int,
+	demand DemandModel,
+	sample WinnerSampler,
+	carryIn map[Rank]int,
+) ([]RoundOutput, error) {
+	if rounds <= 0 {
+		return nil, fmt.Errorf("회차 수는 1 이상이어야 합니다: %d", rounds)
+	}
+
+	results := make([]RoundOutput, 0, rounds)
+	carry := cloneRankIntMap(carryIn)
+
+	for i := range rounds {
+		tickets := demand.Tickets(NewDemandRound(i, carry, cfg.Allocations, demand))
+		input := cfg.roundInput(cfg.roundMeta(i), tickets*LottoPrice, sample(i, tickets), carry)
+
+		out, err := CalculateRound(input)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, out)
+		carry = cloneRankIntMap(out.CarryOut)
+	}
+
+	return results, nil
+}
+
+// 플레이어 티켓 외의 일반 구매자 판매분
+// 회차별 티켓 수는 수요 모델로 정하고 당첨자 수는 확률로 뽑는다
+type Market struct {
+	Demand DemandModel
+	Rng    *rand.Rand
+}
+
+// 회차 입력에 시장 판매액과 시장 당첨자 수를 더한 입력과 시장 티켓 수 반환
+// 판매량은 입력의 이월 상태(CarryIn)와 1등 배정 비율로 정한다
+func (m Market) AddTo(in RoundInput, index int) (RoundInput, int) {
+	tickets := m.Demand.Tickets(NewDemandRound(index, in.CarryIn, in.Allocations, m.Demand))
+
+	winners := cloneRankIntMap(in.Winners)
+	mergeStats(winners, SampleWinners(m.Rng, tickets))
+
+	in.Sales += tickets * LottoPrice
+	in.Winners = winners
+	return in, tickets
+}
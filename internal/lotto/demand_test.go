@@ -0,0 +1,87 @@
+package lotto
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestJackpotDemand(t *testing.T) {
+	demand := JackpotDemand{Base: 1_000, Elasticity: 1}
+	allocs := []Allocation{{Rank: Rank1, BasisPoints: 5_000}}
+
+	tests := []struct {
+		name  string
+		carry map[Rank]int
+		want  int
+	}{
+		{"이월 없음", nil, 1_000},
+		{"1등 금액 2배", map[Rank]int{Rank1: 500_000}, 2_000},
+		{"1등 금액 4배", map[Rank]int{Rank1: 1_500_000}, 4_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			round := NewDemandRound(0, tt.carry, allocs, demand)
+			if got := demand.Tickets(round); got != tt.want {
+				t.Errorf("티켓 수가 예상과 다릅니다. got=%d, want=%d", got, tt.want)
+			}
+		})
+	}
+}
+
+// 1등 배정이 없으면(고정 상금 등) 탄력성과 무관하게 기본 판매량
+func TestJackpotDemand_NoJackpotAllocation(t *testing.T) {
+	demand := JackpotDemand{Base: 1_000, Elasticity: 2}
+	round := NewDemandRound(0, map[Rank]int{Rank1: 1_000_000}, nil, demand)
+
+	if got := demand.Tickets(round); got != 1_000 {
+		t.Errorf("티켓 수가 예상과 다릅니다. got=%d, want=%d", got, 1_000)
+	}
+}
+
+// 1등이 안 나와 이월되면 다음 회차 판매량이 늘어야 한다
+func TestSimulateDemandSeries(t *testing.T) {
+	cfg := SeriesConfig{
+		Mode:         ModeParimutuel,
+		Allocations:  []Allocation{{Rank: Rank1, BasisPoints: 5_000}},
+		RoundingUnit: 1,
+	}
+	demand := JackpotDemand{Base: 1_000, Elasticity: 1}
+	noWinners := func(int, int) map[Rank]int { return map[Rank]int{} }
+
+	outs, err := SimulateDemandSeries(cfg, 3, demand, noWinners, nil)
+	if err != nil {
+		t.Fatalf("시리즈 실행 중 에러가 발생했습니다: %v", err)
+	}
+
+	wantSales := []int{1_000_000, 2_000_000, 4_000_000}
+	for i, out := range outs {
+		if out.Sales != wantSales[i] {
+			t.Errorf("%d회차 판매액이 예상과 다릅니다. got=%d, want=%d", i+1, out.Sales, wantSales[i])
+		}
+	}
+
+	if _, err := SimulateDemandSeries(cfg, 0, demand, noWinners, nil); err == nil {
+		t.Errorf("회차 수가 0이면 에러가 발생해야 합니다")
+	}
+}
+
+func TestMarketAddTo(t *testing.T) {
+	market := Market{Demand: ConstantDemand{N: 10_000_000}, Rng: rand.New(rand.NewSource(1))}
+	in := RoundInput{Sales: 5_000, Winners: map[Rank]int{Rank5: 1}}
+
+	got, tickets := market.AddTo(in, 0)
+
+	if tickets != 10_000_000 {
+		t.Errorf("시장 티켓 수가 예상과 다릅니다. got=%d", tickets)
+	}
+	if got.Sales != 5_000+10_000_000*LottoPrice {
+		t.Errorf("판매액에 시장 판매분이 더해져야 합니다. got=%d", got.Sales)
+	}
+	if got.Winners[Rank5] <= 1 {
+		t.Errorf("시장 당첨자가 더해져야 합니다. got=%d", got.Winners[Rank5])
+	}
+	if in.Winners[Rank5] != 1 {
+		t.Errorf("원래 입력의 당첨자 수는 바뀌면 안 됩니다. got=%d", in.Winners[Rank5])
+	}
+}
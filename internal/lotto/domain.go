@@ -1,6 +1,7 @@
 package lotto
 
 import (
+	"fmt"
 	"math/rand"
 	"sort"
 )
@@ -41,6 +42,25 @@ func PurchaseLottos(amount int) (Lottos, error) {
 	}, nil
 }
 
+// 직접 고른 번호로 티켓 생성 (개수/범위/중복 검증 후 오름차순 정렬)
+func NewLotto(numbers []int) (Lotto, error) {
+	if len(numbers) != LottoSize {
+		return Lotto{}, fmt.Errorf("티켓 번호는 %d개여야 합니다. 입력 개수: %d", LottoSize, len(numbers))
+	}
+	for _, n := range numbers {
+		if err := validateRange(n); err != nil {
+			return Lotto{}, err
+		}
+	}
+	if err := validateNoDuplicates(numbers); err != nil {
+		return Lotto{}, err
+	}
+
+	sorted := append([]int(nil), numbers...)
+	sort.Ints(sorted)
+	return Lotto{Numbers: sorted}, nil
+}
+
 func (l *Lottos) SetWinningNumbers(input string) error {
 	parsed, err := parseWinningNumbers(input)
 	if err != nil {
@@ -85,11 +105,26 @@ func (ls Lottos) CompileStatistics() map[Rank]int {
 	return stats
 }
 
-func generateRandomNumbers() []int {
+// 자동 번호를 뽑는 난수원. *rand.Rand를 그대로 넘길 수 있다
+type NumberSource interface {
+	Intn(n int) int
+}
+
+// math/rand 전역 난수원. 자동 구매(PurchaseLottos)가 사용한다
+var DefaultSource NumberSource = globalSource{}
+
+type globalSource struct{}
+
+func (globalSource) Intn(n int) int {
+	return rand.Intn(n)
+}
+
+// 주어진 난수원으로 뽑은 자동 번호 (오름차순)
+func RandomNumbers(src NumberSource) []int {
 	numbers := make([]int, 0, LottoSize)
 
 	for len(numbers) < LottoSize {
-		num := rand.Intn(LottoMaxNum) + 1
+		num := src.Intn(LottoMaxNum) + 1
 		if !contains(numbers, num) {
 			numbers = append(numbers, num)
 		}
@@ -98,3 +133,7 @@ func generateRandomNumbers() []int {
 
 	return numbers
 }
+
+func generateRandomNumbers() []int {
+	return RandomNumbers(DefaultSource)
+}
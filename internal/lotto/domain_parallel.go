@@ -8,10 +8,13 @@ import (
 func (ls Lottos) CompileStatisticsParallel() map[Rank]int {
 	if len(ls.Lottos) < 100 {
 		// 티켓이 적으면 오버헤드가 더 클 수 있으므로 순차 처리
+		compileStatisticsMetrics.sequential.Inc()
 		return ls.CompileStatistics()
 	}
 
 	const numWorkers = 4
+	started := compileStatisticsMetrics.begin()
+	defer compileStatisticsMetrics.finish(started, numWorkers)
 	ticketsPerWorker := len(ls.Lottos) / numWorkers
 	if ticketsPerWorker == 0 {
 		ticketsPerWorker = 1
@@ -31,6 +34,7 @@ func (ls Lottos) CompileStatisticsParallel() map[Rank]int {
 		wg.Add(1)
 		go func(start, end int) {
 			defer wg.Done()
+			defer compileStatisticsMetrics.workerDone(compileStatisticsMetrics.workerStart())
 
 			localStats := make(map[Rank]int)
 			for j := start; j < end; j++ {
@@ -75,17 +79,18 @@ func DistributeRewardsParallel(
 
 	if totalTickets < 100 {
 		// 티켓이 적으면 오버헤드가 더 클 수 있으므로 순차 처리
+		distributeRewardsMetrics.sequential.Inc()
 		return DistributeRewards(players, winning, out)
 	}
 
-	const numWorkers = 4
+	// 플레이어 단위로 나누므로 플레이어가 4명보다 적으면 그 수만큼만 사용
+	numWorkers := min(4, len(players))
+	started := distributeRewardsMetrics.begin()
+	defer distributeRewardsMetrics.finish(started, numWorkers)
 	rewardsChan := make(chan map[string]int, numWorkers)
 	var wg sync.WaitGroup
 
 	playersPerWorker := len(players) / numWorkers
-	if playersPerWorker == 0 {
-		playersPerWorker = 1
-	}
 
 	// Worker goroutine들 생성
 	for i := 0; i < numWorkers; i++ {
@@ -98,6 +103,7 @@ func DistributeRewardsParallel(
 		wg.Add(1)
 		go func(start, end int) {
 			defer wg.Done()
+			defer distributeRewardsMetrics.workerDone(distributeRewardsMetrics.workerStart())
 
 			localRewards := calculateRewardsForPlayersRange(
 				players[start:end],
@@ -0,0 +1,131 @@
+package lotto
+
+// 한 회차 추첨 결과 (직접 입력, 과거 기록 가져오기 등 출처와 무관)
+type Draw struct {
+	Meta           RoundMeta `json:"meta"`
+	WinningNumbers []int     `json:"winningNumbers"`
+	BonusNumber    int       `json:"bonusNumber"`
+}
+
+// 티켓 묶음에 이 회차 당첨 번호를 붙인 Lottos
+func (d Draw) Lottos(tickets []Lotto) Lottos {
+	return Lottos{
+		Lottos:         tickets,
+		WinningNumbers: d.WinningNumbers,
+		BonusNumber:    d.BonusNumber,
+	}
+}
+
+// 플레이어 시리즈의 한 회차 결과
+type SeriesRound struct {
+	Draw    Draw           `json:"draw"`
+	Input   RoundInput     `json:"input"`
+	Output  RoundOutput    `json:"output"`
+	Payouts map[string]int `json:"payouts"` // 플레이어별 수령액
+}
+
+// 다음 회차 티켓을 사는 방법. 직전 회차 플레이어를 받아 이번 회차 플레이어를 돌려준다
+type Repurchase func(round int, players []Player) ([]Player, error)
+
+// 플레이어마다 직전 회차와 같은 장수를 src로 새로 뽑는다
+func RandomRepurchase(src NumberSource) Repurchase {
+	return func(_ int, players []Player) ([]Player, error) {
+		next := make([]Player, 0, len(players))
+		for _, p := range players {
+			tickets := make([]Lotto, 0, len(p.Tickets))
+			for range p.Tickets {
+				tickets = append(tickets, Lotto{Numbers: RandomNumbers(src)})
+			}
+			next = append(next, Player{Name: p.Name, Tickets: tickets})
+		}
+		return next, nil
+	}
+}
+
+// 플레이어들의 티켓을 회차별 추첨 결과에 대조해 시리즈 실행
+// 판매액은 매 회차 플레이어 티켓 수 × 장당 가격
+// 둘째 회차부터 repurchase로 티켓을 다시 산다. nil이면 첫 회차 번호를 계속 쓴다 (금액은 매 회차 지불)
+func SimulateDrawSeries(
+	cfg SeriesConfig,
+	players []Player,
+	draws []Draw,
+	carryIn map[Rank]int,
+	repurchase Repurchase,
+) ([]SeriesRound, error) {
+	rounds := make([]SeriesRound, 0, len(draws))
+	err := RunDrawSeries(cfg, players, draws, carryIn, repurchase, func(r SeriesRound) error {
+		rounds = append(rounds, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rounds, nil
+}
+
+// SimulateDrawSeries와 같지만 회차를 마칠 때마다 fn에 넘긴다 (진행 상황 보고, 중간 취소용)
+// fn이 에러를 돌려주면 남은 회차를 건너뛰고 그 에러를 그대로 돌려준다
+func RunDrawSeries(
+	cfg SeriesConfig,
+	players []Player,
+	draws []Draw,
+	carryIn map[Rank]int,
+	repurchase Repurchase,
+	fn func(SeriesRound) error,
+) error {
+	carry := cloneRankIntMap(carryIn)
+
+	for i, d := range draws {
+		meta := d.Meta
+		if meta.Sequence == 0 {
+			meta = cfg.roundMeta(i)
+		}
+
+		if i > 0 && repurchase != nil {
+			next, err := repurchase(i, players)
+			if err != nil {
+				return err
+			}
+			players = next
+		}
+
+		sales := 0
+		for _, p := range players {
+			sales += len(p.Tickets) * LottoPrice
+		}
+
+		winning := d.Lottos(nil)
+		in := cfg.roundInput(meta, sales, CountWinnersFromPlayers(players, winning), carry)
+
+		out, err := CalculateRound(in)
+		if err != nil {
+			return err
+		}
+
+		err = fn(SeriesRound{
+			Draw:    d,
+			Input:   in,
+			Output:  out,
+			Payouts: DistributeRewardsParallel(players, winning, out),
+		})
+		if err != nil {
+			return err
+		}
+
+		carry = cloneRankIntMap(out.CarryOut)
+	}
+
+	return nil
+}
+
+// 주어진 난수원으로 뽑은 추첨 결과 (당첨 번호 6개 + 보너스 1개, 서로 다른 번호)
+func RandomDraw(src NumberSource, meta RoundMeta) Draw {
+	winning := RandomNumbers(src)
+
+	bonus := src.Intn(LottoMaxNum) + 1
+	for contains(winning, bonus) {
+		bonus = src.Intn(LottoMaxNum) + 1
+	}
+
+	return Draw{Meta: meta, WinningNumbers: winning, BonusNumber: bonus}
+}
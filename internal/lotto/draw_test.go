@@ -0,0 +1,134 @@
+package lotto
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// 회차별 추첨 결과로 플레이어 시리즈를 돌리면 당첨자 집계/이월/지급이 이어지는지 검증
+func TestSimulateDrawSeries(t *testing.T) {
+	cfg := SeriesConfig{
+		Mode:         ModeParimutuel,
+		Allocations:  []Allocation{{Rank: Rank1, BasisPoints: 10_000}},
+		RoundingUnit: 1,
+	}
+	players := []Player{
+		{Name: "a", Tickets: []Lotto{{Numbers: []int{1, 2, 3, 4, 5, 6}}}},
+		{Name: "b", Tickets: []Lotto{{Numbers: []int{10, 11, 12, 13, 14, 15}}}},
+	}
+	draws := []Draw{
+		{Meta: RoundMeta{Sequence: 100}, WinningNumbers: []int{20, 21, 22, 23, 24, 25}, BonusNumber: 26},
+		{Meta: RoundMeta{Sequence: 101}, WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7},
+	}
+
+	rounds, err := SimulateDrawSeries(cfg, players, draws, nil, nil)
+	if err != nil {
+		t.Fatalf("시리즈 시뮬레이션 중 에러가 발생했습니다. err=%v", err)
+	}
+
+	if rounds[0].Output.CarryOut[Rank1] != 2_000 {
+		t.Errorf("1회차 이월 금액이 예상과 다릅니다. got=%d, want=%d", rounds[0].Output.CarryOut[Rank1], 2_000)
+	}
+	if rounds[1].Output.Meta.Sequence != 101 {
+		t.Errorf("추첨 결과의 회차 번호가 유지되어야 합니다. got=%d", rounds[1].Output.Meta.Sequence)
+	}
+	if rounds[1].Payouts["a"] != 4_000 {
+		t.Errorf("2회차 a 수령액이 예상과 다릅니다. got=%d, want=%d", rounds[1].Payouts["a"], 4_000)
+	}
+	if rounds[1].Payouts["b"] != 0 {
+		t.Errorf("2회차 b 수령액은 0이어야 합니다. got=%d", rounds[1].Payouts["b"])
+	}
+}
+
+// 콜백이 에러를 돌려주면 남은 회차를 실행하지 않고 그 에러를 돌려주는지 검증
+func TestRunDrawSeriesStops(t *testing.T) {
+	cfg := SeriesConfig{Mode: ModeFixedPayout}
+	players := []Player{{Name: "a", Tickets: []Lotto{{Numbers: []int{1, 2, 3, 4, 5, 6}}}}}
+	draws := []Draw{
+		{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7},
+		{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7},
+		{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7},
+	}
+	stop := errors.New("중단")
+
+	calls := 0
+	err := RunDrawSeries(cfg, players, draws, nil, nil, func(r SeriesRound) error {
+		calls++
+		if r.Output.Meta.Sequence != calls {
+			t.Errorf("%d번째 회차 번호 = %d", calls, r.Output.Meta.Sequence)
+		}
+		if calls == 2 {
+			return stop
+		}
+		return nil
+	})
+
+	if !errors.Is(err, stop) {
+		t.Errorf("콜백 에러가 그대로 돌아와야 합니다. got=%v", err)
+	}
+	if calls != 2 {
+		t.Errorf("중단한 뒤에는 회차를 더 실행하지 않아야 합니다. calls=%d", calls)
+	}
+}
+
+// 둘째 회차부터 같은 장수를 새로 사고, 판매액은 그 회차 티켓 수로 계산하는지 검증
+func TestRunDrawSeriesRepurchase(t *testing.T) {
+	cfg := SeriesConfig{Mode: ModeFixedPayout}
+	first := []Lotto{{Numbers: []int{1, 2, 3, 4, 5, 6}}, {Numbers: []int{1, 2, 3, 4, 5, 6}}}
+	players := []Player{{Name: "a", Tickets: first}}
+	draws := []Draw{
+		{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7},
+		{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7},
+		{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7},
+	}
+
+	var seen []int
+	repurchase := func(round int, prev []Player) ([]Player, error) {
+		seen = append(seen, round)
+		return RandomRepurchase(rand.New(rand.NewSource(1)))(round, prev)
+	}
+
+	rounds, err := SimulateDrawSeries(cfg, players, draws, nil, repurchase)
+	if err != nil {
+		t.Fatalf("시리즈 시뮬레이션 중 에러가 발생했습니다. err=%v", err)
+	}
+
+	if !slices.Equal(seen, []int{1, 2}) {
+		t.Errorf("둘째 회차부터 다시 구매해야 합니다. got=%v", seen)
+	}
+	for i, r := range rounds {
+		if r.Input.Sales != 2*LottoPrice {
+			t.Errorf("%d회차 판매액이 예상과 다릅니다. got=%d, want=%d", i+1, r.Input.Sales, 2*LottoPrice)
+		}
+	}
+	if rounds[0].Input.Winners[Rank1] != 2 {
+		t.Errorf("첫 회차는 입력한 티켓을 써야 합니다. got=%v", rounds[0].Input.Winners)
+	}
+	if len(players[0].Tickets) != len(first) || !slices.Equal(players[0].Tickets[0].Numbers, first[0].Numbers) {
+		t.Errorf("입력한 플레이어 티켓은 바뀌지 않아야 합니다. got=%v", players[0].Tickets)
+	}
+}
+
+// 새로 산 티켓은 장수가 같고 유효한 번호인지 검증
+func TestRandomRepurchase(t *testing.T) {
+	players := []Player{
+		{Name: "a", Tickets: make([]Lotto, 3)},
+		{Name: "b"},
+	}
+
+	next, err := RandomRepurchase(rand.New(rand.NewSource(1)))(1, players)
+	if err != nil {
+		t.Fatalf("구매 중 에러가 발생했습니다. err=%v", err)
+	}
+
+	if len(next) != 2 || next[0].Name != "a" || len(next[0].Tickets) != 3 || len(next[1].Tickets) != 0 {
+		t.Fatalf("플레이어별 장수가 유지되어야 합니다. got=%+v", next)
+	}
+	for _, ticket := range next[0].Tickets {
+		if _, err := NewLotto(ticket.Numbers); err != nil {
+			t.Errorf("유효하지 않은 번호입니다. got=%v, err=%v", ticket.Numbers, err)
+		}
+	}
+}
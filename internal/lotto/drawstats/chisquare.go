@@ -0,0 +1,72 @@
+package drawstats
+
+import "math"
+
+// 관측 빈도와 균등 기대 빈도의 카이제곱 적합도 검정
+func ChiSquareTest(observed []int, expected float64) ChiSquare {
+	stat := 0.0
+	for _, o := range observed {
+		d := float64(o) - expected
+		stat += d * d / expected
+	}
+
+	df := len(observed) - 1
+	return ChiSquare{
+		Statistic: stat,
+		DF:        df,
+		PValue:    PValue(stat, df),
+	}
+}
+
+// 카이제곱 통계량의 상단 꼬리 확률
+func PValue(stat float64, df int) float64 {
+	return upperGamma(float64(df)/2, stat/2)
+}
+
+// 정규화된 상부 불완전 감마 함수 Q(a, x). 카이제곱 p값 = Q(df/2, stat/2)
+// x < a+1 이면 급수, 아니면 연분수로 계산
+func upperGamma(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lgammaA, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lgammaA)
+
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < 1000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if term < sum*1e-15 {
+				break
+			}
+		}
+		return 1 - prefix*sum
+	}
+
+	// 변형 Lentz 방법
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < 1000; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return prefix * h
+}
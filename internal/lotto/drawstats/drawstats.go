@@ -0,0 +1,234 @@
+package drawstats
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+var ErrNoDraws = errors.New("분석할 추첨 결과가 없습니다")
+
+// 분석 조건
+type Options struct {
+	Top          int  // 인기 번호/쌍/3개 조합 등 순위 목록 길이 (0이면 10)
+	IncludeBonus bool // 번호별 빈도/간격에 보너스 번호도 포함
+}
+
+// 번호 하나의 출현 통계
+type NumberStat struct {
+	Number   int     `json:"number"`
+	Count    int     `json:"count"`
+	Expected float64 `json:"expected"` // 균등할 때 기대 출현 수
+	Gap      int     `json:"gap"`      // 마지막 출현 이후 지난 회차 수 (한 번도 안 나왔으면 전체 회차 수)
+}
+
+// 함께 나온 번호 묶음과 횟수
+type Combo struct {
+	Numbers []int `json:"numbers"`
+	Count   int   `json:"count"`
+}
+
+// 당첨 번호 합 구간별 회차 수 [From, To]
+type SumBucket struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Count int `json:"count"`
+}
+
+// 홀수 개수별 회차 수와 균등 추첨일 때의 기대 비율
+type OddEven struct {
+	Odd      int     `json:"odd"`
+	Count    int     `json:"count"`
+	Expected float64 `json:"expected"`
+}
+
+// 번호별 출현 수가 균등한지에 대한 카이제곱 적합도 검정
+type ChiSquare struct {
+	Statistic float64 `json:"statistic"`
+	DF        int     `json:"df"`
+	PValue    float64 `json:"pValue"` // 작을수록 균등하지 않다 (보통 0.05 미만이면 의심)
+}
+
+type Report struct {
+	Draws   int          `json:"draws"`
+	Numbers []NumberStat `json:"numbers"` // 1~45 순서
+
+	Hot     []NumberStat `json:"hot"`     // 많이 나온 순
+	Cold    []NumberStat `json:"cold"`    // 적게 나온 순
+	Overdue []NumberStat `json:"overdue"` // 오래 안 나온 순
+
+	Pairs    []Combo `json:"pairs"`
+	Triplets []Combo `json:"triplets"`
+
+	Sums        []SumBucket `json:"sums"`
+	SumMean     float64     `json:"sumMean"`
+	SumStdDev   float64     `json:"sumStdDev"`
+	SumExpected float64     `json:"sumExpected"` // 균등 추첨일 때 합의 기대값 (138)
+
+	OddEven   []OddEven `json:"oddEven"` // 홀수 0~6개
+	ChiSquare ChiSquare `json:"chiSquare"`
+}
+
+// 합 구간 너비
+const sumBucketWidth = 20
+
+// 추첨 결과(당첨 번호 + 보너스)를 회차 순서대로 받아 분석
+func Analyze(draws []lotto.Lottos, opts Options) (Report, error) {
+	if len(draws) == 0 {
+		return Report{}, ErrNoDraws
+	}
+	top := opts.Top
+	if top <= 0 {
+		top = 10
+	}
+
+	perDraw := lotto.LottoSize
+	if opts.IncludeBonus {
+		perDraw++
+	}
+
+	r := Report{Draws: len(draws)}
+	counts := make([]int, lotto.LottoMaxNum+1)
+	lastSeen := make([]int, lotto.LottoMaxNum+1)
+	for i := range lastSeen {
+		lastSeen[i] = -1
+	}
+	pairs := make(map[[3]int]int) // 세 번째 자리는 비워 둔다
+	triplets := make(map[[3]int]int)
+	oddCounts := make([]int, lotto.LottoSize+1)
+	sums := make([]float64, 0, len(draws))
+
+	for idx, d := range draws {
+		numbers := slices.Clone(d.WinningNumbers)
+		sort.Ints(numbers)
+
+		counted := numbers
+		if opts.IncludeBonus && d.BonusNumber > 0 {
+			counted = append(slices.Clone(numbers), d.BonusNumber)
+		}
+		for _, n := range counted {
+			counts[n]++
+			lastSeen[n] = idx
+		}
+
+		sum, odd := 0, 0
+		for i, a := range numbers {
+			sum += a
+			if a%2 == 1 {
+				odd++
+			}
+			for j := i + 1; j < len(numbers); j++ {
+				pairs[[3]int{a, numbers[j]}]++
+				for k := j + 1; k < len(numbers); k++ {
+					triplets[[3]int{a, numbers[j], numbers[k]}]++
+				}
+			}
+		}
+		sums = append(sums, float64(sum))
+		if odd < len(oddCounts) {
+			oddCounts[odd]++
+		}
+	}
+
+	expected := float64(len(draws)*perDraw) / lotto.LottoMaxNum
+	for n := lotto.LottoMinNum; n <= lotto.LottoMaxNum; n++ {
+		gap := len(draws)
+		if lastSeen[n] >= 0 {
+			gap = len(draws) - 1 - lastSeen[n]
+		}
+		r.Numbers = append(r.Numbers, NumberStat{Number: n, Count: counts[n], Expected: expected, Gap: gap})
+	}
+
+	r.Hot = ranked(r.Numbers, top, func(a, b NumberStat) bool { return a.Count > b.Count })
+	r.Cold = ranked(r.Numbers, top, func(a, b NumberStat) bool { return a.Count < b.Count })
+	r.Overdue = ranked(r.Numbers, top, func(a, b NumberStat) bool { return a.Gap > b.Gap })
+
+	r.Pairs = topCombos(pairs, 2, top)
+	r.Triplets = topCombos(triplets, 3, top)
+
+	r.Sums = sumBuckets(sums)
+	r.SumMean, r.SumStdDev = meanStdDev(sums)
+	r.SumExpected = float64(lotto.LottoSize*(lotto.LottoMinNum+lotto.LottoMaxNum)) / 2
+
+	for odd, count := range oddCounts {
+		r.OddEven = append(r.OddEven, OddEven{Odd: odd, Count: count, Expected: oddProbability(odd)})
+	}
+
+	r.ChiSquare = ChiSquareTest(counts[lotto.LottoMinNum:], expected)
+	return r, nil
+}
+
+// less 순으로 정렬한 앞 top개 (같으면 번호 순)
+func ranked(stats []NumberStat, top int, less func(a, b NumberStat) bool) []NumberStat {
+	sorted := slices.Clone(stats)
+	sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+	return sorted[:min(top, len(sorted))]
+}
+
+// 많이 나온 순 (같으면 번호 사전순) 앞 top개. 키의 앞 size개가 번호
+func topCombos(counts map[[3]int]int, size, top int) []Combo {
+	combos := make([]Combo, 0, len(counts))
+	for key, count := range counts {
+		combos = append(combos, Combo{Numbers: slices.Clone(key[:size]), Count: count})
+	}
+	sort.Slice(combos, func(i, j int) bool {
+		if combos[i].Count != combos[j].Count {
+			return combos[i].Count > combos[j].Count
+		}
+		return slices.Compare(combos[i].Numbers, combos[j].Numbers) < 0
+	})
+	return combos[:min(top, len(combos))]
+}
+
+// 가능한 합(21~255)을 sumBucketWidth 단위로 나눈 구간
+func sumBuckets(sums []float64) []SumBucket {
+	lowest := lotto.LottoSize * (lotto.LottoSize + 1) / 2
+	highest := 0
+	for i := range lotto.LottoSize {
+		highest += lotto.LottoMaxNum - i
+	}
+
+	var buckets []SumBucket
+	for from := lowest; from <= highest; from += sumBucketWidth {
+		buckets = append(buckets, SumBucket{From: from, To: min(from+sumBucketWidth-1, highest)})
+	}
+	for _, s := range sums {
+		buckets[(int(s)-lowest)/sumBucketWidth].Count++
+	}
+	return buckets
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// 균등 추첨에서 6개 중 홀수가 odd개일 확률 (초기하분포)
+func oddProbability(odd int) float64 {
+	odds := (lotto.LottoMaxNum + 1) / 2
+	evens := lotto.LottoMaxNum - odds
+	return binomial(odds, odd) * binomial(evens, lotto.LottoSize-odd) / binomial(lotto.LottoMaxNum, lotto.LottoSize)
+}
+
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+package drawstats
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+func TestAnalyze(t *testing.T) {
+	draws := []lotto.Lottos{
+		{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7},
+		{WinningNumbers: []int{1, 2, 3, 10, 20, 30}, BonusNumber: 45},
+		{WinningNumbers: []int{30, 2, 40, 41, 42, 1}, BonusNumber: 3},
+	}
+
+	r, err := Analyze(draws, Options{Top: 3})
+	if err != nil {
+		t.Fatalf("분석 중 에러가 발생했습니다: %v", err)
+	}
+
+	tests := []struct {
+		number, count, gap int
+	}{
+		{1, 3, 0},
+		{3, 2, 1},
+		{6, 1, 2},
+		{45, 0, 3}, // 보너스는 세지 않는다
+	}
+	for _, tt := range tests {
+		got := r.Numbers[tt.number-1]
+		if got.Count != tt.count || got.Gap != tt.gap {
+			t.Errorf("%d번 통계가 예상과 다릅니다. got=%+v, want count=%d gap=%d", tt.number, got, tt.count, tt.gap)
+		}
+	}
+
+	if r.Hot[0].Number != 1 || r.Hot[1].Number != 2 {
+		t.Errorf("가장 많이 나온 번호가 예상과 다릅니다. got=%+v", r.Hot)
+	}
+	if !slices.Equal(r.Pairs[0].Numbers, []int{1, 2}) || r.Pairs[0].Count != 3 {
+		t.Errorf("가장 많이 나온 쌍이 예상과 다릅니다. got=%+v", r.Pairs[0])
+	}
+	if !slices.Equal(r.Triplets[0].Numbers, []int{1, 2, 3}) || r.Triplets[0].Count != 2 {
+		t.Errorf("가장 많이 나온 3개 조합이 예상과 다릅니다. got=%+v", r.Triplets[0])
+	}
+
+	// 합: 21, 66, 156
+	if math.Abs(r.SumMean-81) > 1e-9 || r.Sums[0].Count != 1 {
+		t.Errorf("합 통계가 예상과 다릅니다. mean=%g, first=%+v", r.SumMean, r.Sums[0])
+	}
+	// 홀수 개수: 3, 2, 2
+	if r.OddEven[2].Count != 2 || r.OddEven[3].Count != 1 {
+		t.Errorf("홀짝 분포가 예상과 다릅니다. got=%+v", r.OddEven)
+	}
+	total := 0.0
+	for _, oe := range r.OddEven {
+		total += oe.Expected
+	}
+	if math.Abs(total-1) > 1e-12 {
+		t.Errorf("홀수 개수 기대 비율의 합은 1이어야 합니다. got=%g", total)
+	}
+
+	withBonus, _ := Analyze(draws, Options{IncludeBonus: true})
+	if got := withBonus.Numbers[44]; got.Count != 1 || got.Gap != 1 {
+		t.Errorf("보너스 포함 시 45번 통계가 예상과 다릅니다. got=%+v", got)
+	}
+
+	if _, err := Analyze(nil, Options{}); !errors.Is(err, ErrNoDraws) {
+		t.Errorf("빈 기록은 ErrNoDraws여야 합니다. got=%v", err)
+	}
+}
+
+func TestUpperGamma(t *testing.T) {
+	tests := []struct {
+		a, x, want float64
+	}{
+		{1, 0.5, math.Exp(-0.5)},       // 자유도 2
+		{1, 5, math.Exp(-5)},           // 연분수 구간
+		{22, 30.24, 0.05},              // 자유도 44의 5% 임계값 60.48
+		{0.5, 1.920729, 1 - 0.95},      // 자유도 1의 5% 임계값 3.841
+		{2.5, 2.5, 0.4158801869955079}, // 자유도 5, 통계량 5
+		{22, 0, 1},
+	}
+
+	for _, tt := range tests {
+		if got := upperGamma(tt.a, tt.x); math.Abs(got-tt.want) > 1e-3 {
+			t.Errorf("Q(%g, %g)가 예상과 다릅니다. got=%g, want=%g", tt.a, tt.x, got, tt.want)
+		}
+	}
+}
+
+// 공정한 난수열은 균등 검정을 통과하고, 한쪽으로 쏠린 추첨은 걸러져야 한다
+func TestChiSquareDetectsBias(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	fair := make([]lotto.Lottos, 0, 2000)
+	biased := make([]lotto.Lottos, 0, 2000)
+	for range 2000 {
+		d := lotto.RandomDraw(rng, lotto.RoundMeta{})
+		fair = append(fair, d.Lottos(nil))
+
+		numbers := lotto.RandomNumbers(rng)
+		if numbers[0] > 10 && rng.Intn(3) == 0 {
+			numbers[0] = 1 // 1번이 더 자주 나오는 추첨기
+		}
+		biased = append(biased, lotto.Lottos{WinningNumbers: numbers})
+	}
+
+	fairReport, _ := Analyze(fair, Options{})
+	if fairReport.ChiSquare.DF != 44 || fairReport.ChiSquare.PValue < 0.001 {
+		t.Errorf("공정한 추첨이 균등 검정을 통과하지 못했습니다. got=%+v", fairReport.ChiSquare)
+	}
+
+	biasedReport, _ := Analyze(biased, Options{})
+	if biasedReport.ChiSquare.PValue > 1e-6 {
+		t.Errorf("쏠린 추첨을 걸러내지 못했습니다. got=%+v", biasedReport.ChiSquare)
+	}
+}
@@ -0,0 +1,326 @@
+package fairness
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/drawstats"
+)
+
+var ErrInvalidOptions = errors.New("유효하지 않은 검정 조건입니다")
+
+const (
+	DefaultDraws = 1_000_000
+	DefaultAlpha = 0.001
+	MinDraws     = 1_000 // 쌍 검정의 칸별 기대 빈도가 5 이상이 되는 최소 규모
+
+	pairCount = lotto.LottoMaxNum * (lotto.LottoMaxNum - 1) / 2 // 990
+	sumMean   = lotto.LottoSize * (lotto.LottoMinNum + lotto.LottoMaxNum) / 2
+)
+
+// 번호 한 세트(6개)를 뽑는 생성기
+type Generator func() []int
+
+// 자동 구매와 같은 경로(lotto.RandomNumbers)로 번호를 뽑는 생성기
+func Auto(src lotto.NumberSource) Generator {
+	return func() []int {
+		return lotto.RandomNumbers(src)
+	}
+}
+
+type Options struct {
+	Draws int     // 뽑을 세트 수 (0이면 DefaultDraws)
+	Alpha float64 // 유의수준. p값이 이보다 작으면 실패 (0이면 DefaultAlpha)
+}
+
+// 검정 하나의 결과
+type Check struct {
+	Name      string  `json:"name"`
+	Statistic float64 `json:"statistic"`    // 카이제곱 통계량, 런 검정은 z, 불변식은 위반 건수
+	DF        int     `json:"df,omitempty"` // 카이제곱 자유도
+	PValue    float64 `json:"pValue"`
+	Passed    bool    `json:"passed"`
+	Detail    string  `json:"detail"`
+}
+
+type Report struct {
+	Draws  int     `json:"draws"`
+	Alpha  float64 `json:"alpha"`
+	Checks []Check `json:"checks"`
+}
+
+func (r Report) Passed() bool {
+	return len(r.Failed()) == 0
+}
+
+func (r Report) Failed() []Check {
+	var failed []Check
+	for _, c := range r.Checks {
+		if !c.Passed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// 생성기로 Draws 세트를 뽑아 검정한다
+//   - invariants: 6개, 1~45 범위, 중복 없는 오름차순
+//   - numbers: 번호별 출현 수 카이제곱 (균등)
+//   - pairs: 990개 번호 쌍의 동시 출현 수 카이제곱 (균등)
+//   - position-1..6: 정렬된 k번째 번호의 분포 카이제곱 (순서 통계량 이론 분포)
+//   - runs-sum, runs-odd: 회차 순서대로 본 합(평균 초과/미만)과 홀수 개수(3개 이상/미만)의 런 검정
+//
+// 불변식을 어긴 세트는 나머지 검정에서 제외한다
+func Run(gen Generator, opts Options) (Report, error) {
+	if opts.Draws == 0 {
+		opts.Draws = DefaultDraws
+	}
+	if opts.Alpha == 0 {
+		opts.Alpha = DefaultAlpha
+	}
+	if opts.Draws < MinDraws {
+		return Report{}, fmt.Errorf("%w: 세트 수는 %d 이상이어야 합니다 (입력: %d)", ErrInvalidOptions, MinDraws, opts.Draws)
+	}
+	if opts.Alpha <= 0 || opts.Alpha >= 1 {
+		return Report{}, fmt.Errorf("%w: 유의수준은 0과 1 사이여야 합니다 (입력: %g)", ErrInvalidOptions, opts.Alpha)
+	}
+
+	var (
+		invalid      int
+		firstInvalid []int
+		numbers      = make([]int, lotto.LottoMaxNum+1)
+		pairs        = make([]int, pairCount)
+		positions    [lotto.LottoSize][]int
+		sumRuns      runs
+		oddRuns      runs
+	)
+	for i := range positions {
+		positions[i] = make([]int, lotto.LottoMaxNum+1)
+	}
+
+	for range opts.Draws {
+		set := gen()
+		if !valid(set) {
+			if invalid == 0 {
+				firstInvalid = slices.Clone(set)
+			}
+			invalid++
+			continue
+		}
+
+		sum, odd := 0, 0
+		for i, n := range set {
+			numbers[n]++
+			positions[i][n]++
+			sum += n
+			odd += n % 2
+			for _, m := range set[i+1:] {
+				pairs[pairIndex(n, m)]++
+			}
+		}
+		if sum != sumMean {
+			sumRuns.add(sum > sumMean)
+		}
+		oddRuns.add(odd >= lotto.LottoSize/2)
+	}
+
+	accepted := opts.Draws - invalid
+	r := Report{Draws: opts.Draws, Alpha: opts.Alpha}
+	r.Checks = append(r.Checks, invariantCheck(invalid, firstInvalid))
+	if accepted < MinDraws {
+		return r, nil
+	}
+
+	r.Checks = append(r.Checks, numberCheck(numbers[lotto.LottoMinNum:], accepted))
+	r.Checks = append(r.Checks, pairCheck(pairs, accepted))
+	for i := range positions {
+		r.Checks = append(r.Checks, positionCheck(i+1, positions[i], accepted))
+	}
+	r.Checks = append(r.Checks,
+		sumRuns.check("runs-sum", fmt.Sprintf("합 %d 초과/미만", sumMean)),
+		oddRuns.check("runs-odd", fmt.Sprintf("홀수 %d개 이상/미만", lotto.LottoSize/2)),
+	)
+
+	for i := range r.Checks {
+		if r.Checks[i].Name != "invariants" {
+			r.Checks[i].Passed = r.Checks[i].PValue >= opts.Alpha
+		}
+	}
+	return r, nil
+}
+
+func valid(set []int) bool {
+	if len(set) != lotto.LottoSize {
+		return false
+	}
+	for i, n := range set {
+		if n < lotto.LottoMinNum || n > lotto.LottoMaxNum {
+			return false
+		}
+		if i > 0 && set[i-1] >= n {
+			return false
+		}
+	}
+	return true
+}
+
+func invariantCheck(invalid int, first []int) Check {
+	c := Check{Name: "invariants", Statistic: float64(invalid), PValue: 1, Passed: true, Detail: "모든 세트가 6개, 1~45, 중복 없는 오름차순"}
+	if invalid > 0 {
+		c.PValue, c.Passed = 0, false
+		c.Detail = fmt.Sprintf("위반 %d건 (첫 위반: %v)", invalid, first)
+	}
+	return c
+}
+
+func numberCheck(counts []int, draws int) Check {
+	expected := float64(draws*lotto.LottoSize) / lotto.LottoMaxNum
+	cs := drawstats.ChiSquareTest(counts, expected)
+
+	most, least := 0, 0
+	for i, c := range counts {
+		if c > counts[most] {
+			most = i
+		}
+		if c < counts[least] {
+			least = i
+		}
+	}
+	return Check{
+		Name:      "numbers",
+		Statistic: cs.Statistic,
+		DF:        cs.DF,
+		PValue:    cs.PValue,
+		Detail: fmt.Sprintf("기대 %.1f회, 최다 %d번 %d회, 최소 %d번 %d회",
+			expected, most+lotto.LottoMinNum, counts[most], least+lotto.LottoMinNum, counts[least]),
+	}
+}
+
+func pairCheck(counts []int, draws int) Check {
+	perSet := lotto.LottoSize * (lotto.LottoSize - 1) / 2
+	expected := float64(draws*perSet) / pairCount
+	cs := drawstats.ChiSquareTest(counts, expected)
+
+	most := 0
+	for i, c := range counts {
+		if c > counts[most] {
+			most = i
+		}
+	}
+	a, b := pairNumbers(most)
+	return Check{
+		Name:      "pairs",
+		Statistic: cs.Statistic,
+		DF:        cs.DF,
+		PValue:    cs.PValue,
+		Detail:    fmt.Sprintf("기대 %.1f회, 최다 (%d, %d) %d회", expected, a, b, counts[most]),
+	}
+}
+
+// 정렬된 position번째 번호가 k일 확률 = C(k-1, position-1) × C(45-k, 6-position) / C(45, 6)
+// 기대 빈도 5 미만인 칸은 이웃 칸과 합친다
+func positionCheck(position int, counts []int, draws int) Check {
+	total := binomial(lotto.LottoMaxNum, lotto.LottoSize)
+
+	var observed []int
+	var expected []float64
+	obs, exp := 0, 0.0
+	for k := lotto.LottoMinNum; k <= lotto.LottoMaxNum; k++ {
+		p := binomial(k-1, position-1) * binomial(lotto.LottoMaxNum-k, lotto.LottoSize-position) / total
+		obs += counts[k]
+		exp += p * float64(draws)
+		if exp >= 5 {
+			observed = append(observed, obs)
+			expected = append(expected, exp)
+			obs, exp = 0, 0
+		}
+	}
+	if exp > 0 || obs > 0 {
+		last := len(expected) - 1
+		observed[last] += obs
+		expected[last] += exp
+	}
+
+	stat := 0.0
+	for i, o := range observed {
+		d := float64(o) - expected[i]
+		stat += d * d / expected[i]
+	}
+	df := len(observed) - 1
+	return Check{
+		Name:      fmt.Sprintf("position-%d", position),
+		Statistic: stat,
+		DF:        df,
+		PValue:    drawstats.PValue(stat, df),
+		Detail:    fmt.Sprintf("정렬 %d번째 번호 분포 (%d개 구간)", position, len(observed)),
+	}
+}
+
+// Wald-Wolfowitz 런 검정. 두 범주가 회차 순서와 무관하게 섞여 있는지 본다
+type runs struct {
+	above, below int
+	count        int
+	last         bool
+}
+
+func (r *runs) add(above bool) {
+	if r.above+r.below == 0 || above != r.last {
+		r.count++
+	}
+	r.last = above
+	if above {
+		r.above++
+	} else {
+		r.below++
+	}
+}
+
+func (r runs) check(name, label string) Check {
+	n1, n2 := float64(r.above), float64(r.below)
+	n := n1 + n2
+	c := Check{Name: name, Detail: fmt.Sprintf("%s: 런 %d개", label, r.count)}
+	if n1 == 0 || n2 == 0 {
+		c.Detail += " (한쪽 범주만 나옴)"
+		return c
+	}
+
+	mean := 2*n1*n2/n + 1
+	variance := 2 * n1 * n2 * (2*n1*n2 - n) / (n * n * (n - 1))
+	z := (float64(r.count) - mean) / math.Sqrt(variance)
+
+	c.Statistic = z
+	c.PValue = math.Erfc(math.Abs(z) / math.Sqrt2)
+	c.Detail += fmt.Sprintf(", 기대 %.1f개", mean)
+	return c
+}
+
+// a < b인 번호 쌍의 0부터 시작하는 순번
+func pairIndex(a, b int) int {
+	i, j := a-lotto.LottoMinNum, b-lotto.LottoMinNum
+	return i*(2*lotto.LottoMaxNum-i-1)/2 + (j - i - 1)
+}
+
+func pairNumbers(index int) (int, int) {
+	for a := lotto.LottoMinNum; a < lotto.LottoMaxNum; a++ {
+		row := lotto.LottoMaxNum - a
+		if index < row {
+			return a, a + 1 + index
+		}
+		index -= row
+	}
+	return 0, 0
+}
+
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
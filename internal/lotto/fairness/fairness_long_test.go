@@ -0,0 +1,25 @@
+//go:build fairness
+
+package fairness
+
+import (
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+// 자동 구매가 쓰는 전역 난수원을 500만 세트로 검정한다
+// go test -tags fairness ./internal/lotto/fairness
+func TestDefaultSourceFairness(t *testing.T) {
+	r, err := Run(Auto(lotto.DefaultSource), Options{Draws: 5 * DefaultDraws, Alpha: DefaultAlpha})
+	if err != nil {
+		t.Fatalf("검정 중 에러가 발생했습니다: %v", err)
+	}
+
+	for _, c := range r.Checks {
+		t.Logf("%-12s p=%.4f %s", c.Name, c.PValue, c.Detail)
+		if !c.Passed {
+			t.Errorf("%s 검정에 실패했습니다. p=%.6f (유의수준 %g), %s", c.Name, c.PValue, r.Alpha, c.Detail)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package fairness
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+func TestRun_FairGenerator(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	r, err := Run(Auto(rng), Options{Draws: 20_000})
+	if err != nil {
+		t.Fatalf("검정 중 에러가 발생했습니다: %v", err)
+	}
+	if !r.Passed() {
+		t.Errorf("공정한 생성기가 검정을 통과하지 못했습니다. got=%+v", r.Failed())
+	}
+	if len(r.Checks) != 11 {
+		t.Errorf("검정 수가 예상과 다릅니다. got=%d, want=%d", len(r.Checks), 11)
+	}
+}
+
+func TestRun_DetectsBias(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  func(rng *rand.Rand) Generator
+		fail string
+	}{
+		{
+			name: "정렬되지 않은 세트",
+			gen: func(rng *rand.Rand) Generator {
+				return func() []int { return []int{6, 5, 4, 3, 2, 1} }
+			},
+			fail: "invariants",
+		},
+		{
+			name: "45번이 나오지 않음",
+			gen: func(rng *rand.Rand) Generator {
+				return func() []int {
+					for {
+						set := lotto.RandomNumbers(rng)
+						if set[lotto.LottoSize-1] != lotto.LottoMaxNum {
+							return set
+						}
+					}
+				}
+			},
+			fail: "numbers",
+		},
+		{
+			name: "1, 2번이 자주 같이 나옴",
+			gen: func(rng *rand.Rand) Generator {
+				return func() []int {
+					set := lotto.RandomNumbers(rng)
+					if rng.Intn(20) == 0 && set[0] != 1 && set[0] != 2 && set[1] != 2 {
+						set[0], set[1] = 1, 2
+					}
+					return set
+				}
+			},
+			fail: "pairs",
+		},
+		{
+			name: "합이 번갈아 크고 작음",
+			gen: func(rng *rand.Rand) Generator {
+				high := false
+				return func() []int {
+					high = !high
+					for {
+						set := lotto.RandomNumbers(rng)
+						sum := 0
+						for _, n := range set {
+							sum += n
+						}
+						if (sum > sumMean) == high {
+							return set
+						}
+					}
+				}
+			},
+			fail: "runs-sum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Run(tt.gen(rand.New(rand.NewSource(2))), Options{Draws: 20_000})
+			if err != nil {
+				t.Fatalf("검정 중 에러가 발생했습니다: %v", err)
+			}
+			if r.Passed() {
+				t.Fatalf("쏠린 생성기가 검정을 통과했습니다")
+			}
+			for _, c := range r.Failed() {
+				if c.Name == tt.fail {
+					return
+				}
+			}
+			t.Errorf("%s 검정이 실패해야 합니다. got=%+v", tt.fail, r.Failed())
+		})
+	}
+}
+
+func TestRun_InvalidOptions(t *testing.T) {
+	gen := Auto(rand.New(rand.NewSource(1)))
+
+	tests := []Options{
+		{Draws: MinDraws - 1},
+		{Draws: MinDraws, Alpha: 1},
+		{Draws: MinDraws, Alpha: -0.1},
+	}
+	for _, opts := range tests {
+		if _, err := Run(gen, opts); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("잘못된 조건은 ErrInvalidOptions여야 합니다. opts=%+v, got=%v", opts, err)
+		}
+	}
+}
+
+func TestPairIndex(t *testing.T) {
+	seen := make(map[int]bool, pairCount)
+	for a := lotto.LottoMinNum; a < lotto.LottoMaxNum; a++ {
+		for b := a + 1; b <= lotto.LottoMaxNum; b++ {
+			i := pairIndex(a, b)
+			if i < 0 || i >= pairCount || seen[i] {
+				t.Fatalf("쌍 순번이 겹치거나 범위를 벗어났습니다. (%d, %d) -> %d", a, b, i)
+			}
+			seen[i] = true
+			if gotA, gotB := pairNumbers(i); gotA != a || gotB != b {
+				t.Errorf("순번에서 쌍을 되살리지 못했습니다. got=(%d, %d), want=(%d, %d)", gotA, gotB, a, b)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package history
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSV 형식 (첫 줄은 헤더, 열 순서는 자유)
+//
+//	round,date,n1,n2,n3,n4,n5,n6,bonus[,sales][,winners1..winners5][,prize1..prize5]
+//
+// sales, winnersN, prizeN 열은 선택이며 비어 있으면 0으로 본다
+func ReadCSV(r io.Reader, gameID string) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("빈 CSV 파일입니다")
+		}
+		return nil, err
+	}
+	cols, err := csvColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	line := 1
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("%d행: %w", line, err)
+		}
+
+		raw, err := cols.parse(row)
+		if err != nil {
+			return nil, fmt.Errorf("%d행: %w", line, err)
+		}
+		rec, err := raw.validate(gameID)
+		if err != nil {
+			return nil, fmt.Errorf("%d행: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+
+	return finalize(records)
+}
+
+type csvColumnIndex map[string]int
+
+var requiredCSVColumns = []string{"round", "date", "n1", "n2", "n3", "n4", "n5", "n6", "bonus"}
+
+func csvColumns(header []string) (csvColumnIndex, error) {
+	cols := make(csvColumnIndex, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	for _, name := range requiredCSVColumns {
+		if _, ok := cols[name]; !ok {
+			return nil, fmt.Errorf("CSV 헤더에 %q 열이 없습니다", name)
+		}
+	}
+	return cols, nil
+}
+
+func (c csvColumnIndex) parse(row []string) (rawRecord, error) {
+	raw := rawRecord{
+		Date:    c.text(row, "date"),
+		Winners: make(map[int]int),
+		Prizes:  make(map[int]int),
+	}
+
+	var err error
+	if raw.Round, err = c.int(row, "round"); err != nil {
+		return rawRecord{}, err
+	}
+	for i := 1; i <= 6; i++ {
+		n, err := c.int(row, "n"+strconv.Itoa(i))
+		if err != nil {
+			return rawRecord{}, err
+		}
+		raw.Numbers = append(raw.Numbers, n)
+	}
+	if raw.Bonus, err = c.int(row, "bonus"); err != nil {
+		return rawRecord{}, err
+	}
+	if raw.Sales, err = c.int(row, "sales"); err != nil {
+		return rawRecord{}, err
+	}
+
+	for n := 1; n <= 5; n++ {
+		if raw.Winners[n], err = c.int(row, "winners"+strconv.Itoa(n)); err != nil {
+			return rawRecord{}, err
+		}
+		if raw.Prizes[n], err = c.int(row, "prize"+strconv.Itoa(n)); err != nil {
+			return rawRecord{}, err
+		}
+	}
+	dropZeros(raw.Winners)
+	dropZeros(raw.Prizes)
+
+	return raw, nil
+}
+
+func (c csvColumnIndex) text(row []string, name string) string {
+	idx, ok := c[name]
+	if !ok || idx >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[idx])
+}
+
+// 없는 열이나 빈 칸은 0
+func (c csvColumnIndex) int(row []string, name string) (int, error) {
+	s := strings.ReplaceAll(c.text(row, name), ",", "")
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s 열에 숫자가 아닌 값이 있습니다: %q", name, s)
+	}
+	return n, nil
+}
+
+// 열만 있고 모든 값이 0이면 공식 기록이 없는 것으로 본다
+// (합이 아니라 값 하나하나를 봐야 -1, 1처럼 상쇄되는 값이 음수 검사를 건너뛰지 않는다)
+func dropZeros(m map[int]int) {
+	for _, v := range m {
+		if v != 0 {
+			return
+		}
+	}
+	clear(m)
+}
@@ -0,0 +1,79 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// JSON 형식: 회차 객체 배열
+//
+//	[{"round": 1123, "date": "2024-06-01", "numbers": [1,2,3,4,5,6], "bonus": 7,
+//	  "sales": 100000000, "winners": {"1": 12}, "prizes": {"1": 2100000000}}]
+//
+// winners/prizes의 키는 등수 번호("1"~"5")
+type jsonRecord struct {
+	Round   int            `json:"round"`
+	Date    string         `json:"date"`
+	Numbers []int          `json:"numbers"`
+	Bonus   int            `json:"bonus"`
+	Sales   int            `json:"sales"`
+	Winners map[string]int `json:"winners"`
+	Prizes  map[string]int `json:"prizes"`
+}
+
+func ReadJSON(r io.Reader, gameID string) ([]Record, error) {
+	var rows []jsonRecord
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("유효하지 않은 JSON입니다: %w", err)
+	}
+
+	records := make([]Record, 0, len(rows))
+	for i, row := range rows {
+		raw, err := row.raw()
+		if err != nil {
+			return nil, fmt.Errorf("%d번째 항목: %w", i+1, err)
+		}
+		rec, err := raw.validate(gameID)
+		if err != nil {
+			return nil, fmt.Errorf("%d번째 항목(%d회차): %w", i+1, row.Round, err)
+		}
+		records = append(records, rec)
+	}
+
+	return finalize(records)
+}
+
+func (row jsonRecord) raw() (rawRecord, error) {
+	winners, err := numberKeys(row.Winners)
+	if err != nil {
+		return rawRecord{}, err
+	}
+	prizes, err := numberKeys(row.Prizes)
+	if err != nil {
+		return rawRecord{}, err
+	}
+
+	return rawRecord{
+		Round:   row.Round,
+		Date:    row.Date,
+		Numbers: row.Numbers,
+		Bonus:   row.Bonus,
+		Sales:   row.Sales,
+		Winners: winners,
+		Prizes:  prizes,
+	}, nil
+}
+
+func numberKeys(src map[string]int) (map[int]int, error) {
+	dst := make(map[int]int, len(src))
+	for k, v := range src {
+		n, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, fmt.Errorf("등수 키는 1~5 숫자여야 합니다: %q", k)
+		}
+		dst[n] = v
+	}
+	return dst, nil
+}
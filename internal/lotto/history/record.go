@@ -0,0 +1,177 @@
+package history
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+// 과거 회차 한 줄: 추첨 결과 + (있으면) 공식 판매액/당첨자 수/1인당 당첨금
+type Record struct {
+	lotto.Draw
+
+	Sales       int                `json:"sales,omitempty"`
+	Winners     map[lotto.Rank]int `json:"winners,omitempty"`     // 공식 등수별 당첨자 수
+	PrizePerWin map[lotto.Rank]int `json:"prizePerWin,omitempty"` // 공식 등수별 1인당 당첨금
+}
+
+// 공식 당첨자 수가 들어 있는지
+func (r Record) HasOfficialResult() bool {
+	return len(r.Winners) > 0
+}
+
+// 기록에서 추첨 결과만 추출
+func Draws(records []Record) []lotto.Draw {
+	draws := make([]lotto.Draw, 0, len(records))
+	for _, r := range records {
+		draws = append(draws, r.Draw)
+	}
+	return draws
+}
+
+// 확장자(.csv / .json)에 따라 파일을 읽는다
+func Load(path string, gameID string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ReadCSV(f, gameID)
+	case ".json":
+		return ReadJSON(f, gameID)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 파일 형식입니다: %s (csv, json만 가능)", path)
+	}
+}
+
+// 가져오기 공통 원시 값 (CSV/JSON 모두 이 형태로 모은 뒤 검증)
+type rawRecord struct {
+	Round   int
+	Date    string
+	Numbers []int
+	Bonus   int
+	Sales   int
+	Winners map[int]int // 등수 번호(1~5) → 당첨자 수
+	Prizes  map[int]int // 등수 번호(1~5) → 1인당 당첨금
+}
+
+// 기존 번호 검증 규칙(lotto.Lottos.SetWinningNumbers/SetBonusNumber)으로 검증
+func (raw rawRecord) validate(gameID string) (Record, error) {
+	if raw.Round <= 0 {
+		return Record{}, fmt.Errorf("회차 번호는 1 이상이어야 합니다: %d", raw.Round)
+	}
+
+	drawAt, err := lotto.ParseDrawDate(raw.Date)
+	if err != nil {
+		return Record{}, err
+	}
+
+	var winning lotto.Lottos
+	if err := winning.SetWinningNumbers(joinInts(raw.Numbers)); err != nil {
+		return Record{}, err
+	}
+	if err := winning.SetBonusNumber(strconv.Itoa(raw.Bonus)); err != nil {
+		return Record{}, err
+	}
+
+	if raw.Sales < 0 {
+		return Record{}, lotto.ErrNegativeSales
+	}
+	winners, err := rankMap(raw.Winners, "당첨자 수")
+	if err != nil {
+		return Record{}, err
+	}
+	prizes, err := rankMap(raw.Prizes, "당첨금")
+	if err != nil {
+		return Record{}, err
+	}
+
+	return Record{
+		Draw: lotto.Draw{
+			Meta: lotto.RoundMeta{
+				GameID:   gameID,
+				Sequence: raw.Round,
+				DrawAt:   drawAt,
+			},
+			WinningNumbers: winning.WinningNumbers,
+			BonusNumber:    winning.BonusNumber,
+		},
+		Sales:       raw.Sales,
+		Winners:     winners,
+		PrizePerWin: prizes,
+	}, nil
+}
+
+func rankMap(src map[int]int, label string) (map[lotto.Rank]int, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	dst := make(map[lotto.Rank]int, len(src))
+	for n, v := range src {
+		rank, err := lotto.RankFromNumber(n)
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 {
+			return nil, fmt.Errorf("%d등 %s는 음수가 될 수 없습니다: %d", n, label, v)
+		}
+		dst[rank] = v
+	}
+	return dst, nil
+}
+
+// 회차 번호 중복 검사 후 회차 순 정렬
+func finalize(records []Record) ([]Record, error) {
+	seen := make(map[int]bool, len(records))
+	for _, r := range records {
+		if seen[r.Meta.Sequence] {
+			return nil, fmt.Errorf("중복된 회차가 있습니다: %d", r.Meta.Sequence)
+		}
+		seen[r.Meta.Sequence] = true
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Meta.Sequence < records[j].Meta.Sequence
+	})
+	return records, nil
+}
+
+func joinInts(nums []int) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ",")
+}
+
+// 내용을 보고 CSV/JSON을 구분해 읽는다 (첫 글자가 '['이면 JSON)
+func Read(r io.Reader, gameID string) ([]Record, error) {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return nil, errors.New("빈 과거 기록입니다")
+		}
+		if unicode.IsSpace(rune(b[0])) {
+			_, _ = br.ReadByte()
+			continue
+		}
+		if b[0] == '[' {
+			return ReadJSON(br, gameID)
+		}
+		return ReadCSV(br, gameID)
+	}
+}
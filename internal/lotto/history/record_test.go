@@ -0,0 +1,100 @@
+package history
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+func TestReadCSV(t *testing.T) {
+	input := `round,date,n1,n2,n3,n4,n5,n6,bonus,sales,winners1,prize1
+1124,2024-06-08,45,3,12,22,31,8,9,"115,000,000,000",0,0
+1123,2024-06-01,1,2,3,4,5,6,7,110000000000,12,2100000000
+`
+	records, err := ReadCSV(strings.NewReader(input), lotto.DefaultGameID)
+	if err != nil {
+		t.Fatalf("CSV 가져오기 중 에러가 발생했습니다: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("회차 수가 예상과 다릅니다. got=%d, want=%d", len(records), 2)
+	}
+
+	first := records[0]
+	if first.Meta.Sequence != 1123 {
+		t.Errorf("회차 순으로 정렬되어야 합니다. got=%d", first.Meta.Sequence)
+	}
+	if first.Winners[lotto.Rank1] != 12 || first.PrizePerWin[lotto.Rank1] != 2_100_000_000 {
+		t.Errorf("공식 결과가 예상과 다릅니다. winners=%v, prizes=%v", first.Winners, first.PrizePerWin)
+	}
+
+	second := records[1]
+	if got := joinInts(second.WinningNumbers); got != "3,8,12,22,31,45" {
+		t.Errorf("당첨 번호는 오름차순 정렬되어야 합니다. got=%s", got)
+	}
+	if second.Sales != 115_000_000_000 {
+		t.Errorf("천 단위 구분 판매액 파싱 실패. got=%d", second.Sales)
+	}
+	if second.HasOfficialResult() {
+		t.Errorf("당첨자 수가 모두 0이면 공식 결과가 없는 것으로 봐야 합니다")
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	input := `[{"round": 1123, "date": "2024-06-01", "numbers": [6,5,4,3,2,1], "bonus": 7,
+		"winners": {"1": 12, "5": 1000}, "prizes": {"1": 2100000000, "5": 5000}}]`
+
+	records, err := ReadJSON(strings.NewReader(input), lotto.DefaultGameID)
+	if err != nil {
+		t.Fatalf("JSON 가져오기 중 에러가 발생했습니다: %v", err)
+	}
+	if records[0].Winners[lotto.Rank5] != 1000 {
+		t.Errorf("5등 당첨자 수가 예상과 다릅니다. got=%d", records[0].Winners[lotto.Rank5])
+	}
+	if records[0].Meta.ID() != "kr-645-1123" {
+		t.Errorf("회차 식별자가 예상과 다릅니다. got=%s", records[0].Meta.ID())
+	}
+}
+
+// 기존 번호 규칙 위반은 행 번호와 함께 에러
+func TestReadCSV_InvalidRows(t *testing.T) {
+	header := "round,date,n1,n2,n3,n4,n5,n6,bonus\n"
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"필수 열 누락", "round,date,n1,n2,n3,n4,n5,bonus\n1,2024-06-01,1,2,3,4,5,7\n"},
+		{"번호 범위 위반", header + "1,2024-06-01,0,2,3,4,5,6,7\n"},
+		{"번호 중복", header + "1,2024-06-01,1,1,3,4,5,6,7\n"},
+		{"보너스 중복", header + "1,2024-06-01,1,2,3,4,5,6,6\n"},
+		{"날짜 형식", header + "1,2024/06/01,1,2,3,4,5,6,7\n"},
+		{"회차 중복", header + "1,2024-06-01,1,2,3,4,5,6,7\n1,2024-06-08,1,2,3,4,5,6,7\n"},
+		{"숫자 아님", header + "1,2024-06-01,a,2,3,4,5,6,7\n"},
+		{"합이 0인 음수 당첨자 수", "round,date,n1,n2,n3,n4,n5,n6,bonus,winners1,winners2\n1,2024-06-01,1,2,3,4,5,6,7,-1,1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadCSV(strings.NewReader(tt.input), ""); err == nil {
+				t.Errorf("잘못된 입력에서 에러가 발생하지 않았습니다")
+			}
+		})
+	}
+}
+
+// 첫 글자로 CSV/JSON 자동 구분
+func TestRead_DetectsFormat(t *testing.T) {
+	csvInput := "round,date,n1,n2,n3,n4,n5,n6,bonus\n1,2024-06-01,1,2,3,4,5,6,7\n"
+	jsonInput := `  [{"round": 1, "date": "2024-06-01", "numbers": [1,2,3,4,5,6], "bonus": 7}]`
+
+	for name, input := range map[string]string{"csv": csvInput, "json": jsonInput} {
+		records, err := Read(strings.NewReader(input), "")
+		if err != nil {
+			t.Fatalf("%s 가져오기 중 에러가 발생했습니다: %v", name, err)
+		}
+		if len(records) != 1 {
+			t.Errorf("%s 회차 수가 예상과 다릅니다. got=%d", name, len(records))
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package lotto
+
+import (
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/metrics"
+)
+
+// 서버 /metrics로 내보내는 시뮬레이션 지표
+var (
+	ticketsEvaluated = metrics.NewCounter(
+		"lotto_tickets_evaluated_total",
+		"정산에서 당첨 번호와 대조한 티켓 수 (회차마다 수령액을 계산할 때 한 장을 한 번 센다)",
+	)
+	roundsSimulated = metrics.NewCounterVec(
+		"lotto_rounds_simulated_total",
+		"계산을 마친 회차 수 (단일 회차, 시리즈, 몬테카를로, 세션 추첨 포함)",
+		"mode",
+	)
+)
+
+// 병렬 함수(CompileStatisticsParallel, DistributeRewardsParallel)의 worker 활용률
+// 활용률 = worker_seconds / capacity_seconds (worker가 실제로 일한 시간 / worker 수 × 경과 시간)
+var (
+	parallelCalls = metrics.NewCounterVec(
+		"lotto_parallel_calls_total",
+		"병렬 함수 호출 수. 티켓이 적어 순차 처리했으면 path=sequential",
+		"func", "path",
+	)
+	parallelWorkersBusy = metrics.NewGaugeVec(
+		"lotto_parallel_workers_busy",
+		"지금 일하고 있는 worker goroutine 수",
+		"func",
+	)
+	parallelWorkerSeconds = metrics.NewCounterVec(
+		"lotto_parallel_worker_seconds_total",
+		"worker goroutine들이 일한 시간의 합 (초)",
+		"func",
+	)
+	parallelCapacitySeconds = metrics.NewCounterVec(
+		"lotto_parallel_capacity_seconds_total",
+		"병렬 실행마다 worker 수 × 경과 시간의 합 (초)",
+		"func",
+	)
+)
+
+type parallelMetrics struct {
+	sequential      *metrics.Counter
+	parallel        *metrics.Counter
+	busy            *metrics.Gauge
+	workerSeconds   *metrics.Counter
+	capacitySeconds *metrics.Counter
+}
+
+func newParallelMetrics(fn string) parallelMetrics {
+	return parallelMetrics{
+		sequential:      parallelCalls.With(fn, "sequential"),
+		parallel:        parallelCalls.With(fn, "parallel"),
+		busy:            parallelWorkersBusy.With(fn),
+		workerSeconds:   parallelWorkerSeconds.With(fn),
+		capacitySeconds: parallelCapacitySeconds.With(fn),
+	}
+}
+
+var (
+	compileStatisticsMetrics = newParallelMetrics("CompileStatisticsParallel")
+	distributeRewardsMetrics = newParallelMetrics("DistributeRewardsParallel")
+)
+
+// 병렬 실행 시작. 끝나면 finish(시작 시각, worker 수)
+func (m parallelMetrics) begin() time.Time {
+	m.parallel.Inc()
+	return time.Now()
+}
+
+func (m parallelMetrics) finish(start time.Time, workers int) {
+	m.capacitySeconds.Add(time.Since(start).Seconds() * float64(workers))
+}
+
+// worker 하나의 시작과 끝: defer m.workerDone(m.workerStart())
+func (m parallelMetrics) workerStart() time.Time {
+	m.busy.Inc()
+	return time.Now()
+}
+
+func (m parallelMetrics) workerDone(start time.Time) {
+	m.workerSeconds.Add(time.Since(start).Seconds())
+	m.busy.Dec()
+}
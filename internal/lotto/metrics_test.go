@@ -0,0 +1,91 @@
+package lotto
+
+import "testing"
+
+// 한 회차를 정산하면 대조한 티켓 수가 티켓 장수만큼만 늘어나는지 테스트
+// (당첨자 집계와 수령액 계산을 모두 거쳐도 한 장은 한 번)
+func TestTicketsEvaluated(t *testing.T) {
+	tests := []struct {
+		name    string
+		tickets int
+	}{
+		{name: "순차 처리", tickets: 50},
+		{name: "병렬 처리", tickets: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := make([]Player, 4)
+			for i := range tt.tickets {
+				p := &players[i%len(players)]
+				p.Name = string(rune('a' + i%len(players)))
+				p.Tickets = append(p.Tickets, Lotto{Numbers: []int{1, 2, 3, 10, 20, 30}})
+			}
+			draws := []Draw{
+				{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7},
+				{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7},
+			}
+
+			before := ticketsEvaluated.Value()
+			if _, err := SimulateDrawSeries(SeriesConfig{Mode: ModeFixedPayout}, players, draws, nil, nil); err != nil {
+				t.Fatalf("시리즈 시뮬레이션 중 에러가 발생했습니다. err=%v", err)
+			}
+			if got, want := ticketsEvaluated.Value()-before, float64(tt.tickets*len(draws)); got != want {
+				t.Errorf("대조한 티켓 수 증가 = %v, want %v", got, want)
+			}
+
+			winning := draws[0].Lottos(players[0].Tickets)
+			before = ticketsEvaluated.Value()
+			winning.CompileStatisticsParallel()
+			if got := ticketsEvaluated.Value() - before; got != 0 {
+				t.Errorf("당첨자 집계만으로는 세지 않아야 합니다. got=%v", got)
+			}
+		})
+	}
+}
+
+// 병렬 집계가 worker 사용 지표를 남기는지 테스트
+// (지표는 패키지 전역이라 호출 전후 차이로 확인)
+func TestCompileStatisticsParallel_Metrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		tickets  int
+		parallel bool
+	}{
+		{name: "티켓이 적으면 순차 처리", tickets: 50, parallel: false},
+		{name: "티켓이 많으면 병렬 처리", tickets: 1000, parallel: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := Lottos{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7}
+			for range tt.tickets {
+				ls.Lottos = append(ls.Lottos, Lotto{Numbers: []int{1, 2, 3, 10, 20, 30}})
+			}
+
+			m := compileStatisticsMetrics
+			beforeSeq, beforePar := m.sequential.Value(), m.parallel.Value()
+			beforeWork, beforeCap := m.workerSeconds.Value(), m.capacitySeconds.Value()
+
+			ls.CompileStatisticsParallel()
+
+			wantSeq, wantPar := 1.0, 0.0
+			if tt.parallel {
+				wantSeq, wantPar = 0, 1
+			}
+			if got := m.sequential.Value() - beforeSeq; got != wantSeq {
+				t.Errorf("순차 처리 호출 증가 = %v, want %v", got, wantSeq)
+			}
+			if got := m.parallel.Value() - beforePar; got != wantPar {
+				t.Errorf("병렬 처리 호출 증가 = %v, want %v", got, wantPar)
+			}
+			work, capacity := m.workerSeconds.Value()-beforeWork, m.capacitySeconds.Value()-beforeCap
+			if tt.parallel && (work <= 0 || capacity <= 0) {
+				t.Errorf("worker 시간 = %v, 전체 용량 = %v: 0보다 커야 합니다", work, capacity)
+			}
+			if m.busy.Value() != 0 {
+				t.Errorf("끝난 뒤 실행 중인 worker = %v, want 0", m.busy.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package lotto
+
+import (
+	"math"
+	"math/rand"
+)
+
+// 6/45 전체 조합 수
+const TotalCombinations = 8_145_060
+
+// 등수별 당첨 조합 수 (TotalCombinations 중)
+var winningCombinations = map[Rank]int{
+	Rank1: 1,
+	Rank2: 6,
+	Rank3: 228,
+	Rank4: 11_115,
+	Rank5: 182_780,
+}
+
+// 티켓 1장의 등수별 당첨 확률
+func (r Rank) Odds() float64 {
+	return float64(winningCombinations[r]) / TotalCombinations
+}
+
+// 무작위 번호 티켓 tickets장을 샀을 때의 등수별 당첨자 수를 확률로 뽑는다.
+// 티켓을 실제로 발행하지 않으므로 판매량이 큰 시리즈/스윕에 쓴다
+func SampleWinners(rng *rand.Rand, tickets int) map[Rank]int {
+	winners := make(map[Rank]int, len(winningCombinations))
+
+	// 다항 분포를 등수별 조건부 이항 분포로 나눠 뽑기
+	remaining := tickets
+	remainingCombos := TotalCombinations
+	for _, r := range []Rank{Rank1, Rank2, Rank3, Rank4, Rank5} {
+		p := float64(winningCombinations[r]) / float64(remainingCombos)
+		n := sampleBinomial(rng, remaining, p)
+
+		winners[r] = n
+		remaining -= n
+		remainingCombos -= winningCombinations[r]
+	}
+	return winners
+}
+
+// 기대값이 작으면 포아송, 크면 정규 근사
+func sampleBinomial(rng *rand.Rand, n int, p float64) int {
+	if n <= 0 || p <= 0 {
+		return 0
+	}
+
+	mean := float64(n) * p
+	var k int
+	if mean < 30 {
+		k = samplePoisson(rng, mean)
+	} else {
+		std := math.Sqrt(mean * (1 - p))
+		k = int(math.Round(mean + std*rng.NormFloat64()))
+	}
+	return min(max(k, 0), n)
+}
+
+func samplePoisson(rng *rand.Rand, mean float64) int {
+	limit := math.Exp(-mean)
+	k := 0
+	for prod := rng.Float64(); prod > limit; prod *= rng.Float64() {
+		k++
+	}
+	return k
+}
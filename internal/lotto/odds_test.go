@@ -0,0 +1,34 @@
+package lotto
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSampleWinners(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const tickets = 100_000_000
+
+	winners := SampleWinners(rng, tickets)
+
+	for _, r := range []Rank{Rank3, Rank4, Rank5} {
+		want := float64(tickets) * r.Odds()
+		got := float64(winners[r])
+		if math.Abs(got-want)/want > 0.05 {
+			t.Errorf("%d등 당첨자 수가 기대값과 너무 다릅니다. got=%.0f, want≈%.0f", r.Number(), got, want)
+		}
+	}
+	if winners[Rank1] > 40 {
+		t.Errorf("1등 당첨자 수가 비정상적으로 많습니다. got=%d", winners[Rank1])
+	}
+}
+
+func TestSampleWinners_NoTickets(t *testing.T) {
+	winners := SampleWinners(rand.New(rand.NewSource(1)), 0)
+	for r, n := range winners {
+		if n != 0 {
+			t.Errorf("티켓이 없으면 당첨자도 없어야 합니다. rank=%d, got=%d", r.Number(), n)
+		}
+	}
+}
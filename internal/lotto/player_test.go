@@ -91,3 +91,39 @@ func TestDistributeRewards_FixedMode(t *testing.T) {
 		t.Fatalf("플레이어 b 수령 금액이 예상과 다릅니다. got=%d, want=%d", rewards["b"], wantB)
 	}
 }
+
+// 티켓이 많고 플레이어가 워커 수(4)보다 적어도 순차 계산과 같은지 테스트
+func TestDistributeRewardsParallel_FewPlayers(t *testing.T) {
+	winning := Lottos{
+		WinningNumbers: []int{1, 2, 3, 4, 5, 6},
+		BonusNumber:    7,
+	}
+	out := RoundOutput{
+		PaidPerWin: map[Rank]int{
+			Rank1: Rank1.Prize(),
+			Rank5: Rank5.Prize(),
+		},
+	}
+
+	for n := 1; n <= 5; n++ {
+		players := make([]Player, 0, n)
+		for i := range n {
+			tickets := make([]Lotto, 0, 150)
+			for range 150 {
+				tickets = append(tickets, Lotto{Numbers: []int{1, 2, 3, 10 + i, 20, 30}}) // 5등
+			}
+			players = append(players, Player{Name: string(rune('a' + i)), Tickets: tickets})
+		}
+
+		got := DistributeRewardsParallel(players, winning, out)
+		want := DistributeRewards(players, winning, out)
+		if len(got) != len(want) {
+			t.Fatalf("플레이어 %d명: 수령 금액 = %v, want %v", n, got, want)
+		}
+		for name, amount := range want {
+			if got[name] != amount {
+				t.Errorf("플레이어 %d명: %s 수령 금액 = %d, want %d", n, name, got[name], amount)
+			}
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package popularity
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+var ErrUnknownModel = errors.New("지원하지 않는 번호 선호 모델입니다")
+
+// 사람들이 번호를 고르는 방식
+// 티켓마다 PatternShare 확률로 흔한 패턴을, PreviousShare 확률로 직전 당첨 번호를 그대로 고르고
+// 나머지는 번호별 가중치로 6개를 뽑는다 (비복원)
+type Model struct {
+	Name          string    `json:"name"`
+	Weights       []float64 `json:"weights"`       // 1~45번 선호 가중치 (비우면 균등)
+	PatternShare  float64   `json:"patternShare"`  // 흔한 패턴 조합을 고르는 티켓 비율
+	Patterns      [][]int   `json:"patterns"`      // 패턴 조합 목록 (균등하게 고른다)
+	PreviousShare float64   `json:"previousShare"` // 직전 회차 당첨 번호를 다시 고르는 티켓 비율
+	Previous      []int     `json:"previous"`      // 직전 회차 당첨 번호
+}
+
+// 모든 조합이 같은 확률 (자동 번호)
+func Uniform() Model {
+	return Model{Name: "uniform"}
+}
+
+// 1~31(생일/기념일) 번호를 boost배 더 자주 고른다
+func Birthday(boost float64) Model {
+	weights := make([]float64, lotto.LottoMaxNum)
+	for i := range weights {
+		weights[i] = 1
+		if i+1 <= 31 {
+			weights[i] = boost
+		}
+	}
+	return Model{Name: "birthday", Weights: weights}
+}
+
+// 생일 번호 선호 + 흔한 패턴 + 직전 당첨 번호 재선택을 섞은 모델
+func Realistic() Model {
+	m := Birthday(1.5)
+	m.Name = "realistic"
+	m.PatternShare = 0.005
+	m.Patterns = CommonPatterns()
+	m.PreviousShare = 0.001
+	return m
+}
+
+// 용지에서 줄/대각선/등차로 고르는 흔한 조합
+func CommonPatterns() [][]int {
+	return [][]int{
+		{1, 2, 3, 4, 5, 6},
+		{40, 41, 42, 43, 44, 45},
+		{7, 14, 21, 28, 35, 42},
+		{5, 10, 15, 20, 25, 30},
+		{1, 8, 15, 22, 29, 36},
+		{1, 11, 21, 31, 41, 45},
+		{3, 9, 15, 21, 27, 33},
+		{2, 4, 6, 8, 10, 12},
+	}
+}
+
+var presets = map[string]func() Model{
+	"uniform":   Uniform,
+	"birthday":  func() Model { return Birthday(2) },
+	"realistic": Realistic,
+}
+
+// 이름으로 기본 모델 생성
+func Preset(name string) (Model, error) {
+	newModel, exists := presets[name]
+	if !exists {
+		return Model{}, fmt.Errorf("%w: %s (%v)", ErrUnknownModel, name, PresetNames())
+	}
+	return newModel(), nil
+}
+
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 직전 회차 당첨 번호를 바꾼 복사본
+func (m Model) WithPrevious(winning []int) Model {
+	m.Previous = winning
+	return m
+}
+
+func (m Model) Validate() error {
+	if m.Weights != nil {
+		if len(m.Weights) != lotto.LottoMaxNum {
+			return fmt.Errorf("가중치는 %d개여야 합니다: %d", lotto.LottoMaxNum, len(m.Weights))
+		}
+		positive := 0
+		for _, w := range m.Weights {
+			if w < 0 {
+				return errors.New("가중치는 음수일 수 없습니다")
+			}
+			if w > 0 {
+				positive++
+			}
+		}
+		if positive < lotto.LottoSize {
+			return fmt.Errorf("가중치가 0보다 큰 번호가 %d개 이상이어야 합니다", lotto.LottoSize)
+		}
+	}
+	if m.PatternShare < 0 || m.PreviousShare < 0 || m.PatternShare+m.PreviousShare > 1 {
+		return errors.New("패턴/직전 번호 비율은 0 이상이고 합이 1 이하여야 합니다")
+	}
+	for _, p := range m.Patterns {
+		if _, err := lotto.NewLotto(p); err != nil {
+			return fmt.Errorf("패턴 조합이 올바르지 않습니다: %w", err)
+		}
+	}
+	return nil
+}
+
+// 모델대로 티켓 번호 한 장 (오름차순)
+func (m Model) Ticket(rng *rand.Rand) []int {
+	// 패턴/직전 번호가 비어 있으면 그 몫은 가중치 추첨으로 대신한다
+	switch u := rng.Float64(); {
+	case u < m.PatternShare:
+		if len(m.Patterns) > 0 {
+			return slices.Clone(m.Patterns[rng.Intn(len(m.Patterns))])
+		}
+	case u < m.PatternShare+m.PreviousShare:
+		if len(m.Previous) == lotto.LottoSize {
+			return slices.Clone(m.Previous)
+		}
+	}
+	if m.Weights == nil {
+		return lotto.RandomNumbers(rng)
+	}
+	return m.weightedTicket(rng)
+}
+
+func (m Model) weightedTicket(rng *rand.Rand) []int {
+	weights := slices.Clone(m.Weights)
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+
+	numbers := make([]int, 0, lotto.LottoSize)
+	for len(numbers) < lotto.LottoSize {
+		target := rng.Float64() * total
+		idx := len(weights) - 1
+		for i, w := range weights {
+			if target < w {
+				idx = i
+				break
+			}
+			target -= w
+		}
+		if weights[idx] == 0 {
+			continue // 부동소수 오차로 끝까지 간 경우
+		}
+
+		numbers = append(numbers, idx+1)
+		total -= weights[idx]
+		weights[idx] = 0
+	}
+	sort.Ints(numbers)
+	return numbers
+}
+
+// 티켓 한 장이 이 조합(오름차순)일 확률
+func (m Model) Probability(combo []int) float64 {
+	weighted := 1 / float64(lotto.TotalCombinations)
+	if m.Weights != nil {
+		weighted = m.weightedProbability(combo)
+	}
+
+	p := (1 - m.PatternShare - m.PreviousShare) * weighted
+	if len(m.Patterns) > 0 {
+		for _, pattern := range m.Patterns {
+			if slices.Equal(pattern, combo) {
+				p += m.PatternShare / float64(len(m.Patterns))
+			}
+		}
+	} else {
+		p += m.PatternShare * weighted // 패턴이 없으면 가중치 추첨으로 대신한다
+	}
+	if len(m.Previous) == lotto.LottoSize {
+		if slices.Equal(m.Previous, combo) {
+			p += m.PreviousShare
+		}
+	} else {
+		p += m.PreviousShare * weighted
+	}
+	return p
+}
+
+// 가중치 비복원 추첨으로 정확히 이 6개가 나올 확률
+// 뽑은 부분집합마다 "그 순서와 상관없이 먼저 나올 확률"을 누적 (2^6 상태)
+func (m Model) weightedProbability(combo []int) float64 {
+	total := 0.0
+	for _, w := range m.Weights {
+		total += w
+	}
+
+	n := len(combo)
+	prob := make([]float64, 1<<n)
+	drawn := make([]float64, 1<<n) // 부분집합 가중치 합
+	prob[0] = 1
+
+	for set := 1; set < 1<<n; set++ {
+		for i := range n {
+			if set&(1<<i) == 0 {
+				continue
+			}
+			prev := set &^ (1 << i)
+			w := m.Weights[combo[i]-1]
+			drawn[set] = drawn[prev] + w
+			if remaining := total - drawn[prev]; remaining > 0 {
+				prob[set] += prob[prev] * w / remaining
+			}
+		}
+	}
+	return prob[1<<n-1]
+}
@@ -0,0 +1,129 @@
+package popularity
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+func TestProbability(t *testing.T) {
+	uniform := 1.0 / lotto.TotalCombinations
+
+	if got := Uniform().Probability([]int{1, 2, 3, 4, 5, 6}); math.Abs(got-uniform) > 1e-15 {
+		t.Errorf("균등 모델 확률이 예상과 다릅니다. got=%g, want=%g", got, uniform)
+	}
+	if got := Birthday(1).Probability([]int{8, 19, 27, 33, 40, 44}); math.Abs(got-uniform) > 1e-15 {
+		t.Errorf("가중치가 모두 같으면 균등과 같아야 합니다. got=%g, want=%g", got, uniform)
+	}
+
+	birthday := Birthday(2)
+	if low, high := birthday.Probability([]int{3, 9, 14, 22, 27, 30}), birthday.Probability([]int{32, 35, 38, 40, 43, 45}); low <= uniform || high >= uniform {
+		t.Errorf("생일 번호 조합은 균등보다 높고, 32 이상 조합은 낮아야 합니다. low=%g, high=%g", low, high)
+	}
+
+	// 7개 번호만 가중치가 있으면 그중 6개 조합 7가지의 확률 합은 1
+	weights := make([]float64, lotto.LottoMaxNum)
+	for i, w := range []float64{1, 2, 3, 4, 5, 6, 7} {
+		weights[i] = w
+	}
+	m := Model{Weights: weights}
+	sum := 0.0
+	for skip := 1; skip <= 7; skip++ {
+		combo := make([]int, 0, 6)
+		for n := 1; n <= 7; n++ {
+			if n != skip {
+				combo = append(combo, n)
+			}
+		}
+		sum += m.Probability(combo)
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		t.Errorf("조합 확률 합이 1이어야 합니다. got=%g", sum)
+	}
+
+	realistic := Realistic().WithPrevious([]int{4, 11, 17, 25, 38, 42})
+	if got := realistic.Probability([]int{1, 2, 3, 4, 5, 6}); got < realistic.PatternShare/float64(len(realistic.Patterns)) {
+		t.Errorf("패턴 조합은 패턴 비율만큼 확률이 있어야 합니다. got=%g", got)
+	}
+	if got := realistic.Probability([]int{4, 11, 17, 25, 38, 42}); got < realistic.PreviousShare {
+		t.Errorf("직전 당첨 번호는 재선택 비율만큼 확률이 있어야 합니다. got=%g", got)
+	}
+}
+
+func TestTicket(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	m := Birthday(3)
+
+	const n = 20_000
+	low := 0
+	for range n {
+		ticket, err := lotto.NewLotto(m.Ticket(rng))
+		if err != nil {
+			t.Fatalf("유효한 티켓이어야 합니다: %v", err)
+		}
+		for _, num := range ticket.Numbers {
+			if num <= 31 {
+				low++
+			}
+		}
+	}
+
+	// 31개 번호가 3배 가중치면 한 번 뽑을 때 1~31일 확률은 93/107 근처 (비복원이라 조금 낮아진다)
+	ratio := float64(low) / (n * lotto.LottoSize)
+	if ratio < 0.80 || ratio > 0.88 {
+		t.Errorf("1~31 번호 비율이 예상 범위를 벗어났습니다. got=%.3f", ratio)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	tickets := lotto.TotalCombinations
+	got := Analyze(Uniform(), tickets, 1_000_000, []int{1, 2, 3, 4, 5, 6})
+
+	if math.Abs(got.CoWinners-1) > 1e-9 {
+		t.Errorf("조합 수만큼 팔리면 같은 조합 기대 수는 1이어야 합니다. got=%g", got.CoWinners)
+	}
+	wantShare := 1 - math.Exp(-1)
+	if math.Abs(got.ExpectedShare-wantShare) > 1e-9 {
+		t.Errorf("기대 비율이 예상과 다릅니다. got=%g, want=%g", got.ExpectedShare, wantShare)
+	}
+	if got.ExpectedPrize != int(1_000_000*wantShare) {
+		t.Errorf("기대 당첨금이 예상과 다릅니다. got=%d", got.ExpectedPrize)
+	}
+
+	popular := Analyze(Realistic(), tickets, 1_000_000, []int{1, 2, 3, 4, 5, 6})
+	if popular.ExpectedPrize >= got.ExpectedPrize {
+		t.Errorf("흔한 패턴은 당첨금을 더 많이 나눠야 합니다. got=%d, uniform=%d", popular.ExpectedPrize, got.ExpectedPrize)
+	}
+}
+
+func TestSurveyDraws(t *testing.T) {
+	tickets := 100_000_000
+	lambda := float64(tickets) / lotto.TotalCombinations
+
+	uniform, err := SurveyDraws(Uniform(), rand.New(rand.NewSource(3)), tickets, 10_000_000_000, 500)
+	if err != nil {
+		t.Fatalf("분석 중 에러가 발생했습니다: %v", err)
+	}
+	if math.Abs(uniform.MeanWinners-lambda) > 1e-6 || math.Abs(uniform.RolloverRate-math.Exp(-lambda)) > 1e-9 {
+		t.Errorf("균등 모델 요약이 예상과 다릅니다. got=%+v, lambda=%g", uniform, lambda)
+	}
+
+	biased, err := SurveyDraws(Birthday(2), rand.New(rand.NewSource(3)), tickets, 10_000_000_000, 500)
+	if err != nil {
+		t.Fatalf("분석 중 에러가 발생했습니다: %v", err)
+	}
+	// 쏠림이 있으면 평균 당첨자 수는 같아도 1등이 안 나오는 회차가 늘어난다
+	if biased.RolloverRate <= uniform.RolloverRate {
+		t.Errorf("번호 쏠림이 있으면 이월 확률이 높아야 합니다. biased=%g, uniform=%g", biased.RolloverRate, uniform.RolloverRate)
+	}
+
+	if _, err := Preset("lucky"); !errors.Is(err, ErrUnknownModel) {
+		t.Errorf("없는 모델은 ErrUnknownModel이어야 합니다. got=%v", err)
+	}
+	if _, err := SurveyDraws(Model{Weights: []float64{1}}, rand.New(rand.NewSource(1)), 1, 1, 1); err == nil {
+		t.Error("가중치 개수가 틀리면 에러가 나야 합니다")
+	}
+}
@@ -0,0 +1,116 @@
+package popularity
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+// 1등 조합 하나에 대한 당첨금 나눔 분석
+// 다른 티켓 중 같은 조합 수 K는 Poisson(CoWinners)으로 본다
+type Split struct {
+	Combo         []int   `json:"combo"`
+	Probability   float64 `json:"probability"`   // 티켓 한 장이 이 조합일 확률
+	Popularity    float64 `json:"popularity"`    // 균등 확률 대비 배수
+	CoWinners     float64 `json:"coWinners"`     // 이 조합이 1등일 때 같은 조합을 산 다른 티켓 기대 수
+	ExpectedShare float64 `json:"expectedShare"` // 이 조합 한 장이 1등일 때 받는 풀 비율 기대값 E[1/(1+K)]
+	ExpectedPrize int     `json:"expectedPrize"` // pool × ExpectedShare
+}
+
+// tickets장이 팔린 회차에서 combo(오름차순)가 1등일 때 pool을 몇 명과 나누는지
+func Analyze(m Model, tickets, pool int, combo []int) Split {
+	p := m.Probability(combo)
+	lambda := float64(tickets) * p
+	share := expectedShare(lambda)
+
+	return Split{
+		Combo:         combo,
+		Probability:   p,
+		Popularity:    p * lotto.TotalCombinations,
+		CoWinners:     lambda,
+		ExpectedShare: share,
+		ExpectedPrize: int(float64(pool) * share),
+	}
+}
+
+// 공정한 추첨을 여러 번 가정했을 때의 1등 나눔 요약
+type Survey struct {
+	Model   string `json:"model"`
+	Tickets int    `json:"tickets"`
+	Pool    int    `json:"pool"`
+	Samples int    `json:"samples"`
+
+	MeanWinners    float64 `json:"meanWinners"`    // 회차당 1등 당첨자 기대 수
+	RolloverRate   float64 `json:"rolloverRate"`   // 1등이 나오지 않을 확률
+	PrizePerWinner int     `json:"prizePerWinner"` // 1등이 나온 회차의 1인당 당첨금 기대값
+	MaxWinners     float64 `json:"maxWinners"`     // 표본 중 가장 많이 몰린 조합의 1등 기대 수
+	MostShared     []int   `json:"mostShared"`     // 그 조합
+}
+
+// 추첨은 공정하므로 당첨 조합은 균등하게 뽑고, 당첨자 수만 모델을 따른다
+// 균등 모델과 비교하면 번호 쏠림이 이월 확률과 1인당 당첨금을 얼마나 바꾸는지 알 수 있다
+func SurveyDraws(m Model, rng *rand.Rand, tickets, pool, samples int) (Survey, error) {
+	if samples <= 0 || tickets < 0 {
+		return Survey{}, errors.New("표본 수는 1 이상, 티켓 수는 0 이상이어야 합니다")
+	}
+	if err := m.Validate(); err != nil {
+		return Survey{}, err
+	}
+
+	s := Survey{Model: m.Name, Tickets: tickets, Pool: pool, Samples: samples}
+	var winners, rollover, won, paid float64
+
+	for range samples {
+		combo := lotto.RandomNumbers(rng)
+
+		lambda := float64(tickets) * m.Probability(combo)
+		winners += lambda
+		rollover += math.Exp(-lambda)
+		won += -math.Expm1(-lambda)
+		paid += float64(pool) * expectedInverse(lambda)
+
+		if lambda > s.MaxWinners {
+			s.MaxWinners = lambda
+			s.MostShared = slices.Clone(combo)
+		}
+	}
+
+	n := float64(samples)
+	s.MeanWinners = winners / n
+	s.RolloverRate = rollover / n
+	if won > 0 {
+		s.PrizePerWinner = int(paid / won)
+	}
+	return s, nil
+}
+
+// K ~ Poisson(λ) 일 때 E[1/(1+K)] = (1 - e^-λ) / λ
+func expectedShare(lambda float64) float64 {
+	if lambda < 1e-9 {
+		return 1 - lambda/2
+	}
+	return -math.Expm1(-lambda) / lambda
+}
+
+// K ~ Poisson(λ) 일 때 E[1/K; K≥1]. 1등 풀을 K명이 나눌 때의 1인당 비율 기대값 (K=0이면 0)
+// 평균에서 크게 벗어난 항은 무시한다
+func expectedInverse(lambda float64) float64 {
+	if lambda <= 0 {
+		return 0
+	}
+
+	spread := 12*math.Sqrt(lambda) + 30
+	from := max(1, int(lambda-spread))
+	to := int(lambda + spread)
+
+	sum := 0.0
+	logLambda := math.Log(lambda)
+	for k := from; k <= to; k++ {
+		logFact, _ := math.Lgamma(float64(k + 1))
+		sum += math.Exp(float64(k)*logLambda-lambda-logFact) / float64(k)
+	}
+	return sum
+}
@@ -0,0 +1,133 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+// 프로필 파일 형식 (YAML/JSON 공통). 등수 키는 1~5
+//
+//	profiles:
+//	  - name: my-game
+//	    mode: parimutuel        # fixed | parimutuel
+//	    allocations: {1: 7000, 2: 1500, 3: 1500}
+//	    caps: {1: 3000000000}
+//	    roundingUnit: 1000
+//	    rollDownMethod: equal   # proportional | equal
+//	    fixedPrizes: {1: 2000000000}
+type fileFormat struct {
+	Profiles []fileProfile `json:"profiles" yaml:"profiles"`
+}
+
+type fileProfile struct {
+	Name           string      `json:"name" yaml:"name"`
+	Description    string      `json:"description" yaml:"description"`
+	Mode           string      `json:"mode" yaml:"mode"`
+	Allocations    map[int]int `json:"allocations" yaml:"allocations"`
+	Caps           map[int]int `json:"caps" yaml:"caps"`
+	RoundingUnit   int         `json:"roundingUnit" yaml:"roundingUnit"`
+	RollDownMethod string      `json:"rollDownMethod" yaml:"rollDownMethod"`
+	FixedPrizes    map[int]int `json:"fixedPrizes" yaml:"fixedPrizes"`
+}
+
+// 파일의 프로필을 모두 등록. 확장자(.yaml/.yml/.json)로 형식 결정
+func (reg *Registry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var file fileFormat
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&file)
+	default:
+		return fmt.Errorf("지원하지 않는 프로필 파일 형식입니다: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, fp := range file.Profiles {
+		p, err := fp.toProfile()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := reg.Add(p); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (fp fileProfile) toProfile() (Profile, error) {
+	var mode lotto.Mode
+	if err := mode.UnmarshalText([]byte(fp.Mode)); err != nil {
+		return Profile{}, fmt.Errorf("%w: %s: 모드는 fixed 또는 parimutuel이어야 합니다 (%q)", ErrInvalidProfile, fp.Name, fp.Mode)
+	}
+	method := lotto.RollDownProportional
+	if fp.RollDownMethod != "" && method.UnmarshalText([]byte(fp.RollDownMethod)) != nil {
+		return Profile{}, fmt.Errorf("%w: %s: 롤다운 방식은 proportional 또는 equal이어야 합니다 (%q)", ErrInvalidProfile, fp.Name, fp.RollDownMethod)
+	}
+
+	caps, err := rankMap(fp.Caps)
+	if err != nil {
+		return Profile{}, fmt.Errorf("%w: %s: caps: %w", ErrInvalidProfile, fp.Name, err)
+	}
+	prizes, err := rankMap(fp.FixedPrizes)
+	if err != nil {
+		return Profile{}, fmt.Errorf("%w: %s: fixedPrizes: %w", ErrInvalidProfile, fp.Name, err)
+	}
+	allocBps, err := rankMap(fp.Allocations)
+	if err != nil {
+		return Profile{}, fmt.Errorf("%w: %s: allocations: %w", ErrInvalidProfile, fp.Name, err)
+	}
+
+	// 1등부터 순서대로
+	ranks := make([]lotto.Rank, 0, len(allocBps))
+	for r := range allocBps {
+		ranks = append(ranks, r)
+	}
+	sort.Slice(ranks, func(i, j int) bool { return ranks[i] > ranks[j] })
+
+	allocs := make([]lotto.Allocation, 0, len(ranks))
+	for _, r := range ranks {
+		allocs = append(allocs, lotto.Allocation{Rank: r, BasisPoints: allocBps[r]})
+	}
+
+	return Profile{
+		Name:           fp.Name,
+		Description:    fp.Description,
+		Mode:           mode,
+		Allocations:    allocs,
+		CapPerRank:     caps,
+		RoundingUnit:   fp.RoundingUnit,
+		RollDownMethod: method,
+		FixedPayout:    prizes,
+	}, nil
+}
+
+func rankMap(src map[int]int) (map[lotto.Rank]int, error) {
+	dst := make(map[lotto.Rank]int, len(src))
+	for n, v := range src {
+		r, err := lotto.RankFromNumber(n)
+		if err != nil {
+			return nil, err
+		}
+		dst[r] = v
+	}
+	return dst, nil
+}
@@ -0,0 +1,147 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+const (
+	KR645Fixed      = "kr-645-fixed"
+	KR645Parimutuel = "kr-645-parimutuel"
+)
+
+var (
+	ErrUnknownProfile = errors.New("등록되지 않은 프로필입니다")
+	ErrInvalidProfile = errors.New("유효하지 않은 프로필입니다")
+)
+
+// 한 게임의 분배/상금 규칙 묶음
+// 회차마다 달라지는 판매액/당첨자 수/이월은 RoundInput에서 채운다
+type Profile struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Mode        lotto.Mode `json:"mode"`
+
+	Allocations    []lotto.Allocation   `json:"allocations"`
+	CapPerRank     map[lotto.Rank]int   `json:"capPerRank"`
+	RoundingUnit   int                  `json:"roundingUnit"`
+	RollDownMethod lotto.RollDownMethod `json:"rollDownMethod"`
+
+	FixedPayout map[lotto.Rank]int `json:"fixedPayout"` // 고정 상금 모드의 등수별 상금
+}
+
+// 프로필 규칙에 회차 값을 채운 입력
+func (p Profile) RoundInput(sales int, winners, carryIn map[lotto.Rank]int) lotto.RoundInput {
+	if carryIn == nil {
+		carryIn = map[lotto.Rank]int{}
+	}
+
+	return lotto.RoundInput{
+		Mode:           p.Mode,
+		Sales:          sales,
+		Winners:        winners,
+		CarryIn:        carryIn,
+		Allocations:    p.Allocations,
+		CapPerRank:     p.CapPerRank,
+		RoundingUnit:   p.RoundingUnit,
+		RollDownMethod: p.RollDownMethod,
+		FixedPayout:    p.FixedPayout,
+	}
+}
+
+func (p Profile) SeriesConfig() lotto.SeriesConfig {
+	return lotto.SeriesConfig{
+		Mode:           p.Mode,
+		Allocations:    p.Allocations,
+		CapPerRank:     p.CapPerRank,
+		RoundingUnit:   p.RoundingUnit,
+		RollDownMethod: p.RollDownMethod,
+	}
+}
+
+// 규칙 필드(판매액/당첨자 수/이월 제외)를 프로필 값으로 덮어쓴다
+func (p Profile) Apply(in lotto.RoundInput) lotto.RoundInput {
+	out := p.RoundInput(in.Sales, in.Winners, in.CarryIn)
+	out.Meta = in.Meta
+	out.RollDownTargets = in.RollDownTargets
+	return out
+}
+
+func (p Profile) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("%w: 이름이 비어 있습니다", ErrInvalidProfile)
+	}
+
+	switch p.Mode {
+	case lotto.ModeFixedPayout:
+		if len(p.FixedPayout) == 0 {
+			return fmt.Errorf("%w: %s: 고정 상금 모드는 상금표가 필요합니다", ErrInvalidProfile, p.Name)
+		}
+		for r, prize := range p.FixedPayout {
+			if prize < 0 {
+				return fmt.Errorf("%w: %s: %d등 고정 상금은 음수일 수 없습니다 (%d)", ErrInvalidProfile, p.Name, r.Number(), prize)
+			}
+		}
+	case lotto.ModeParimutuel:
+		total := 0
+		for _, a := range p.Allocations {
+			if a.BasisPoints < 0 {
+				return fmt.Errorf("%w: %s: 배정 비율은 음수일 수 없습니다", ErrInvalidProfile, p.Name)
+			}
+			total += a.BasisPoints
+		}
+		if total == 0 || total > lotto.BasisPoints {
+			return fmt.Errorf("%w: %s: 배정 비율 합계는 1~%d bps여야 합니다 (현재 %d)",
+				ErrInvalidProfile, p.Name, lotto.BasisPoints, total)
+		}
+	default:
+		return fmt.Errorf("%w: %s: 지원하지 않는 모드입니다", ErrInvalidProfile, p.Name)
+	}
+
+	for r, c := range p.CapPerRank {
+		if c < 0 {
+			return fmt.Errorf("%w: %s: %d등 상한은 음수일 수 없습니다 (%d)", ErrInvalidProfile, p.Name, r.Number(), c)
+		}
+	}
+	if p.RoundingUnit < 0 {
+		return fmt.Errorf("%w: %s: 라운딩 단위는 음수일 수 없습니다", ErrInvalidProfile, p.Name)
+	}
+	return nil
+}
+
+// 기존 기본값: 고정 상금표 / 7500·1250·1250 bps, 1등 상한 20억, 100원 단위 내림
+func builtins() []Profile {
+	return []Profile{
+		{
+			Name:        KR645Fixed,
+			Description: "6/45 고정 상금",
+			Mode:        lotto.ModeFixedPayout,
+			FixedPayout: map[lotto.Rank]int{
+				lotto.Rank1: lotto.Rank1.Prize(),
+				lotto.Rank2: lotto.Rank2.Prize(),
+				lotto.Rank3: lotto.Rank3.Prize(),
+				lotto.Rank4: lotto.Rank4.Prize(),
+				lotto.Rank5: lotto.Rank5.Prize(),
+			},
+		},
+		{
+			Name:        KR645Parimutuel,
+			Description: "6/45 판매액 분배 (1등 상한 20억)",
+			Mode:        lotto.ModeParimutuel,
+			Allocations: []lotto.Allocation{
+				{Rank: lotto.Rank1, BasisPoints: 7500},
+				{Rank: lotto.Rank2, BasisPoints: 1250},
+				{Rank: lotto.Rank3, BasisPoints: 1250},
+				{Rank: lotto.Rank4, BasisPoints: 0},
+				{Rank: lotto.Rank5, BasisPoints: 0},
+			},
+			CapPerRank: map[lotto.Rank]int{
+				lotto.Rank1: 2_000_000_000,
+			},
+			RoundingUnit:   100,
+			RollDownMethod: lotto.RollDownProportional,
+		},
+	}
+}
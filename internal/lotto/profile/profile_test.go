@@ -0,0 +1,156 @@
+package profile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+func TestRegistryResolve(t *testing.T) {
+	reg := NewRegistry()
+
+	tests := []struct {
+		name     string
+		mode     lotto.Mode
+		wantName string
+	}{
+		{"", lotto.ModeFixedPayout, KR645Fixed},
+		{"", lotto.ModeParimutuel, KR645Parimutuel},
+		{KR645Parimutuel, lotto.ModeFixedPayout, KR645Parimutuel}, // 이름이 모드보다 우선
+	}
+
+	for _, tt := range tests {
+		p, err := reg.Resolve(tt.name, tt.mode)
+		if err != nil {
+			t.Fatalf("프로필 조회 중 에러가 발생했습니다: %v", err)
+		}
+		if p.Name != tt.wantName {
+			t.Errorf("프로필이 예상과 다릅니다. got=%s, want=%s", p.Name, tt.wantName)
+		}
+	}
+
+	if _, err := reg.Get("없는-프로필"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("등록되지 않은 이름은 ErrUnknownProfile여야 합니다. got=%v", err)
+	}
+}
+
+// 고정 상금 프로필도 이월을 그대로 넘긴다
+func TestProfileRoundInput(t *testing.T) {
+	reg := NewRegistry()
+	carry := map[lotto.Rank]int{lotto.Rank1: 1_000}
+
+	for _, name := range reg.Names() {
+		p, _ := reg.Get(name)
+		in := p.RoundInput(5_000, map[lotto.Rank]int{lotto.Rank5: 1}, carry)
+
+		if in.Mode != p.Mode || in.Sales != 5_000 || in.CarryIn[lotto.Rank1] != 1_000 {
+			t.Errorf("%s: 회차 입력이 예상과 다릅니다. got=%+v", name, in)
+		}
+		if _, err := lotto.CalculateRound(in); err != nil {
+			t.Errorf("%s: 기본 프로필로 계산 중 에러가 발생했습니다: %v", name, err)
+		}
+	}
+}
+
+func TestProfileApply(t *testing.T) {
+	p, _ := NewRegistry().Get(KR645Parimutuel)
+	in := lotto.RoundInput{
+		Meta:         lotto.RoundMeta{Sequence: 7},
+		Mode:         lotto.ModeFixedPayout,
+		Sales:        10_000,
+		RoundingUnit: 1,
+	}
+
+	got := p.Apply(in)
+	if got.Mode != lotto.ModeParimutuel || got.RoundingUnit != 100 {
+		t.Errorf("규칙 필드는 프로필 값이어야 합니다. got mode=%d, unit=%d", got.Mode, got.RoundingUnit)
+	}
+	if got.Sales != 10_000 || got.Meta.Sequence != 7 {
+		t.Errorf("회차 값은 유지되어야 합니다. got=%+v", got)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "profiles.yaml")
+	writeFile(t, yamlPath, `profiles:
+  - name: big-jackpot
+    description: 1등 상한 없음
+    mode: parimutuel
+    allocations: {1: 8000, 2: 1000, 3: 1000}
+    roundingUnit: 1000
+    rollDownMethod: equal
+  - name: kr-645-fixed
+    mode: fixed
+    fixedPrizes: {1: 1000000000, 5: 5000}
+`)
+	jsonPath := filepath.Join(dir, "profiles.json")
+	writeFile(t, jsonPath, `{"profiles": [{"name": "tiny", "mode": "parimutuel", "allocations": {"1": 5000}, "caps": {"1": 1000000}}]}`)
+
+	reg := NewRegistry()
+	for _, path := range []string{yamlPath, jsonPath} {
+		if err := reg.LoadFile(path); err != nil {
+			t.Fatalf("프로필 파일을 불러오는 중 에러가 발생했습니다: %v", err)
+		}
+	}
+
+	if got := len(reg.Names()); got != 4 {
+		t.Errorf("같은 이름은 덮어써야 합니다. got=%d개, want=%d개", got, 4)
+	}
+
+	big, _ := reg.Get("big-jackpot")
+	if big.RollDownMethod != lotto.RollDownEqual || big.RoundingUnit != 1000 {
+		t.Errorf("YAML 프로필 값이 예상과 다릅니다. got=%+v", big)
+	}
+	if big.Allocations[0].Rank != lotto.Rank1 || big.Allocations[0].BasisPoints != 8000 {
+		t.Errorf("배정 비율은 1등부터 정렬되어야 합니다. got=%v", big.Allocations)
+	}
+
+	fixed, _ := reg.Get(KR645Fixed)
+	if fixed.FixedPayout[lotto.Rank1] != 1_000_000_000 {
+		t.Errorf("기본 프로필을 파일로 덮어쓸 수 있어야 합니다. got=%v", fixed.FixedPayout)
+	}
+
+	tiny, _ := reg.Get("tiny")
+	if tiny.CapPerRank[lotto.Rank1] != 1_000_000 {
+		t.Errorf("JSON 프로필 상한이 예상과 다릅니다. got=%v", tiny.CapPerRank)
+	}
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"모드 누락", `profiles: [{name: a, allocations: {1: 5000}}]`},
+		{"배정 비율 초과", `profiles: [{name: a, mode: parimutuel, allocations: {1: 9000, 2: 2000}}]`},
+		{"없는 등수", `profiles: [{name: a, mode: parimutuel, allocations: {6: 5000}}]`},
+		{"상금표 없는 고정 모드", `profiles: [{name: a, mode: fixed}]`},
+		{"잘못된 롤다운 방식", `profiles: [{name: a, mode: parimutuel, allocations: {1: 5000}, rollDownMethod: random}]`},
+		{"이름 누락", `profiles: [{mode: parimutuel, allocations: {1: 5000}}]`},
+		{"음수 상한", `profiles: [{name: a, mode: parimutuel, allocations: {1: 5000}, caps: {1: -1}}]`},
+		{"음수 고정 상금", `profiles: [{name: a, mode: fixed, fixedPrizes: {1: 1000000000, 5: -5000}}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "p.yaml")
+			writeFile(t, path, tt.content)
+
+			if err := NewRegistry().LoadFile(path); err == nil {
+				t.Errorf("에러가 발생해야 합니다")
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
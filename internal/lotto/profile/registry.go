@@ -0,0 +1,69 @@
+package profile
+
+import (
+	"fmt"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+// 이름으로 프로필을 찾는 목록 (등록 순서 유지)
+type Registry struct {
+	profiles map[string]Profile
+	names    []string
+}
+
+// 기본 프로필(kr-645-fixed, kr-645-parimutuel)이 등록된 목록
+func NewRegistry() *Registry {
+	reg := &Registry{profiles: make(map[string]Profile)}
+	for _, p := range builtins() {
+		_ = reg.Add(p)
+	}
+	return reg
+}
+
+// 같은 이름이 있으면 덮어쓴다 (파일로 기본 프로필 조정 가능)
+func (reg *Registry) Add(p Profile) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+	if _, exists := reg.profiles[p.Name]; !exists {
+		reg.names = append(reg.names, p.Name)
+	}
+	reg.profiles[p.Name] = p
+	return nil
+}
+
+func (reg *Registry) Get(name string) (Profile, error) {
+	p, exists := reg.profiles[name]
+	if !exists {
+		return Profile{}, fmt.Errorf("%w: %s", ErrUnknownProfile, name)
+	}
+	return p, nil
+}
+
+// 이름이 비어 있으면 모드별 기본 프로필
+func (reg *Registry) Resolve(name string, mode lotto.Mode) (Profile, error) {
+	if name != "" {
+		return reg.Get(name)
+	}
+	return reg.Get(DefaultName(mode))
+}
+
+func (reg *Registry) Names() []string {
+	return append([]string(nil), reg.names...)
+}
+
+func (reg *Registry) List() []Profile {
+	list := make([]Profile, 0, len(reg.names))
+	for _, name := range reg.names {
+		list = append(list, reg.profiles[name])
+	}
+	return list
+}
+
+func DefaultName(mode lotto.Mode) string {
+	if mode == lotto.ModeFixedPayout {
+		return KR645Fixed
+	}
+	return KR645Parimutuel
+}
@@ -1,5 +1,7 @@
 package lotto
 
+import "fmt"
+
 type Rank int
 
 const (
@@ -32,6 +34,22 @@ func (r Rank) Prize() int {
 	return prizes[r]
 }
 
+// 사람이 읽는 등수 번호 (Rank1 → 1, RankNone → 0)
+func (r Rank) Number() int {
+	if r <= RankNone || r > Rank1 {
+		return 0
+	}
+	return int(Rank1-r) + 1
+}
+
+// 등수 번호(1~5)를 Rank로 변환
+func RankFromNumber(n int) (Rank, error) {
+	if n < 1 || n > 5 {
+		return RankNone, fmt.Errorf("%w: %d", ErrInvalidRank, n)
+	}
+	return Rank1 - Rank(n-1), nil
+}
+
 func DetermineRank(matchCount int, hasBonus bool) Rank {
 	if matchCount == 6 {
 		return Rank1
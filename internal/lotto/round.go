@@ -26,7 +26,8 @@ type Allocation struct {
 
 // 한 회차당 필요한 입력값
 type RoundInput struct {
-	Mode Mode `json:"mode"`
+	Meta RoundMeta `json:"meta"` // 회차 번호/추첨 일시 등 식별 정보
+	Mode Mode      `json:"mode"`
 	// 회차 판매액
 	Sales   int          `json:"sales"`
 	Winners map[Rank]int `json:"winners"` // 등수별 당첨자 수
@@ -36,22 +37,28 @@ type RoundInput struct {
 	CapPerRank     map[Rank]int   `json:"capPerRank"`     // 등수별 상한 금액
 	RoundingUnit   int            `json:"roundingUnit"`   // 라운딩 단위 (1, 10, 100단위 내림)
 	RollDownMethod RollDownMethod `json:"rollDownMethod"` // 롤다운 분배 방식
+	// 등수별 상한 초과분을 보낼 곳 (없으면 하위 등수)
+	RollDownTargets map[Rank]RollDownTarget `json:"rollDownTargets"`
 	// 고정 모드
 	FixedPayout map[Rank]int `json:"fixedPayout"`
 }
 
 // 한 회차 분배 결과
 type RoundOutput struct {
-	Sales int `json:"sales"`
+	Meta  RoundMeta `json:"meta"`
+	Sales int       `json:"sales"`
 
-	PoolBefore   map[Rank]int `json:"poolBefore"`   // 이월 포함, 상한/롤다운 적용 전 풀 금액
-	PoolAfterCap map[Rank]int `json:"poolAfterCap"` // 상한 적용 후 풀 금액
-	PaidPerWin   map[Rank]int `json:"paidPerWin"`   // 등수별 1인당 지급액
-	PaidTotal    map[Rank]int `json:"paidTotal"`    // 등수별 총 지급액
-	CarryOut     map[Rank]int `json:"carryOut"`     // 등수별 다음 회차로 이월되는 금액
-	RollDown     map[Rank]int `json:"rollDown"`     // 상한 초과로 하위 등수로 내려보낸 금액
+	PoolBefore   map[Rank]int   `json:"poolBefore"`   // 이월 포함, 상한/롤다운 적용 전 풀 금액
+	PoolAfterCap map[Rank]int   `json:"poolAfterCap"` // 상한 적용 후 풀 금액
+	PaidPerWin   map[Rank]int   `json:"paidPerWin"`   // 등수별 1인당 지급액
+	PaidTotal    map[Rank]int   `json:"paidTotal"`    // 등수별 총 지급액
+	CarryOut     map[Rank]int   `json:"carryOut"`     // 등수별 다음 회차로 이월되는 금액
+	RollDown     map[Rank]int   `json:"rollDown"`     // 상한 초과로 하위 등수로 내려보낸 금액
+	Reserves     map[string]int `json:"reserves"`     // 상한 초과분 중 적립금으로 보낸 금액
 
 	RoundRemainder int `json:"roundRemainder"` // 판매액 중 풀에 배정되지 않은 라운드 잔액
+
+	Ledger Ledger `json:"ledger"` // 회차 내 모든 금액 이동 기록
 }
 
 type roundCalculator func(*RoundOutput, RoundInput)
@@ -66,6 +73,10 @@ func CalculateRound(in RoundInput) (RoundOutput, error) {
 		return RoundOutput{}, ErrNegativeSales
 	}
 
+	if err := validateRollDownTargets(in.RollDownTargets); err != nil {
+		return RoundOutput{}, err
+	}
+
 	out := newRoundOutput(in)
 
 	calc, exists := modeCalculators[in.Mode]
@@ -74,6 +85,12 @@ func CalculateRound(in RoundInput) (RoundOutput, error) {
 	}
 
 	calc(&out, in)
+
+	// 돈이 새거나 생겨나면 결과를 내보내지 않는다
+	if err := CheckLedger(in, out); err != nil {
+		return RoundOutput{}, err
+	}
+	roundsSimulated.With(in.Mode.String()).Inc()
 	return out, nil
 }
 
@@ -87,8 +104,9 @@ func calcParimutuelRound(out *RoundOutput, in RoundInput) {
 	if out.RoundRemainder < 0 {
 		out.RoundRemainder = 0
 	}
+	out.Ledger.record(LedgerRemainder, AccountSales, RankNone, AccountRemainder, RankNone, out.RoundRemainder)
 
-	applyCapAndRolldown(out.PoolAfterCap, in.CapPerRank, allocBps, order, out.RollDown, in.RollDownMethod)
+	applyCapAndRolldown(out, in, allocBps, order)
 	calcPayoutAndCarry(out, in, order)
 }
 
@@ -108,6 +126,9 @@ func calcBasePools(
 		carry := in.CarryIn[r]
 		pool := basePool + carry
 
+		out.Ledger.record(LedgerSale, AccountSales, RankNone, AccountPool, r, basePool)
+		out.Ledger.record(LedgerCarryIn, AccountCarryIn, r, AccountPool, r, carry)
+
 		out.PoolBefore[r] = pool
 		out.PoolAfterCap[r] = pool
 	}
@@ -127,7 +148,8 @@ func calcPayoutAndCarry(
 
 		if winners <= 0 {
 			// 당첨자 없으면 전체 이월
-			out.CarryOut[r] = pool
+			out.CarryOut[r] += pool // 롤다운으로 먼저 쌓인 이월금이 있을 수 있음
+			out.Ledger.record(LedgerCarryOut, AccountPool, r, AccountCarryOut, r, pool)
 			continue
 		}
 		// 라운딩 단위 설정(0이하면 1원 단위 취급)
@@ -147,35 +169,41 @@ func calcPayoutAndCarry(
 
 		out.PaidPerWin[r] = roundedPer
 		out.PaidTotal[r] = total
+		out.Ledger.record(LedgerPayout, AccountPool, r, AccountWinners, r, total)
 
 		// 잔액 이월
 		remain := pool - total
 		if remain > 0 {
-			out.CarryOut[r] = remain
+			out.CarryOut[r] += remain
+			out.Ledger.record(LedgerCarryOut, AccountPool, r, AccountCarryOut, r, remain)
 		}
 	}
 }
 
+// 롤다운을 받은 등수가 다시 상한을 넘으면 그 초과분도 다시 보낸다.
+// 돈은 하위 등수나 풀 밖으로만 흐르므로 등수 수만큼 반복하면 반드시 안정된다
 func applyCapAndRolldown(
-	pool map[Rank]int,
-	caps map[Rank]int,
+	out *RoundOutput,
+	in RoundInput,
 	allocBps map[Rank]int,
 	order []Rank,
-	rollDown map[Rank]int,
-	method RollDownMethod,
 ) {
-	for i, r := range order {
-		overflow := calculateOverflow(pool, caps, r, rollDown)
-		if overflow <= 0 {
-			continue
-		}
+	for pass := 0; pass <= len(order); pass++ {
+		overflowed := false
 
-		lowerRanks := order[i+1:]
-		if len(lowerRanks) == 0 {
-			continue
+		for i, r := range order {
+			overflow := calculateOverflow(out.PoolAfterCap, in.CapPerRank, r, out.RollDown)
+			if overflow <= 0 {
+				continue
+			}
+			overflowed = true
+
+			routeOverflow(out, in, r, order[i+1:], overflow, allocBps)
 		}
 
-		distributeOverflow(pool, lowerRanks, overflow, allocBps, method)
+		if !overflowed {
+			return
+		}
 	}
 }
 
@@ -194,55 +222,65 @@ func calculateOverflow(pool map[Rank]int, caps map[Rank]int, rank Rank, rollDown
 	return overflow
 }
 
+// 초과분을 하위 등수 풀에 더하고, 등수별로 더한 금액을 돌려준다
 func distributeOverflow(
 	pool map[Rank]int,
 	lowerRanks []Rank,
 	overflow int,
 	allocBps map[Rank]int,
 	method RollDownMethod,
-) {
+) map[Rank]int {
+	var moved map[Rank]int
 	if method == RollDownEqual {
-		distributeEqually(pool, lowerRanks, overflow)
-		return
+		moved = distributeEqually(lowerRanks, overflow)
+	} else {
+		moved = distributeProportionally(lowerRanks, overflow, allocBps)
+	}
+
+	for lr, add := range moved {
+		pool[lr] += add
 	}
-	distributeProportionally(pool, lowerRanks, overflow, allocBps)
+	return moved
 }
 
-func distributeEqually(pool map[Rank]int, lowerRanks []Rank, overflow int) {
+func distributeEqually(lowerRanks []Rank, overflow int) map[Rank]int {
+	moved := make(map[Rank]int, len(lowerRanks))
 	perRank := overflow / len(lowerRanks)
 	remainder := overflow % len(lowerRanks)
 
 	for idx, lr := range lowerRanks {
 		add := perRank
 		if idx == len(lowerRanks)-1 {
-			add += remainder
+			add += remainder // 나눗셈 잔액은 마지막 등수로
 		}
-		pool[lr] += add
+		moved[lr] += add
 	}
+	return moved
 }
 
 func distributeProportionally(
-	pool map[Rank]int,
 	lowerRanks []Rank,
 	overflow int,
 	allocBps map[Rank]int,
-) {
+) map[Rank]int {
+	moved := make(map[Rank]int, len(lowerRanks))
 	totalBasicPoints := calculateTotalBasicPoints(lowerRanks, allocBps)
 	if totalBasicPoints == 0 {
-		pool[lowerRanks[len(lowerRanks)-1]] += overflow
-		return
+		moved[lowerRanks[len(lowerRanks)-1]] += overflow
+		return moved
 	}
 
 	distributed := 0
 	for idx, lr := range lowerRanks {
 		if idx == len(lowerRanks)-1 {
-			pool[lr] += overflow - distributed
+			moved[lr] += overflow - distributed // 나눗셈 잔액은 마지막 등수로
 			break
 		}
 		add := overflow * allocBps[lr] / totalBasicPoints
 		distributed += add
-		pool[lr] += add
+		moved[lr] += add
 	}
+	return moved
 }
 
 func calculateTotalBasicPoints(lowerRanks []Rank, allocBps map[Rank]int) int {
@@ -279,10 +317,26 @@ func calcFixedPayoutRound(out *RoundOutput, in RoundInput) {
 		remainder = 0
 	}
 	out.RoundRemainder = remainder
+
+	recordFixedPayoutLedger(out, totalPaid)
+}
+
+// 고정 모드는 판매액(+부족분은 운영 자금)을 하나의 풀에 모아 지급한다
+func recordFixedPayoutLedger(out *RoundOutput, totalPaid int) {
+	out.Ledger.record(LedgerSale, AccountSales, RankNone, AccountPool, RankNone, out.Sales)
+	if totalPaid > out.Sales {
+		out.Ledger.record(LedgerSubsidy, AccountOperator, RankNone, AccountPool, RankNone, totalPaid-out.Sales)
+	}
+
+	for _, r := range ledgerRanks {
+		out.Ledger.record(LedgerPayout, AccountPool, RankNone, AccountWinners, r, out.PaidTotal[r])
+	}
+	out.Ledger.record(LedgerRemainder, AccountPool, RankNone, AccountRemainder, RankNone, out.RoundRemainder)
 }
 
 func newRoundOutput(in RoundInput) RoundOutput {
 	return RoundOutput{
+		Meta:         in.Meta,
 		Sales:        in.Sales,
 		PoolBefore:   make(map[Rank]int),
 		PoolAfterCap: make(map[Rank]int),
@@ -290,6 +344,7 @@ func newRoundOutput(in RoundInput) RoundOutput {
 		PaidTotal:    make(map[Rank]int),
 		CarryOut:     make(map[Rank]int),
 		RollDown:     make(map[Rank]int),
+		Reserves:     make(map[string]int),
 	}
 }
 
@@ -0,0 +1,182 @@
+package lotto
+
+import "fmt"
+
+// 원장 계정(돈이 머무는 곳)
+type LedgerAccount string
+
+const (
+	AccountSales     LedgerAccount = "sales"     // 회차 판매액
+	AccountCarryIn   LedgerAccount = "carryIn"   // 이전 회차에서 넘어온 이월금
+	AccountOperator  LedgerAccount = "operator"  // 고정 모드에서 판매액을 넘는 지급을 보전하는 운영 자금
+	AccountPool      LedgerAccount = "pool"      // 등수별 풀 (고정 모드는 RankNone 풀 하나)
+	AccountWinners   LedgerAccount = "winners"   // 당첨자 지급
+	AccountCarryOut  LedgerAccount = "carryOut"  // 다음 회차로 이월
+	AccountRemainder LedgerAccount = "remainder" // 라운드 잔액
+	AccountReserve   LedgerAccount = "reserve"   // 상한 초과분 적립금 (Reserve로 이름 구분)
+)
+
+// 원장 이동 종류
+type LedgerKind string
+
+const (
+	LedgerSale      LedgerKind = "sale"      // 판매액 → 풀
+	LedgerCarryIn   LedgerKind = "carryIn"   // 이월금 → 풀
+	LedgerSubsidy   LedgerKind = "subsidy"   // 운영 자금 → 풀
+	LedgerRollDown  LedgerKind = "rollDown"  // 상한 초과분 → 하위 등수 풀
+	LedgerPayout    LedgerKind = "payout"    // 풀 → 당첨자
+	LedgerCarryOut  LedgerKind = "carryOut"  // 풀 → 다음 회차 이월
+	LedgerRemainder LedgerKind = "remainder" // 판매액/풀 → 라운드 잔액
+)
+
+// 복식 기입 한 줄: From 계정에서 To 계정으로 Amount만큼 이동
+type LedgerEntry struct {
+	Kind     LedgerKind    `json:"kind"`
+	From     LedgerAccount `json:"from"`
+	FromRank Rank          `json:"fromRank"`
+	To       LedgerAccount `json:"to"`
+	ToRank   Rank          `json:"toRank"`
+	Amount   int           `json:"amount"`
+	Reserve  string        `json:"reserve,omitempty"` // To가 AccountReserve일 때 적립금 이름
+}
+
+// 한 회차 동안의 모든 금액 이동 기록
+type Ledger struct {
+	Entries []LedgerEntry `json:"entries"`
+}
+
+func (l *Ledger) record(
+	kind LedgerKind,
+	from LedgerAccount, fromRank Rank,
+	to LedgerAccount, toRank Rank,
+	amount int,
+) {
+	if amount == 0 {
+		return
+	}
+	l.Entries = append(l.Entries, LedgerEntry{
+		Kind:     kind,
+		From:     from,
+		FromRank: fromRank,
+		To:       to,
+		ToRank:   toRank,
+		Amount:   amount,
+	})
+}
+
+func (l *Ledger) recordReserve(from Rank, reserve string, amount int) {
+	if amount == 0 {
+		return
+	}
+	l.Entries = append(l.Entries, LedgerEntry{
+		Kind:     LedgerRollDown,
+		From:     AccountPool,
+		FromRank: from,
+		To:       AccountReserve,
+		Amount:   amount,
+		Reserve:  reserve,
+	})
+}
+
+// 계정(+등수)의 순잔액 = 들어온 금액 - 나간 금액
+func (l Ledger) Balance(account LedgerAccount, rank Rank) int {
+	balance := 0
+	for _, e := range l.Entries {
+		if e.To == account && e.ToRank == rank {
+			balance += e.Amount
+		}
+		if e.From == account && e.FromRank == rank {
+			balance -= e.Amount
+		}
+	}
+	return balance
+}
+
+// 등수 구분 없이 계정 전체의 순잔액
+func (l Ledger) AccountBalance(account LedgerAccount) int {
+	balance := 0
+	for _, e := range l.Entries {
+		if e.To == account {
+			balance += e.Amount
+		}
+		if e.From == account {
+			balance -= e.Amount
+		}
+	}
+	return balance
+}
+
+var ledgerRanks = []Rank{RankNone, Rank1, Rank2, Rank3, Rank4, Rank5}
+
+// 원장이 입력/출력과 맞아떨어지는지 검사. 한 푼이라도 어긋나면 ErrLedgerImbalance
+func CheckLedger(in RoundInput, out RoundOutput) error {
+	l := out.Ledger
+
+	for _, e := range l.Entries {
+		if e.Amount < 0 {
+			return fmt.Errorf("%w: 음수 이동 %s %d원", ErrLedgerImbalance, e.Kind, e.Amount)
+		}
+	}
+
+	// 풀은 들어온 만큼 모두 나가야 한다
+	for _, r := range ledgerRanks {
+		if b := l.Balance(AccountPool, r); b != 0 {
+			return fmt.Errorf("%w: %s 풀에 %d원이 남았습니다", ErrLedgerImbalance, rankLabel(r), b)
+		}
+	}
+
+	if sold := -l.AccountBalance(AccountSales); sold != out.Sales {
+		return fmt.Errorf("%w: 판매액 %d원 중 %d원만 기록되었습니다", ErrLedgerImbalance, out.Sales, sold)
+	}
+
+	// 고정 모드는 이월을 다루지 않으므로 분배 모드에서만 이월 입력을 대조
+	if in.Mode == ModeParimutuel {
+		for r, carry := range in.CarryIn {
+			if used := -l.Balance(AccountCarryIn, r); used != carry {
+				return fmt.Errorf("%w: %s 이월금 %d원 중 %d원만 기록되었습니다",
+					ErrLedgerImbalance, rankLabel(r), carry, used)
+			}
+		}
+	}
+
+	for _, r := range ledgerRanks {
+		if paid := l.Balance(AccountWinners, r); paid != out.PaidTotal[r] {
+			return fmt.Errorf("%w: %s 지급액 불일치 (원장 %d원, 결과 %d원)",
+				ErrLedgerImbalance, rankLabel(r), paid, out.PaidTotal[r])
+		}
+		if carried := l.Balance(AccountCarryOut, r); carried != out.CarryOut[r] {
+			return fmt.Errorf("%w: %s 이월액 불일치 (원장 %d원, 결과 %d원)",
+				ErrLedgerImbalance, rankLabel(r), carried, out.CarryOut[r])
+		}
+	}
+
+	reserved := make(map[string]int)
+	for _, e := range l.Entries {
+		if e.To == AccountReserve {
+			reserved[e.Reserve] += e.Amount
+		}
+	}
+	if len(reserved) != len(out.Reserves) {
+		return fmt.Errorf("%w: 적립금 항목 수 불일치", ErrLedgerImbalance)
+	}
+	for name, amount := range out.Reserves {
+		if reserved[name] != amount {
+			return fmt.Errorf("%w: 적립금 %q 불일치 (원장 %d원, 결과 %d원)",
+				ErrLedgerImbalance, name, reserved[name], amount)
+		}
+	}
+
+	if rem := l.AccountBalance(AccountRemainder); rem != out.RoundRemainder {
+		return fmt.Errorf("%w: 라운드 잔액 불일치 (원장 %d원, 결과 %d원)",
+			ErrLedgerImbalance, rem, out.RoundRemainder)
+	}
+
+	return nil
+}
+
+func rankLabel(r Rank) string {
+	if r == RankNone {
+		return "공통"
+	}
+	return fmt.Sprintf("%d등", r.Number())
+}
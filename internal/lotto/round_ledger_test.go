@@ -0,0 +1,156 @@
+package lotto
+
+import (
+	"errors"
+	"testing"
+)
+
+// 여러 분배 시나리오에서 원장 금액이 모두 맞는지 검증
+func TestCalculateRound_LedgerBalances(t *testing.T) {
+	tests := []struct {
+		name string
+		in   RoundInput
+	}{
+		{
+			name: "분배 모드 - 이월 + 라운딩 잔액",
+			in: RoundInput{
+				Mode:    ModeParimutuel,
+				Sales:   1_234_567,
+				Winners: map[Rank]int{Rank1: 3, Rank2: 0, Rank3: 7},
+				CarryIn: map[Rank]int{Rank1: 10_001, Rank2: 333},
+				Allocations: []Allocation{
+					{Rank: Rank1, BasisPoints: 7_500},
+					{Rank: Rank2, BasisPoints: 1_250},
+					{Rank: Rank3, BasisPoints: 1_250},
+				},
+				RoundingUnit: 100,
+			},
+		},
+		{
+			name: "분배 모드 - 상한 + 균등 롤다운 나눗셈 잔액",
+			in: RoundInput{
+				Mode:    ModeParimutuel,
+				Sales:   1_000_001,
+				Winners: map[Rank]int{Rank1: 1, Rank2: 1, Rank3: 1, Rank4: 1, Rank5: 1},
+				Allocations: []Allocation{
+					{Rank: Rank1, BasisPoints: 10_000},
+				},
+				CapPerRank:     map[Rank]int{Rank1: 1},
+				RoundingUnit:   1,
+				RollDownMethod: RollDownEqual,
+			},
+		},
+		{
+			name: "분배 모드 - 최하위 등수 상한 초과분",
+			in: RoundInput{
+				Mode:    ModeParimutuel,
+				Sales:   100_000,
+				Winners: map[Rank]int{Rank5: 2},
+				Allocations: []Allocation{
+					{Rank: Rank5, BasisPoints: 10_000},
+				},
+				CapPerRank:   map[Rank]int{Rank5: 30_000},
+				RoundingUnit: 1,
+			},
+		},
+		{
+			name: "고정 모드 - 판매액보다 많은 지급",
+			in: RoundInput{
+				Mode:        ModeFixedPayout,
+				Sales:       5_000,
+				Winners:     map[Rank]int{Rank1: 1, Rank5: 2},
+				FixedPayout: map[Rank]int{Rank1: Rank1.Prize(), Rank5: Rank5.Prize()},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := CalculateRound(tt.in)
+			if err != nil {
+				t.Fatalf("라운드 계산 중 에러가 발생했습니다: %v", err)
+			}
+			if len(out.Ledger.Entries) == 0 {
+				t.Fatalf("원장 기록이 비어 있습니다")
+			}
+
+			sources := -out.Ledger.AccountBalance(AccountSales) -
+				out.Ledger.AccountBalance(AccountCarryIn) -
+				out.Ledger.AccountBalance(AccountOperator)
+			sinks := out.Ledger.AccountBalance(AccountWinners) +
+				out.Ledger.AccountBalance(AccountCarryOut) +
+				out.Ledger.AccountBalance(AccountRemainder)
+			if sources != sinks {
+				t.Errorf("유입과 유출이 다릅니다. sources=%d, sinks=%d", sources, sinks)
+			}
+		})
+	}
+}
+
+// 하위 등수가 없는 상한 초과분은 라운드 잔액으로 남아야 함
+func TestCalculateRound_LowestRankOverflowGoesToRemainder(t *testing.T) {
+	in := RoundInput{
+		Mode:    ModeParimutuel,
+		Sales:   100_000,
+		Winners: map[Rank]int{Rank5: 2},
+		Allocations: []Allocation{
+			{Rank: Rank5, BasisPoints: 10_000},
+		},
+		CapPerRank:   map[Rank]int{Rank5: 30_000},
+		RoundingUnit: 1,
+	}
+
+	out, err := CalculateRound(in)
+	if err != nil {
+		t.Fatalf("라운드 계산 중 에러가 발생했습니다: %v", err)
+	}
+
+	if out.RoundRemainder != 70_000 {
+		t.Errorf("라운드 잔액이 예상과 다릅니다. got=%d, want=%d", out.RoundRemainder, 70_000)
+	}
+	if out.PaidTotal[Rank5] != 30_000 {
+		t.Errorf("5등 총 지급액이 예상과 다릅니다. got=%d, want=%d", out.PaidTotal[Rank5], 30_000)
+	}
+}
+
+// 배정 비율 합이 100%를 넘으면 판매액보다 많은 돈이 풀로 들어가므로 원장 검증에 걸려야 함
+func TestCalculateRound_OverAllocationFailsLedger(t *testing.T) {
+	in := RoundInput{
+		Mode:    ModeParimutuel,
+		Sales:   1_000_000,
+		Winners: map[Rank]int{Rank1: 1},
+		Allocations: []Allocation{
+			{Rank: Rank1, BasisPoints: 8_000},
+			{Rank: Rank2, BasisPoints: 8_000},
+		},
+		RoundingUnit: 1,
+	}
+
+	_, err := CalculateRound(in)
+	if !errors.Is(err, ErrLedgerImbalance) {
+		t.Fatalf("원장 불일치 에러가 발생해야 합니다. got=%v", err)
+	}
+}
+
+// 원장이 조작되면 검증기가 잡아내야 함
+func TestCheckLedger_DetectsTampering(t *testing.T) {
+	in := RoundInput{
+		Mode:    ModeParimutuel,
+		Sales:   1_000_000,
+		Winners: map[Rank]int{Rank1: 1},
+		Allocations: []Allocation{
+			{Rank: Rank1, BasisPoints: 10_000},
+		},
+		RoundingUnit: 1,
+	}
+
+	out, err := CalculateRound(in)
+	if err != nil {
+		t.Fatalf("라운드 계산 중 에러가 발생했습니다: %v", err)
+	}
+
+	out.PaidTotal[Rank1] += 1
+	if err := CheckLedger(in, out); !errors.Is(err, ErrLedgerImbalance) {
+		t.Fatalf("지급액 조작을 잡아내야 합니다. got=%v", err)
+	}
+}
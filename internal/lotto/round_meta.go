@@ -0,0 +1,137 @@
+package lotto
+
+import (
+	"fmt"
+	"time"
+)
+
+// 회차 식별 정보 (외부 데이터와 회차/날짜로 join 하기 위함)
+type RoundMeta struct {
+	GameID     string    `json:"gameId,omitempty"`
+	Sequence   int       `json:"sequence,omitempty"`  // 회차 번호
+	DrawAt     time.Time `json:"drawAt,omitzero"`     // 추첨 일시
+	SalesOpen  time.Time `json:"salesOpen,omitzero"`  // 판매 시작
+	SalesClose time.Time `json:"salesClose,omitzero"` // 판매 마감
+}
+
+// 게임 ID와 회차 번호를 합친 식별자 (예: kr-645-1123)
+func (m RoundMeta) ID() string {
+	if m.GameID == "" {
+		return fmt.Sprintf("%d", m.Sequence)
+	}
+	return fmt.Sprintf("%s-%d", m.GameID, m.Sequence)
+}
+
+// 보고서용 표기 (예: 1123회차, 2024-06-01)
+func (m RoundMeta) Label() string {
+	if m.DrawAt.IsZero() {
+		return fmt.Sprintf("%d회차", m.Sequence)
+	}
+	return fmt.Sprintf("%d회차, %s", m.Sequence, m.DrawAt.Format(time.DateOnly))
+}
+
+// 회차 번호와 추첨 일정을 만들어 내는 추첨 달력
+type DrawCalendar struct {
+	GameID        string
+	FirstSequence int           // 첫 회차 번호
+	FirstDraw     time.Time     // 첫 회차 추첨 일시
+	Interval      time.Duration // 추첨 간격 (0이면 매주 같은 요일/시각)
+	SalesWindow   time.Duration // 추첨 전 판매 기간 (0이면 직전 추첨부터)
+	SalesCutoff   time.Duration // 추첨 몇 분 전에 판매를 마감하는지
+
+	Dates []time.Time // 직접 지정한 추첨 일시 (있으면 Interval보다 우선)
+}
+
+const (
+	DefaultGameID    = "kr-645"
+	DefaultDrawHour  = 20
+	DefaultDrawMin   = 35
+	DefaultCutoffMin = 35 // 20:00 판매 마감
+)
+
+// 한국 로또 추첨 기준 시간대
+var KST = time.FixedZone("KST", 9*60*60)
+
+// "2006-01-02" 또는 "2006-01-02 15:04" 형식의 추첨 일시 파싱 (날짜만 주면 기본 추첨 시각)
+func ParseDrawDate(input string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02 15:04", input, KST); err == nil {
+		return t, nil
+	}
+
+	d, err := time.ParseInLocation(time.DateOnly, input, KST)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("추첨 일자는 YYYY-MM-DD 형식이어야 합니다: %q", input)
+	}
+	return d.Add(DefaultDrawHour*time.Hour + DefaultDrawMin*time.Minute), nil
+}
+
+// 매주 같은 요일/시각에 추첨하는 기본 달력
+func WeeklyCalendar(gameID string, firstSequence int, firstDraw time.Time) DrawCalendar {
+	return DrawCalendar{
+		GameID:        gameID,
+		FirstSequence: firstSequence,
+		FirstDraw:     firstDraw,
+		SalesCutoff:   DefaultCutoffMin * time.Minute,
+	}
+}
+
+// idx번째(0부터) 회차 메타데이터
+func (c DrawCalendar) Round(idx int) RoundMeta {
+	first := c.FirstSequence
+	if first <= 0 {
+		first = 1
+	}
+
+	meta := RoundMeta{
+		GameID:   c.GameID,
+		Sequence: first + idx,
+	}
+	if c.FirstDraw.IsZero() && len(c.Dates) == 0 {
+		return meta
+	}
+
+	meta.DrawAt = c.drawAt(idx)
+	meta.SalesClose = meta.DrawAt.Add(-c.SalesCutoff)
+	meta.SalesOpen = c.salesOpen(idx, meta.DrawAt)
+	return meta
+}
+
+// 처음부터 n개 회차 메타데이터
+func (c DrawCalendar) Rounds(n int) []RoundMeta {
+	metas := make([]RoundMeta, 0, n)
+	for i := 0; i < n; i++ {
+		metas = append(metas, c.Round(i))
+	}
+	return metas
+}
+
+func (c DrawCalendar) drawAt(idx int) time.Time {
+	if len(c.Dates) > 0 {
+		if idx < len(c.Dates) {
+			return c.Dates[idx]
+		}
+		// 지정한 날짜를 넘어가면 마지막 날짜부터 간격을 이어 붙인다
+		last := len(c.Dates) - 1
+		return c.step(c.Dates[last], idx-last)
+	}
+	return c.step(c.FirstDraw, idx)
+}
+
+func (c DrawCalendar) step(from time.Time, n int) time.Time {
+	if c.Interval <= 0 {
+		// 일 단위로 더해야 서머타임이 있어도 같은 시각 유지
+		return from.AddDate(0, 0, 7*n)
+	}
+	return from.Add(time.Duration(n) * c.Interval)
+}
+
+func (c DrawCalendar) salesOpen(idx int, drawAt time.Time) time.Time {
+	if c.SalesWindow > 0 {
+		return drawAt.Add(-c.SalesWindow)
+	}
+	// 기본: 직전 추첨 직후부터 판매
+	if idx == 0 {
+		return c.step(drawAt, -1)
+	}
+	return c.drawAt(idx - 1)
+}
@@ -0,0 +1,96 @@
+package lotto
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDrawCalendar_Round(t *testing.T) {
+	first := time.Date(2024, 6, 1, 20, 35, 0, 0, KST)
+
+	tests := []struct {
+		name     string
+		cal      DrawCalendar
+		idx      int
+		wantSeq  int
+		wantDraw time.Time
+	}{
+		{"주간 - 첫 회차", WeeklyCalendar(DefaultGameID, 1123, first), 0, 1123, first},
+		{"주간 - 세 번째 회차", WeeklyCalendar(DefaultGameID, 1123, first), 2, 1125, first.AddDate(0, 0, 14)},
+		{
+			"사용자 간격 - 3일",
+			DrawCalendar{FirstSequence: 10, FirstDraw: first, Interval: 72 * time.Hour},
+			1, 11, first.Add(72 * time.Hour),
+		},
+		{
+			"직접 지정 날짜 이후는 간격으로 이어 붙임",
+			DrawCalendar{FirstSequence: 1, Dates: []time.Time{first, first.AddDate(0, 0, 3)}},
+			2, 3, first.AddDate(0, 0, 10),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := tt.cal.Round(tt.idx)
+			if meta.Sequence != tt.wantSeq {
+				t.Errorf("회차 번호가 예상과 다릅니다. got=%d, want=%d", meta.Sequence, tt.wantSeq)
+			}
+			if !meta.DrawAt.Equal(tt.wantDraw) {
+				t.Errorf("추첨 일시가 예상과 다릅니다. got=%v, want=%v", meta.DrawAt, tt.wantDraw)
+			}
+			if !meta.SalesOpen.Before(meta.SalesClose) || meta.SalesClose.After(meta.DrawAt) {
+				t.Errorf("판매 기간이 올바르지 않습니다. open=%v, close=%v, draw=%v",
+					meta.SalesOpen, meta.SalesClose, meta.DrawAt)
+			}
+		})
+	}
+}
+
+func TestRoundMeta_Label(t *testing.T) {
+	cal := WeeklyCalendar(DefaultGameID, 1123, time.Date(2024, 6, 1, 20, 35, 0, 0, KST))
+	meta := cal.Round(0)
+
+	if got := meta.Label(); got != "1123회차, 2024-06-01" {
+		t.Errorf("회차 표기가 예상과 다릅니다. got=%q", got)
+	}
+	if got := meta.ID(); got != "kr-645-1123" {
+		t.Errorf("회차 식별자가 예상과 다릅니다. got=%q", got)
+	}
+}
+
+func TestParseDrawDate(t *testing.T) {
+	got, err := ParseDrawDate("2024-06-01")
+	if err != nil {
+		t.Fatalf("날짜 파싱 중 에러가 발생했습니다: %v", err)
+	}
+	want := time.Date(2024, 6, 1, DefaultDrawHour, DefaultDrawMin, 0, 0, KST)
+	if !got.Equal(want) {
+		t.Errorf("기본 추첨 시각이 적용되지 않았습니다. got=%v, want=%v", got, want)
+	}
+
+	if _, err := ParseDrawDate("2024/06/01"); err == nil {
+		t.Errorf("잘못된 형식에서 에러가 발생해야 합니다")
+	}
+}
+
+// 시리즈 결과에 달력 기반 메타데이터가 붙는지 검증
+func TestSimulateSeries_AttachesCalendarMeta(t *testing.T) {
+	cal := WeeklyCalendar(DefaultGameID, 500, time.Date(2024, 1, 6, 20, 35, 0, 0, KST))
+	cfg := SeriesConfig{
+		Mode:        ModeParimutuel,
+		Allocations: []Allocation{{Rank: Rank1, BasisPoints: 10_000}},
+		Calendar:    &cal,
+	}
+
+	results, err := SimulateSeries(cfg, []int{1_000, 1_000}, []map[Rank]int{{}, {}}, nil)
+	if err != nil {
+		t.Fatalf("시리즈 시뮬레이션 중 에러가 발생했습니다. err=%v", err)
+	}
+
+	if results[1].Meta.Sequence != 501 {
+		t.Errorf("2회차 번호가 예상과 다릅니다. got=%d, want=%d", results[1].Meta.Sequence, 501)
+	}
+	if got := results[1].Meta.DrawAt.Format(time.DateOnly); got != "2024-01-13" {
+		t.Errorf("2회차 추첨일이 예상과 다릅니다. got=%s", got)
+	}
+}
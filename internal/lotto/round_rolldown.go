@@ -0,0 +1,69 @@
+package lotto
+
+import "fmt"
+
+// 상한 초과분을 보낼 곳
+type RollDownTargetKind int
+
+const (
+	RollDownToLower       RollDownTargetKind = iota // 하위 등수 풀 (기본값)
+	RollDownToNextJackpot                           // 다음 회차 1등 풀로 이월
+	RollDownToReserve                               // 이름 붙은 적립금
+)
+
+// 등수별 롤다운 경로
+type RollDownTarget struct {
+	Kind    RollDownTargetKind `json:"kind"`
+	Reserve string             `json:"reserve,omitempty"` // RollDownToReserve일 때 적립금 이름
+}
+
+func validateRollDownTargets(targets map[Rank]RollDownTarget) error {
+	for r, t := range targets {
+		if r < Rank5 || r > Rank1 {
+			return fmt.Errorf("%w: 등수 %d", ErrInvalidRollDown, r)
+		}
+		switch t.Kind {
+		case RollDownToLower, RollDownToNextJackpot:
+		case RollDownToReserve:
+			if t.Reserve == "" {
+				return fmt.Errorf("%w: %d등 적립금 이름이 비었습니다", ErrInvalidRollDown, r.Number())
+			}
+		default:
+			return fmt.Errorf("%w: %d등 대상 종류 %d", ErrInvalidRollDown, r.Number(), t.Kind)
+		}
+	}
+	return nil
+}
+
+// 등수 설정에 따라 상한 초과분을 옮기고 원장에 기록
+func routeOverflow(
+	out *RoundOutput,
+	in RoundInput,
+	from Rank,
+	lowerRanks []Rank,
+	overflow int,
+	allocBps map[Rank]int,
+) {
+	target := in.RollDownTargets[from]
+
+	switch target.Kind {
+	case RollDownToNextJackpot:
+		out.CarryOut[Rank1] += overflow
+		out.Ledger.record(LedgerRollDown, AccountPool, from, AccountCarryOut, Rank1, overflow)
+	case RollDownToReserve:
+		out.Reserves[target.Reserve] += overflow
+		out.Ledger.recordReserve(from, target.Reserve, overflow)
+	default:
+		if len(lowerRanks) == 0 {
+			// 내려보낼 하위 등수가 없으면 라운드 잔액으로 보낸다
+			out.RoundRemainder += overflow
+			out.Ledger.record(LedgerRemainder, AccountPool, from, AccountRemainder, RankNone, overflow)
+			return
+		}
+
+		moved := distributeOverflow(out.PoolAfterCap, lowerRanks, overflow, allocBps, in.RollDownMethod)
+		for _, lr := range lowerRanks {
+			out.Ledger.record(LedgerRollDown, AccountPool, from, AccountPool, lr, moved[lr])
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package lotto
+
+import (
+	"errors"
+	"testing"
+)
+
+// 연쇄 상한 / 롤다운 경로별 풀, 잔액, 이월, 적립금 검증
+func TestCalculateRound_CascadingCaps(t *testing.T) {
+	allWinners := map[Rank]int{Rank1: 1, Rank2: 1, Rank3: 1, Rank4: 1, Rank5: 1}
+
+	tests := []struct {
+		name          string
+		in            RoundInput
+		wantPool      map[Rank]int
+		wantRollDown  map[Rank]int
+		wantRemainder int
+		wantCarry     map[Rank]int
+		wantReserves  map[string]int
+	}{
+		{
+			name: "1등 초과분을 받은 2등이 다시 상한 초과 (비례)",
+			in: RoundInput{
+				Mode:    ModeParimutuel,
+				Sales:   1_000_000,
+				Winners: allWinners,
+				Allocations: []Allocation{
+					{Rank: Rank1, BasisPoints: 6_000},
+					{Rank: Rank2, BasisPoints: 2_000},
+					{Rank: Rank3, BasisPoints: 1_000},
+					{Rank: Rank4, BasisPoints: 1_000},
+				},
+				CapPerRank:   map[Rank]int{Rank1: 400_000, Rank2: 250_000},
+				RoundingUnit: 1,
+			},
+			// 1등 200,000 초과 → 2등 100,000 / 3등 50,000 / 4등 50,000
+			// 2등 300,000 → 50,000 초과 → 3등 25,000 / 4등 25,000
+			wantPool:     map[Rank]int{Rank1: 400_000, Rank2: 250_000, Rank3: 175_000, Rank4: 175_000},
+			wantRollDown: map[Rank]int{Rank1: 200_000, Rank2: 50_000},
+		},
+		{
+			name: "모든 등수가 상한에 걸리면 최하위 초과분은 라운드 잔액 (균등)",
+			in: RoundInput{
+				Mode:    ModeParimutuel,
+				Sales:   100_000,
+				Winners: allWinners,
+				Allocations: []Allocation{
+					{Rank: Rank1, BasisPoints: 10_000},
+				},
+				CapPerRank: map[Rank]int{
+					Rank1: 10_000, Rank2: 10_000, Rank3: 10_000, Rank4: 10_000, Rank5: 10_000,
+				},
+				RoundingUnit:   1,
+				RollDownMethod: RollDownEqual,
+			},
+			wantPool: map[Rank]int{
+				Rank1: 10_000, Rank2: 10_000, Rank3: 10_000, Rank4: 10_000, Rank5: 10_000,
+			},
+			wantRollDown: map[Rank]int{
+				Rank1: 90_000, Rank2: 12_500, Rank3: 16_666, Rank4: 24_999, Rank5: 50_000,
+			},
+			wantRemainder: 50_000,
+		},
+		{
+			name: "1등 초과분을 다음 회차 1등으로 이월",
+			in: RoundInput{
+				Mode:    ModeParimutuel,
+				Sales:   1_000_000,
+				Winners: map[Rank]int{Rank1: 1},
+				Allocations: []Allocation{
+					{Rank: Rank1, BasisPoints: 10_000},
+				},
+				CapPerRank:      map[Rank]int{Rank1: 300_000},
+				RoundingUnit:    1,
+				RollDownTargets: map[Rank]RollDownTarget{Rank1: {Kind: RollDownToNextJackpot}},
+			},
+			wantPool:     map[Rank]int{Rank1: 300_000},
+			wantRollDown: map[Rank]int{Rank1: 700_000},
+			wantCarry:    map[Rank]int{Rank1: 700_000},
+		},
+		{
+			name: "롤다운을 받아 넘친 2등 초과분은 적립금으로",
+			in: RoundInput{
+				Mode:    ModeParimutuel,
+				Sales:   1_000_000,
+				Winners: allWinners,
+				Allocations: []Allocation{
+					{Rank: Rank1, BasisPoints: 8_000},
+					{Rank: Rank2, BasisPoints: 2_000},
+				},
+				CapPerRank:   map[Rank]int{Rank1: 500_000, Rank2: 300_000},
+				RoundingUnit: 1,
+				RollDownTargets: map[Rank]RollDownTarget{
+					Rank2: {Kind: RollDownToReserve, Reserve: "fund"},
+				},
+			},
+			wantPool:     map[Rank]int{Rank1: 500_000, Rank2: 300_000},
+			wantRollDown: map[Rank]int{Rank1: 300_000, Rank2: 200_000},
+			wantReserves: map[string]int{"fund": 200_000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := CalculateRound(tt.in)
+			if err != nil {
+				t.Fatalf("라운드 계산 중 에러가 발생했습니다: %v", err)
+			}
+
+			for _, r := range []Rank{Rank1, Rank2, Rank3, Rank4, Rank5} {
+				if got := out.PoolAfterCap[r]; got != tt.wantPool[r] {
+					t.Errorf("%d등 풀(후) 값이 예상과 다릅니다. got=%d, want=%d", r.Number(), got, tt.wantPool[r])
+				}
+				if got := out.RollDown[r]; got != tt.wantRollDown[r] {
+					t.Errorf("%d등 롤다운 값이 예상과 다릅니다. got=%d, want=%d", r.Number(), got, tt.wantRollDown[r])
+				}
+				if got := out.CarryOut[r]; got != tt.wantCarry[r] {
+					t.Errorf("%d등 이월 금액이 예상과 다릅니다. got=%d, want=%d", r.Number(), got, tt.wantCarry[r])
+				}
+			}
+
+			if out.RoundRemainder != tt.wantRemainder {
+				t.Errorf("라운드 잔액이 예상과 다릅니다. got=%d, want=%d", out.RoundRemainder, tt.wantRemainder)
+			}
+
+			if len(out.Reserves) != len(tt.wantReserves) {
+				t.Fatalf("적립금 항목 수가 예상과 다릅니다. got=%v, want=%v", out.Reserves, tt.wantReserves)
+			}
+			for name, want := range tt.wantReserves {
+				if got := out.Reserves[name]; got != want {
+					t.Errorf("적립금 %q 금액이 예상과 다릅니다. got=%d, want=%d", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// 잘못된 롤다운 대상은 에러 처리
+func TestCalculateRound_InvalidRollDownTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets map[Rank]RollDownTarget
+	}{
+		{"적립금 이름 누락", map[Rank]RollDownTarget{Rank1: {Kind: RollDownToReserve}}},
+		{"알 수 없는 대상 종류", map[Rank]RollDownTarget{Rank2: {Kind: RollDownTargetKind(9)}}},
+		{"등수 범위 밖", map[Rank]RollDownTarget{RankNone: {Kind: RollDownToLower}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := RoundInput{
+				Mode:            ModeParimutuel,
+				Sales:           1_000,
+				RollDownTargets: tt.targets,
+			}
+			if _, err := CalculateRound(in); !errors.Is(err, ErrInvalidRollDown) {
+				t.Fatalf("롤다운 대상 에러가 발생해야 합니다. got=%v", err)
+			}
+		})
+	}
+}
@@ -4,9 +4,15 @@ import "fmt"
 
 // 여러 회차를 공통 규칙으로 돌리기 위한 설정
 type SeriesConfig struct {
-	Mode        Mode
-	Allocations []Allocation
-	CapPerRank  map[Rank]int
+	Mode        Mode         `json:"mode"`
+	Allocations []Allocation `json:"allocations"`
+	CapPerRank  map[Rank]int `json:"capPerRank"`
+
+	RoundingUnit    int                     `json:"roundingUnit"`
+	RollDownMethod  RollDownMethod          `json:"rollDownMethod"`
+	RollDownTargets map[Rank]RollDownTarget `json:"rollDownTargets"` // 등수별 상한 초과분 경로
+
+	Calendar *DrawCalendar `json:"-"` // 있으면 회차별 메타데이터(회차 번호, 추첨 일시) 부여
 }
 
 // 여러 회차에 대해 라운드 로직 순차 실행 -> 각 회차 결과 반환
@@ -28,14 +34,7 @@ func SimulateSeries(
 	carry := cloneRankIntMap(carryIn)
 
 	for i := 0; i < len(salesPerRound); i++ {
-		input := RoundInput{
-			Mode:        cfg.Mode,
-			Sales:       salesPerRound[i],
-			Winners:     winnersPerRound[i],
-			CarryIn:     cloneRankIntMap(carry),
-			Allocations: cfg.Allocations,
-			CapPerRank:  cfg.CapPerRank,
-		}
+		input := cfg.roundInput(cfg.roundMeta(i), salesPerRound[i], winnersPerRound[i], carry)
 
 		out, err := CalculateRound(input)
 		if err != nil { // 실패하면 리턴
@@ -51,6 +50,34 @@ func SimulateSeries(
 	return results, nil
 }
 
+func (cfg SeriesConfig) roundInput(
+	meta RoundMeta,
+	sales int,
+	winners map[Rank]int,
+	carry map[Rank]int,
+) RoundInput {
+	return RoundInput{
+		Meta:        meta,
+		Mode:        cfg.Mode,
+		Sales:       sales,
+		Winners:     winners,
+		CarryIn:     cloneRankIntMap(carry),
+		Allocations: cfg.Allocations,
+		CapPerRank:  cfg.CapPerRank,
+
+		RoundingUnit:    cfg.RoundingUnit,
+		RollDownMethod:  cfg.RollDownMethod,
+		RollDownTargets: cfg.RollDownTargets,
+	}
+}
+
+func (cfg SeriesConfig) roundMeta(idx int) RoundMeta {
+	if cfg.Calendar == nil {
+		return RoundMeta{Sequence: idx + 1}
+	}
+	return cfg.Calendar.Round(idx)
+}
+
 // 맵 복사 -> 참조 공유 방지
 func cloneRankIntMap(src map[Rank]int) map[Rank]int {
 	dst := make(map[Rank]int, len(src))
@@ -0,0 +1,336 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+)
+
+var (
+	ErrSimulationNotFound = errors.New("등록되지 않은 시뮬레이션입니다")
+	ErrPlayerNotFound     = errors.New("등록되지 않은 플레이어입니다")
+	ErrRoundNotFound      = errors.New("존재하지 않는 회차입니다")
+	ErrDuplicatePlayer    = errors.New("이미 등록된 플레이어입니다")
+	ErrInvalidPlayer      = errors.New("플레이어 이름이 비어 있습니다")
+	ErrInvalidOrder       = errors.New("티켓 구매 요청이 올바르지 않습니다")
+	ErrInvalidDraw        = errors.New("추첨 번호가 올바르지 않습니다")
+	ErrNoTickets          = errors.New("이번 회차에 구매한 티켓이 없습니다")
+	ErrInvalidDrawSource  = errors.New("지원하지 않는 추첨 방식입니다")
+)
+
+// 추첨 번호를 정하는 방법
+type DrawSource string
+
+const (
+	DrawRandom       DrawSource = "random"        // 추첨할 때 무작위 (번호를 직접 정할 수도 있다)
+	DrawCommitReveal DrawSource = "commit-reveal" // 판매 전에 커밋값을 공개하고 추첨 때 시드를 공개
+)
+
+// 플레이어를 등록하고, 회차마다 티켓을 사고 추첨하는 시뮬레이션
+// 티켓은 다음 추첨 한 번에만 참여하고 추첨이 끝나면 비운다
+type Simulation struct {
+	ID        string             `json:"id"`
+	Profile   profile.Profile    `json:"profile"`
+	Calendar  lotto.DrawCalendar `json:"-"`
+	CreatedAt time.Time          `json:"createdAt"`
+	Players   []Player           `json:"players"`
+	Rounds    []Round            `json:"rounds"`
+	CarryOut  map[lotto.Rank]int `json:"carryOut"` // 다음 회차로 넘어갈 이월 금액
+
+	DrawSource     DrawSource           `json:"drawSource"`
+	NextCommitment *commitreveal.Sealed `json:"nextCommitment,omitempty"` // 커밋-공개 추첨에서 다음 회차 커밋값
+}
+
+type Player struct {
+	Name    string        `json:"name"`
+	Tickets []lotto.Lotto `json:"tickets"` // 다음 추첨에 참여할 티켓
+	Spent   int           `json:"spent"`   // 지금까지 구매 금액
+	Won     int           `json:"won"`     // 지금까지 수령액
+}
+
+// 추첨을 마친 회차. 만들어진 뒤에는 바뀌지 않는다
+type Round struct {
+	Number  int                `json:"number"` // 시뮬레이션 안에서 1부터
+	Draw    lotto.Draw         `json:"draw"`
+	Tickets map[string]int     `json:"tickets"` // 플레이어별 참여 티켓 수
+	Sales   int                `json:"sales"`
+	Winners map[lotto.Rank]int `json:"winners"`
+	Output  lotto.RoundOutput  `json:"output"`
+	Payouts map[string]int     `json:"payouts"` // 플레이어별 수령액
+
+	Proof *commitreveal.Proof `json:"proof,omitempty"` // 커밋-공개 추첨이면 검증에 쓸 시드와 커밋값
+}
+
+// 티켓 구매 요청. 자동(Amount)과 수동(Numbers)을 함께 보낼 수 있다
+type Order struct {
+	Amount  int     `json:"amount"`  // 자동 구매 금액 (장당 가격 단위)
+	Numbers [][]int `json:"numbers"` // 직접 고른 번호
+}
+
+func New(game profile.Profile, calendar lotto.DrawCalendar) *Simulation {
+	return &Simulation{
+		Profile:    game,
+		Calendar:   calendar,
+		CreatedAt:  time.Now(),
+		CarryOut:   map[lotto.Rank]int{},
+		DrawSource: DrawRandom,
+	}
+}
+
+// 추첨 방식을 정한다. 커밋-공개면 다음 회차 커밋값을 바로 만든다
+// 이미 산 티켓이 있으면 그 판매는 커밋 전에 이뤄졌으므로 바꿀 수 없다
+func (s *Simulation) UseDrawSource(source DrawSource) error {
+	if s.PendingTickets() > 0 {
+		return fmt.Errorf("%w: 티켓을 사기 전에 정해야 합니다", ErrInvalidDrawSource)
+	}
+
+	switch source {
+	case "", DrawRandom:
+		s.DrawSource, s.NextCommitment = DrawRandom, nil
+	case DrawCommitReveal:
+		sealed, err := commitreveal.Seal(s.NextRound().ID())
+		if err != nil {
+			return err
+		}
+		s.DrawSource, s.NextCommitment = DrawCommitReveal, sealed
+	default:
+		return fmt.Errorf("%w: %q (%s, %s)", ErrInvalidDrawSource, source, DrawRandom, DrawCommitReveal)
+	}
+	return nil
+}
+
+func (s *Simulation) AddPlayer(name string) (Player, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Player{}, ErrInvalidPlayer
+	}
+	if _, err := s.player(name); err == nil {
+		return Player{}, fmt.Errorf("%w: %s", ErrDuplicatePlayer, name)
+	}
+
+	s.Players = append(s.Players, Player{Name: name})
+	return s.Players[len(s.Players)-1], nil
+}
+
+func (s *Simulation) Player(name string) (Player, error) {
+	p, err := s.player(name)
+	if err != nil {
+		return Player{}, err
+	}
+	return *p, nil
+}
+
+func (s *Simulation) player(name string) (*Player, error) {
+	for i := range s.Players {
+		if s.Players[i].Name == name {
+			return &s.Players[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrPlayerNotFound, name)
+}
+
+// 다음 회차 티켓을 구매하고 이번에 산 티켓을 돌려준다
+func (s *Simulation) BuyTickets(name string, order Order) ([]lotto.Lotto, error) {
+	p, err := s.player(name)
+	if err != nil {
+		return nil, err
+	}
+	if order.Amount == 0 && len(order.Numbers) == 0 {
+		return nil, fmt.Errorf("%w: 구매 금액이나 번호를 입력해 주세요", ErrInvalidOrder)
+	}
+
+	var bought []lotto.Lotto
+	for _, numbers := range order.Numbers {
+		t, err := lotto.NewLotto(numbers)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidOrder, err)
+		}
+		bought = append(bought, t)
+	}
+	if order.Amount != 0 {
+		auto, err := lotto.PurchaseLottos(order.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidOrder, err)
+		}
+		bought = append(bought, auto.Lottos...)
+	}
+
+	p.Tickets = append(p.Tickets, bought...)
+	p.Spent += len(bought) * lotto.LottoPrice
+	return bought, nil
+}
+
+// 다음 회차 메타데이터 (달력 기준)
+func (s *Simulation) NextRound() lotto.RoundMeta {
+	return s.Calendar.Round(len(s.Rounds))
+}
+
+func (s *Simulation) PendingTickets() int {
+	total := 0
+	for _, p := range s.Players {
+		total += len(p.Tickets)
+	}
+	return total
+}
+
+// 구매한 티켓으로 다음 회차를 추첨하고 정산한다
+// numbers가 nil이면 무작위로 뽑는다. 커밋-공개 추첨이면 시드를 공개해 번호를 정하고 다음 회차 커밋값을 만든다
+func (s *Simulation) Draw(numbers *lotto.Draw) (Round, error) {
+	if s.PendingTickets() == 0 {
+		return Round{}, ErrNoTickets
+	}
+
+	meta := s.NextRound()
+	draw := lotto.RandomDraw(lotto.DefaultSource, meta)
+	var proof *commitreveal.Proof
+	var next *commitreveal.Sealed
+	switch {
+	case s.DrawSource == DrawCommitReveal:
+		if numbers != nil {
+			return Round{}, fmt.Errorf("%w: 커밋-공개 추첨은 번호를 직접 정할 수 없습니다", ErrInvalidDraw)
+		}
+		var err error
+		if next, err = commitreveal.Seal(s.Calendar.Round(len(s.Rounds) + 1).ID()); err != nil {
+			return Round{}, err
+		}
+		revealed, p := s.NextCommitment.Reveal()
+		draw = lotto.Draw{Meta: meta, WinningNumbers: revealed.WinningNumbers, BonusNumber: revealed.BonusNumber}
+		proof = &p
+	case numbers != nil:
+		var err error
+		if draw, err = validateDraw(*numbers, meta); err != nil {
+			return Round{}, err
+		}
+	}
+
+	players := make([]lotto.Player, 0, len(s.Players))
+	tickets := make(map[string]int, len(s.Players))
+	for _, p := range s.Players {
+		players = append(players, lotto.Player{Name: p.Name, Tickets: p.Tickets})
+		tickets[p.Name] = len(p.Tickets)
+	}
+
+	winning := draw.Lottos(nil)
+	sales := s.PendingTickets() * lotto.LottoPrice
+	in := s.Profile.RoundInput(sales, lotto.CountWinnersFromPlayers(players, winning), s.CarryOut)
+	in.Meta = meta
+
+	out, err := lotto.CalculateRound(in)
+	if err != nil {
+		return Round{}, err
+	}
+	payouts := lotto.DistributeRewardsParallel(players, winning, out)
+
+	for i := range s.Players {
+		s.Players[i].Won += payouts[s.Players[i].Name]
+		s.Players[i].Tickets = nil
+	}
+	s.CarryOut = maps.Clone(out.CarryOut)
+	if next != nil {
+		s.NextCommitment = next
+	}
+
+	round := Round{
+		Number:  len(s.Rounds) + 1,
+		Draw:    draw,
+		Tickets: tickets,
+		Sales:   sales,
+		Winners: in.Winners,
+		Output:  out,
+		Payouts: payouts,
+		Proof:   proof,
+	}
+	s.Rounds = append(s.Rounds, round)
+	return round, nil
+}
+
+// 기존 번호 검증 규칙(lotto.Lottos.SetWinningNumbers/SetBonusNumber)으로 검증
+func validateDraw(d lotto.Draw, meta lotto.RoundMeta) (lotto.Draw, error) {
+	parts := make([]string, len(d.WinningNumbers))
+	for i, n := range d.WinningNumbers {
+		parts[i] = strconv.Itoa(n)
+	}
+
+	var winning lotto.Lottos
+	if err := winning.SetWinningNumbers(strings.Join(parts, ",")); err != nil {
+		return lotto.Draw{}, fmt.Errorf("%w: %w", ErrInvalidDraw, err)
+	}
+	if err := winning.SetBonusNumber(strconv.Itoa(d.BonusNumber)); err != nil {
+		return lotto.Draw{}, fmt.Errorf("%w: %w", ErrInvalidDraw, err)
+	}
+	return lotto.Draw{Meta: meta, WinningNumbers: winning.WinningNumbers, BonusNumber: winning.BonusNumber}, nil
+}
+
+// number번째(1부터) 회차
+func (s *Simulation) Round(number int) (Round, error) {
+	if number < 1 || number > len(s.Rounds) {
+		return Round{}, fmt.Errorf("%w: %d", ErrRoundNotFound, number)
+	}
+	return s.Rounds[number-1], nil
+}
+
+// 플레이어별 구매/수령 합계
+type PlayerSettlement struct {
+	Name           string  `json:"name"`
+	Spent          int     `json:"spent"`
+	Won            int     `json:"won"`
+	Net            int     `json:"net"`
+	ReturnRate     float64 `json:"returnRate"`     // 수령액 / 구매 금액
+	PendingTickets int     `json:"pendingTickets"` // 아직 추첨하지 않은 티켓
+}
+
+type Settlement struct {
+	Rounds   int                `json:"rounds"`
+	Sales    int                `json:"sales"`    // 추첨을 마친 회차의 판매액 합계
+	Paid     int                `json:"paid"`     // 지급액 합계
+	CarryOut map[lotto.Rank]int `json:"carryOut"` // 다음 회차로 넘어갈 이월 금액
+	Players  []PlayerSettlement `json:"players"`
+}
+
+func (s *Simulation) Settlement() Settlement {
+	st := Settlement{Rounds: len(s.Rounds), CarryOut: s.CarryOut, Players: make([]PlayerSettlement, 0, len(s.Players))}
+	for _, r := range s.Rounds {
+		st.Sales += r.Sales
+		for _, paid := range r.Payouts {
+			st.Paid += paid
+		}
+	}
+
+	for _, p := range s.Players {
+		ps := PlayerSettlement{
+			Name:           p.Name,
+			Spent:          p.Spent,
+			Won:            p.Won,
+			Net:            p.Won - p.Spent,
+			PendingTickets: len(p.Tickets),
+		}
+		if p.Spent > 0 {
+			ps.ReturnRate = float64(p.Won) / float64(p.Spent)
+		}
+		st.Players = append(st.Players, ps)
+	}
+	return st
+}
+
+// 저장소가 내부 상태를 공유하지 않도록 쓰는 복사본
+// 티켓과 회차는 덧붙이거나 통째로 비우기만 하고 원소를 바꾸지 않으므로 배열을 공유한다
+// 용량을 길이로 잘라 두어 복사본에 덧붙이면 새 배열을 쓴다 (티켓 수와 무관하게 플레이어 수만큼만 복사)
+func (s *Simulation) clone() *Simulation {
+	c := *s
+	c.Players = make([]Player, len(s.Players))
+	for i, p := range s.Players {
+		p.Tickets = slices.Clip(p.Tickets)
+		c.Players[i] = p
+	}
+	c.Rounds = slices.Clip(s.Rounds)
+	c.CarryOut = maps.Clone(s.CarryOut)
+	return &c
+}
@@ -0,0 +1,284 @@
+package session
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+)
+
+func newFixedSimulation(t *testing.T) *Simulation {
+	t.Helper()
+	game, err := profile.NewRegistry().Get(profile.KR645Fixed)
+	if err != nil {
+		t.Fatalf("프로필을 불러오지 못했습니다: %v", err)
+	}
+	return New(game, lotto.WeeklyCalendar(lotto.DefaultGameID, 1000, time.Time{}))
+}
+
+func TestAddPlayer(t *testing.T) {
+	sim := newFixedSimulation(t)
+	if _, err := sim.AddPlayer("철수"); err != nil {
+		t.Fatalf("플레이어 등록 중 에러가 발생했습니다: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want error
+	}{
+		{"철수", ErrDuplicatePlayer},
+		{" 철수 ", ErrDuplicatePlayer},
+		{"", ErrInvalidPlayer},
+		{"   ", ErrInvalidPlayer},
+	}
+	for _, tt := range tests {
+		if _, err := sim.AddPlayer(tt.name); !errors.Is(err, tt.want) {
+			t.Errorf("%q 등록 에러가 예상과 다릅니다. got=%v, want=%v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestBuyTickets(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   Order
+		tickets int
+		want    error
+	}{
+		{"자동", Order{Amount: 3000}, 3, nil},
+		{"수동+자동", Order{Amount: 1000, Numbers: [][]int{{1, 2, 3, 4, 5, 6}}}, 2, nil},
+		{"빈 주문", Order{}, 0, ErrInvalidOrder},
+		{"단위가 맞지 않는 금액", Order{Amount: 1500}, 0, ErrInvalidOrder},
+		{"중복 번호", Order{Numbers: [][]int{{1, 1, 2, 3, 4, 5}}}, 0, ErrInvalidOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := newFixedSimulation(t)
+			_, _ = sim.AddPlayer("철수")
+
+			bought, err := sim.BuyTickets("철수", tt.order)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("구매 에러가 예상과 다릅니다. got=%v, want=%v", err, tt.want)
+			}
+			p, _ := sim.Player("철수")
+			if len(bought) != tt.tickets || len(p.Tickets) != tt.tickets || p.Spent != tt.tickets*lotto.LottoPrice {
+				t.Errorf("구매 결과가 예상과 다릅니다. bought=%d, player=%+v, want=%d장", len(bought), p, tt.tickets)
+			}
+		})
+	}
+
+	sim := newFixedSimulation(t)
+	if _, err := sim.BuyTickets("영희", Order{Amount: 1000}); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("없는 플레이어는 ErrPlayerNotFound여야 합니다. got=%v", err)
+	}
+}
+
+func TestDrawAndSettlement(t *testing.T) {
+	sim := newFixedSimulation(t)
+	_, _ = sim.AddPlayer("철수")
+	_, _ = sim.AddPlayer("영희")
+
+	if _, err := sim.Draw(nil); !errors.Is(err, ErrNoTickets) {
+		t.Fatalf("티켓 없이 추첨하면 ErrNoTickets여야 합니다. got=%v", err)
+	}
+
+	_, _ = sim.BuyTickets("철수", Order{Numbers: [][]int{{1, 2, 3, 4, 5, 6}, {1, 2, 3, 40, 41, 42}}})
+	_, _ = sim.BuyTickets("영희", Order{Numbers: [][]int{{10, 11, 12, 13, 14, 15}}})
+
+	if _, err := sim.Draw(&lotto.Draw{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 6}); !errors.Is(err, ErrInvalidDraw) {
+		t.Fatalf("보너스 번호가 겹치면 ErrInvalidDraw여야 합니다. got=%v", err)
+	}
+
+	round, err := sim.Draw(&lotto.Draw{WinningNumbers: []int{6, 5, 4, 3, 2, 1}, BonusNumber: 7})
+	if err != nil {
+		t.Fatalf("추첨 중 에러가 발생했습니다: %v", err)
+	}
+	if round.Number != 1 || round.Draw.Meta.Sequence != 1000 || round.Sales != 3*lotto.LottoPrice {
+		t.Errorf("회차 정보가 예상과 다릅니다. got number=%d meta=%+v sales=%d", round.Number, round.Draw.Meta, round.Sales)
+	}
+	if round.Winners[lotto.Rank1] != 1 || round.Winners[lotto.Rank5] != 1 {
+		t.Errorf("등수별 당첨자 수가 예상과 다릅니다. got=%v", round.Winners)
+	}
+	if sim.PendingTickets() != 0 {
+		t.Errorf("추첨 후에는 남은 티켓이 없어야 합니다. got=%d", sim.PendingTickets())
+	}
+
+	want := lotto.Rank1.Prize() + lotto.Rank5.Prize()
+	st := sim.Settlement()
+	if st.Rounds != 1 || st.Sales != 3*lotto.LottoPrice || st.Paid != want {
+		t.Errorf("정산 합계가 예상과 다릅니다. got=%+v", st)
+	}
+	if got := st.Players[0]; got.Won != want || got.Spent != 2*lotto.LottoPrice || got.Net != want-2*lotto.LottoPrice {
+		t.Errorf("철수 정산이 예상과 다릅니다. got=%+v", got)
+	}
+	if got := st.Players[1]; got.Won != 0 || got.Net != -lotto.LottoPrice {
+		t.Errorf("영희 정산이 예상과 다릅니다. got=%+v", got)
+	}
+
+	if _, err := sim.Round(2); !errors.Is(err, ErrRoundNotFound) {
+		t.Errorf("없는 회차는 ErrRoundNotFound여야 합니다. got=%v", err)
+	}
+	if got := sim.NextRound().Sequence; got != 1001 {
+		t.Errorf("다음 회차 번호가 예상과 다릅니다. got=%d, want=%d", got, 1001)
+	}
+}
+
+// 커밋-공개 추첨: 판매 전에 공개한 커밋값의 시드로 번호를 정하고 다음 회차 커밋값을 새로 만든다
+func TestDrawCommitReveal(t *testing.T) {
+	sim := newFixedSimulation(t)
+	if err := sim.UseDrawSource(DrawCommitReveal); err != nil {
+		t.Fatalf("추첨 방식 설정 중 에러가 발생했습니다: %v", err)
+	}
+	committed := sim.NextCommitment
+	if committed == nil || committed.Round != sim.NextRound().ID() {
+		t.Fatalf("판매 전에 다음 회차 커밋값이 있어야 합니다. got=%+v", committed)
+	}
+
+	sim.AddPlayer("철수")
+	if _, err := sim.BuyTickets("철수", Order{Amount: 1_000}); err != nil {
+		t.Fatalf("구매 중 에러가 발생했습니다: %v", err)
+	}
+	if err := sim.UseDrawSource(DrawRandom); !errors.Is(err, ErrInvalidDrawSource) {
+		t.Errorf("티켓을 산 뒤에는 추첨 방식을 바꿀 수 없어야 합니다. got=%v", err)
+	}
+	if _, err := sim.Draw(&lotto.Draw{WinningNumbers: []int{1, 2, 3, 4, 5, 6}, BonusNumber: 7}); !errors.Is(err, ErrInvalidDraw) {
+		t.Errorf("커밋-공개 추첨에서 번호를 정하면 ErrInvalidDraw여야 합니다. got=%v", err)
+	}
+
+	round, err := sim.Draw(nil)
+	if err != nil {
+		t.Fatalf("추첨 중 에러가 발생했습니다: %v", err)
+	}
+	if round.Proof == nil || round.Proof.Commitment != committed.Commitment {
+		t.Fatalf("회차에 판매 전 커밋값의 증명이 있어야 합니다. got=%+v", round.Proof)
+	}
+
+	v, err := commitreveal.Verify(commitreveal.VerifyRequest{
+		Round:          round.Proof.Round,
+		Commitment:     round.Proof.Commitment,
+		Seed:           round.Proof.Seed,
+		WinningNumbers: round.Draw.WinningNumbers,
+		BonusNumber:    round.Draw.BonusNumber,
+	})
+	if err != nil || !v.Valid {
+		t.Errorf("공개한 번호가 검증을 통과해야 합니다. got=%+v, err=%v", v, err)
+	}
+
+	if sim.NextCommitment == committed || sim.NextCommitment.Round != sim.NextRound().ID() {
+		t.Errorf("다음 회차 커밋값을 새로 만들어야 합니다. got=%+v", sim.NextCommitment)
+	}
+	if err := sim.UseDrawSource("magic"); !errors.Is(err, ErrInvalidDrawSource) {
+		t.Errorf("없는 추첨 방식은 ErrInvalidDrawSource여야 합니다. got=%v", err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	for range 3 {
+		if _, err := store.Create(newFixedSimulation(t)); err != nil {
+			t.Fatalf("저장 중 에러가 발생했습니다: %v", err)
+		}
+	}
+
+	page, total, _ := store.List(1, 5)
+	if total != 3 || len(page) != 2 || page[0].ID != "2" {
+		t.Errorf("목록 조회 결과가 예상과 다릅니다. total=%d, len=%d", total, len(page))
+	}
+
+	// 실패한 변경은 저장되지 않는다
+	_, err := store.Update("1", func(sim *Simulation) error {
+		_, _ = sim.AddPlayer("철수")
+		return ErrNoTickets
+	})
+	if !errors.Is(err, ErrNoTickets) {
+		t.Fatalf("변경 함수의 에러를 그대로 돌려줘야 합니다. got=%v", err)
+	}
+	if sim, _ := store.Get("1"); len(sim.Players) != 0 {
+		t.Errorf("실패한 변경이 저장되었습니다. got=%+v", sim.Players)
+	}
+
+	// 돌려받은 복사본을 바꿔도 저장된 상태는 그대로다
+	sim, _ := store.Update("1", func(sim *Simulation) error {
+		_, err := sim.AddPlayer("철수")
+		return err
+	})
+	sim.Players[0].Name = "바뀐 이름"
+	if stored, _ := store.Get("1"); stored.Players[0].Name != "철수" {
+		t.Errorf("복사본 변경이 저장소에 반영되었습니다. got=%+v", stored.Players)
+	}
+
+	if _, err := store.Get("없음"); !errors.Is(err, ErrSimulationNotFound) {
+		t.Errorf("없는 시뮬레이션은 ErrSimulationNotFound여야 합니다. got=%v", err)
+	}
+}
+
+// 최대 개수를 넘으면 가장 오래된 시뮬레이션부터 지우는지 검증
+func TestMemoryStoreEviction(t *testing.T) {
+	store := NewMemoryStore()
+	for range MaxSimulations + 2 {
+		if _, err := store.Create(newFixedSimulation(t)); err != nil {
+			t.Fatalf("저장 중 에러가 발생했습니다: %v", err)
+		}
+	}
+
+	for _, id := range []string{"1", "2"} {
+		if _, err := store.Get(id); !errors.Is(err, ErrSimulationNotFound) {
+			t.Errorf("지운 시뮬레이션 %s는 ErrSimulationNotFound여야 합니다. got=%v", id, err)
+		}
+		if _, err := store.Update(id, func(*Simulation) error { return nil }); !errors.Is(err, ErrSimulationNotFound) {
+			t.Errorf("지운 시뮬레이션 %s는 바꿀 수 없어야 합니다. got=%v", id, err)
+		}
+	}
+
+	page, total, _ := store.List(0, 1)
+	if total != MaxSimulations || len(page) != 1 || page[0].ID != "3" {
+		t.Errorf("남은 목록이 예상과 다릅니다. total=%d, first=%+v", total, page)
+	}
+	if _, err := store.Get(strconv.Itoa(MaxSimulations + 2)); err != nil {
+		t.Errorf("최근 시뮬레이션은 남아 있어야 합니다. err=%v", err)
+	}
+}
+
+// 복사본에 티켓을 사도 저장된 티켓이나 먼저 돌려받은 복사본은 바뀌지 않는지 검증
+func TestMemoryStoreSharedTickets(t *testing.T) {
+	store := NewMemoryStore()
+	sim, _ := store.Create(newFixedSimulation(t))
+	buy := func(numbers []int) (*Simulation, error) {
+		return store.Update(sim.ID, func(sim *Simulation) error {
+			_, err := sim.BuyTickets("철수", Order{Numbers: [][]int{numbers}})
+			return err
+		})
+	}
+
+	if _, err := store.Update(sim.ID, func(sim *Simulation) error {
+		_, err := sim.AddPlayer("철수")
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// 덧붙이다 보면 저장된 티켓 배열에 남는 용량이 생긴다 (3장이면 용량 4)
+	var first *Simulation
+	for n := 1; n <= 3; n++ {
+		var err error
+		if first, err = buy([]int{n, 10, 11, 12, 13, 14}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := buy([]int{7, 10, 11, 12, 13, 14}); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = first.BuyTickets("철수", Order{Numbers: [][]int{{40, 41, 42, 43, 44, 45}}})
+
+	stored, _ := store.Get(sim.ID)
+	if got := stored.Players[0].Tickets; len(got) != 4 || got[3].Numbers[0] != 7 {
+		t.Errorf("저장된 티켓이 예상과 다릅니다. got=%v", got)
+	}
+	if got := first.Players[0].Tickets; len(got) != 4 || got[3].Numbers[0] != 40 {
+		t.Errorf("복사본의 티켓이 예상과 다릅니다. got=%v", got)
+	}
+}
@@ -0,0 +1,95 @@
+package session
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// 시뮬레이션 저장소. 지금은 메모리 구현만 있지만 영속 저장소로 바꿀 수 있도록 인터페이스로 둔다
+// 모든 메서드는 복사본을 주고받아 호출자가 저장된 상태를 직접 바꿀 수 없다
+// (티켓과 회차 원소는 복사본끼리 공유하므로 덧붙이기만 하고 고쳐 쓰지 않는다)
+type Store interface {
+	// ID를 부여해 저장하고 저장된 시뮬레이션을 돌려준다
+	Create(sim *Simulation) (*Simulation, error)
+	Get(id string) (*Simulation, error)
+	// 생성 순서로 offset부터 limit개와 전체 개수
+	List(offset, limit int) ([]*Simulation, int, error)
+	// fn이 에러 없이 끝나야 변경 내용을 저장한다 (한 시뮬레이션의 변경은 순서대로 처리)
+	Update(id string, fn func(*Simulation) error) (*Simulation, error)
+}
+
+// MemoryStore가 보관하는 최대 시뮬레이션 수. 넘치면 오래된 것부터 지운다
+const MaxSimulations = 1_000
+
+// 프로세스 메모리에 보관하는 저장소 (서버를 재시작하면 사라진다)
+// 지운 시뮬레이션은 ErrSimulationNotFound가 되고, ID는 다시 쓰지 않는다
+type MemoryStore struct {
+	mu    sync.RWMutex
+	sims  map[string]*Simulation
+	order []string // 생성 순서 (오래된 것부터 지우기 위함)
+	seq   int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sims: make(map[string]*Simulation)}
+}
+
+func (m *MemoryStore) Create(sim *Simulation) (*Simulation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.seq++
+	stored := sim.clone()
+	stored.ID = strconv.Itoa(m.seq)
+	m.sims[stored.ID] = stored
+	m.order = append(m.order, stored.ID)
+	if len(m.order) > MaxSimulations {
+		delete(m.sims, m.order[0])
+		m.order = m.order[1:]
+	}
+	return stored.clone(), nil
+}
+
+func (m *MemoryStore) Get(id string) (*Simulation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sim, ok := m.sims[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, id)
+	}
+	return sim.clone(), nil
+}
+
+func (m *MemoryStore) List(offset, limit int) ([]*Simulation, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	total := len(m.order)
+	start := min(max(offset, 0), total)
+	end := min(start+max(limit, 0), total)
+
+	sims := make([]*Simulation, 0, end-start)
+	for _, id := range m.order[start:end] {
+		sims = append(sims, m.sims[id].clone())
+	}
+	return sims, total, nil
+}
+
+func (m *MemoryStore) Update(id string, fn func(*Simulation) error) (*Simulation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sim, ok := m.sims[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, id)
+	}
+
+	updated := sim.clone()
+	if err := fn(updated); err != nil {
+		return nil, err
+	}
+	m.sims[id] = updated
+	return updated.clone(), nil
+}
@@ -40,6 +40,7 @@ func BuildRoundInput(base RoundInput, players []Player, winning Lottos) RoundInp
 }
 
 // 플레이어에게 지급액 분배
+// 정산마다 한 번 부르므로 대조한 티켓 수 지표는 여기서만 센다 (당첨자 집계는 세지 않는다)
 func DistributeRewards(players []Player, winning Lottos, out RoundOutput) map[string]int {
 	rewards := make(map[string]int)
 
@@ -60,5 +61,6 @@ func calculatePlayerReward(tickets []Lotto, playerName string, winning Lottos, o
 			total += perWin
 		}
 	}
+	ticketsEvaluated.Add(float64(len(tickets)))
 	return total
 }
@@ -0,0 +1,261 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+)
+
+// 전략대로 구매하는 가상 플레이어
+type Bettor struct {
+	Name     string
+	Group    string
+	Strategy Strategy
+}
+
+// 묶음마다 인원수만큼 플레이어 생성 (이름은 묶음 이름-번호)
+func NewPopulation(groups []Group) []Bettor {
+	var bettors []Bettor
+	for _, g := range groups {
+		for i := range g.Count {
+			bettors = append(bettors, Bettor{
+				Name:     fmt.Sprintf("%s-%d", g.Name, i+1),
+				Group:    g.Name,
+				Strategy: g.Strategy,
+			})
+		}
+	}
+	return bettors
+}
+
+// 인구 시뮬레이션 조건
+type Simulation struct {
+	Game     profile.Profile
+	Rounds   int
+	Draws    []lotto.Draw        // 있으면 순서대로 사용하고, 모자라면 무작위 추첨
+	Market   *lotto.Market       // 플레이어 외 일반 구매자 (없으면 플레이어 구매분만)
+	Calendar *lotto.DrawCalendar // 있으면 회차별 메타데이터 부여
+	Rng      *rand.Rand          // 번호 선택/무작위 추첨용
+}
+
+// 한 회차 요약
+type RoundSummary struct {
+	Draw          lotto.Draw        `json:"draw"`
+	Jackpot       int               `json:"jackpot"` // 구매 전 광고된 1등 금액 추정
+	Buyers        int               `json:"buyers"`
+	Tickets       int               `json:"tickets"` // 플레이어 티켓 수
+	MarketTickets int               `json:"marketTickets"`
+	Output        lotto.RoundOutput `json:"output"`
+}
+
+// 플레이어 한 명의 누적 결과
+type BettorResult struct {
+	Name         string             `json:"name"`
+	Group        string             `json:"group"`
+	Spent        int                `json:"spent"`
+	Won          int                `json:"won"`
+	RoundsPlayed int                `json:"roundsPlayed"`
+	Tickets      int                `json:"tickets"`
+	Wins         map[lotto.Rank]int `json:"wins"` // 등수별 당첨 티켓 수
+}
+
+func (r BettorResult) Net() int { return r.Won - r.Spent }
+
+// 전략 묶음별 누적 결과
+type GroupSummary struct {
+	Group        string             `json:"group"`
+	Bettors      int                `json:"bettors"`
+	Spent        int                `json:"spent"`
+	Won          int                `json:"won"`
+	RoundsPlayed int                `json:"roundsPlayed"` // 구성원이 구매한 회차 수 합계
+	Tickets      int                `json:"tickets"`
+	Wins         map[lotto.Rank]int `json:"wins"`
+	Profitable   int                `json:"profitable"` // 수익을 낸 구성원 수
+}
+
+// 수령액 / 구매 금액 (구매가 없으면 0)
+func (g GroupSummary) ReturnRate() float64 {
+	if g.Spent == 0 {
+		return 0
+	}
+	return float64(g.Won) / float64(g.Spent)
+}
+
+type Report struct {
+	Rounds  []RoundSummary `json:"rounds"`
+	Bettors []BettorResult `json:"bettors"`
+	Groups  []GroupSummary `json:"groups"`
+}
+
+// 회차마다 전략대로 티켓을 사고, 기존 분배/정산 로직으로 당첨금을 나눈다
+// 전략이 보는 1등 금액은 직전 회차 판매액과 이월로 추정한다
+// (첫 회차는 일반 구매자 기본 판매량, 없으면 이월만)
+func Simulate(sim Simulation, bettors []Bettor) (Report, error) {
+	if sim.Rounds <= 0 {
+		return Report{}, fmt.Errorf("회차 수는 1 이상이어야 합니다: %d", sim.Rounds)
+	}
+	if sim.Rng == nil {
+		return Report{}, errors.New("번호 선택에 쓸 난수열이 필요합니다")
+	}
+	// 수령액을 이름으로 나누므로 이름이 겹치면 정산이 섞인다
+	seen := make(map[string]bool, len(bettors))
+	for _, b := range bettors {
+		if seen[b.Name] {
+			return Report{}, fmt.Errorf("플레이어 이름이 중복됩니다: %s", b.Name)
+		}
+		seen[b.Name] = true
+	}
+
+	states := make([]State, len(bettors))
+	results := make([]BettorResult, len(bettors))
+	for i, b := range bettors {
+		results[i] = BettorResult{Name: b.Name, Group: b.Group, Wins: make(map[lotto.Rank]int)}
+	}
+
+	rounds := make([]RoundSummary, 0, sim.Rounds)
+	carry := make(map[lotto.Rank]int)
+	lastSales := 0
+	if sim.Market != nil {
+		lastSales = sim.Market.Demand.Baseline() * lotto.LottoPrice
+	}
+
+	for idx := range sim.Rounds {
+		draw := sim.draw(idx)
+		winning := draw.Lottos(nil)
+		jackpot := advertisedJackpot(sim.Game, lastSales, carry)
+
+		// 전략별 구매
+		bought := make([][]lotto.Lotto, len(bettors))
+		var players []lotto.Player
+		sales := 0
+		for i, b := range bettors {
+			states[i].Round = idx
+			states[i].Jackpot = jackpot
+
+			bought[i] = Buy(b.Strategy, states[i], sim.Rng)
+			if len(bought[i]) == 0 {
+				continue
+			}
+			sales += len(bought[i]) * lotto.LottoPrice
+			players = append(players, lotto.Player{Name: b.Name, Tickets: bought[i]})
+		}
+
+		in := lotto.BuildRoundInput(sim.Game.RoundInput(sales, nil, carry), players, winning)
+		in.Meta = draw.Meta
+
+		marketTickets := 0
+		if sim.Market != nil {
+			in, marketTickets = sim.Market.AddTo(in, idx)
+		}
+
+		out, err := lotto.CalculateRound(in)
+		if err != nil {
+			return Report{}, fmt.Errorf("%s 정산 실패: %w", draw.Meta.Label(), err)
+		}
+		payouts := lotto.DistributeRewardsParallel(players, winning, out)
+
+		for i := range bettors {
+			spent := len(bought[i]) * lotto.LottoPrice
+			won := payouts[bettors[i].Name]
+			states[i].LastSpent, states[i].LastWon = spent, won
+			states[i].LastDraw = draw.WinningNumbers
+			states[i].Spent += spent
+			states[i].Won += won
+
+			results[i].Spent += spent
+			results[i].Won += won
+			if spent > 0 {
+				results[i].RoundsPlayed++
+				results[i].Tickets += len(bought[i])
+				stats := draw.Lottos(bought[i]).CompileStatistics()
+				delete(stats, lotto.RankNone)
+				mergeCounts(results[i].Wins, stats)
+			}
+		}
+
+		rounds = append(rounds, RoundSummary{
+			Draw:          draw,
+			Jackpot:       jackpot,
+			Buyers:        len(players),
+			Tickets:       sales / lotto.LottoPrice,
+			MarketTickets: marketTickets,
+			Output:        out,
+		})
+
+		carry = out.CarryOut
+		lastSales = in.Sales
+	}
+
+	return Report{Rounds: rounds, Bettors: results, Groups: summarize(results)}, nil
+}
+
+// 가져온 추첨 결과가 있으면 그 회차를, 없으면 무작위 추첨
+func (sim Simulation) draw(idx int) lotto.Draw {
+	meta := lotto.RoundMeta{Sequence: idx + 1}
+	if sim.Calendar != nil {
+		meta = sim.Calendar.Round(idx)
+	}
+
+	if idx < len(sim.Draws) {
+		d := sim.Draws[idx]
+		if d.Meta.Sequence == 0 {
+			d.Meta = meta
+		}
+		return d
+	}
+	return lotto.RandomDraw(sim.Rng, meta)
+}
+
+// 고정 모드는 1등 상금, 분배 모드는 판매액 × 1등 배정 비율 + 이월 (상한 적용)
+func advertisedJackpot(game profile.Profile, sales int, carry map[lotto.Rank]int) int {
+	if game.Mode == lotto.ModeFixedPayout {
+		return game.FixedPayout[lotto.Rank1]
+	}
+
+	jackpot := carry[lotto.Rank1]
+	for _, a := range game.Allocations {
+		if a.Rank == lotto.Rank1 {
+			jackpot += sales * a.BasisPoints / lotto.BasisPoints
+		}
+	}
+	if cap1, hasCap := game.CapPerRank[lotto.Rank1]; hasCap {
+		jackpot = min(jackpot, cap1)
+	}
+	return jackpot
+}
+
+// 묶음이 처음 나온 순서대로 합산
+func summarize(results []BettorResult) []GroupSummary {
+	var groups []GroupSummary
+	index := make(map[string]int)
+
+	for _, r := range results {
+		i, exists := index[r.Group]
+		if !exists {
+			i = len(groups)
+			index[r.Group] = i
+			groups = append(groups, GroupSummary{Group: r.Group, Wins: make(map[lotto.Rank]int)})
+		}
+
+		g := &groups[i]
+		g.Bettors++
+		g.Spent += r.Spent
+		g.Won += r.Won
+		g.RoundsPlayed += r.RoundsPlayed
+		g.Tickets += r.Tickets
+		mergeCounts(g.Wins, r.Wins)
+		if r.Net() > 0 {
+			g.Profitable++
+		}
+	}
+	return groups
+}
+
+func mergeCounts(dst, src map[lotto.Rank]int) {
+	for rank, n := range src {
+		dst[rank] += n
+	}
+}
@@ -0,0 +1,126 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/popularity"
+)
+
+var ErrUnknownStrategy = errors.New("지원하지 않는 전략입니다")
+
+// 내장 전략을 만들 때 쓰는 값
+type Params struct {
+	Amount    int   // 회차당 기본 구매 금액
+	Threshold int   // jackpot: 이 금액 이상일 때만 구매
+	MaxAmount int   // martingale: 회차당 상한 (0이면 기본 금액 × MartingaleMaxMultiple)
+	Favorite  []int // favorite: 매 회차 고르는 번호 6개
+}
+
+var budgets = map[string]func(p Params) Budget{
+	"fixed":      func(p Params) Budget { return FixedBudget{Per: p.Amount} },
+	"jackpot":    func(p Params) Budget { return JackpotThreshold{Threshold: p.Threshold, Per: p.Amount} },
+	"martingale": func(p Params) Budget { return Martingale{Base: p.Amount, Max: p.MaxAmount} },
+}
+
+var pickers = map[string]func(p Params) Picker{
+	"auto":          func(Params) Picker { return AllAuto{} },
+	"favorite":      func(p Params) Picker { return FavoriteNumbers{Numbers: p.Favorite} },
+	"avoid-popular": func(Params) Picker { return AvoidPopular{} },
+	"birthday":      func(Params) Picker { return Popular{Model: popularity.Birthday(2)} },
+	"popular":       func(Params) Picker { return Popular{Model: popularity.Realistic()} },
+}
+
+// 같은 전략을 쓰는 플레이어 묶음
+type Group struct {
+	Name     string // "금액규칙/번호규칙"
+	Strategy Strategy
+	Count    int
+}
+
+// "금액규칙[/번호규칙]=인원" 을 쉼표로 이은 구성 해석 (번호규칙 기본값 auto)
+// 예: "fixed=100,jackpot/avoid-popular=50,martingale/favorite=10"
+func ParsePopulation(spec string, p Params) ([]Group, error) {
+	var groups []Group
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, countStr, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("전략 구성은 이름=인원 형식이어야 합니다: %q", entry)
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(countStr))
+		if err != nil || count <= 0 {
+			return nil, fmt.Errorf("인원은 1 이상의 숫자여야 합니다: %q", entry)
+		}
+
+		st, label, err := New(strings.TrimSpace(name), p)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, Group{Name: label, Strategy: st, Count: count})
+	}
+
+	if len(groups) == 0 {
+		return nil, errors.New("전략 구성이 비어 있습니다")
+	}
+	return groups, nil
+}
+
+// "금액규칙[/번호규칙]" 이름으로 내장 전략 생성. 정규화된 이름도 함께 반환
+func New(name string, p Params) (Strategy, string, error) {
+	budgetName, pickerName, found := strings.Cut(name, "/")
+	if !found {
+		pickerName = "auto"
+	}
+
+	newBudget, exists := budgets[budgetName]
+	if !exists {
+		return nil, "", fmt.Errorf("%w: %s (금액 규칙: %s)", ErrUnknownStrategy, budgetName, strings.Join(BudgetNames(), ", "))
+	}
+	newPicker, exists := pickers[pickerName]
+	if !exists {
+		return nil, "", fmt.Errorf("%w: %s (번호 규칙: %s)", ErrUnknownStrategy, pickerName, strings.Join(PickerNames(), ", "))
+	}
+
+	if p.Amount < lotto.LottoPrice {
+		return nil, "", fmt.Errorf("회차당 구매 금액은 %d원 이상이어야 합니다: %d", lotto.LottoPrice, p.Amount)
+	}
+	if budgetName == "martingale" {
+		if p.MaxAmount < 0 {
+			return nil, "", fmt.Errorf("martingale 상한은 0 이상이어야 합니다: %d", p.MaxAmount)
+		}
+		if p.MaxAmount == 0 && p.Amount > math.MaxInt/MartingaleMaxMultiple {
+			return nil, "", fmt.Errorf("martingale 기본 금액이 너무 큽니다: %d", p.Amount)
+		}
+	}
+	if pickerName == "favorite" {
+		if _, err := lotto.NewLotto(p.Favorite); err != nil {
+			return nil, "", fmt.Errorf("favorite 번호가 올바르지 않습니다: %w", err)
+		}
+	}
+
+	st := Combined{Budget: newBudget(p), Picker: newPicker(p)}
+	return st, budgetName + "/" + pickerName, nil
+}
+
+func BudgetNames() []string { return sortedKeys(budgets) }
+func PickerNames() []string { return sortedKeys(pickers) }
+
+func sortedKeys[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
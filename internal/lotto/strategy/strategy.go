@@ -0,0 +1,188 @@
+package strategy
+
+import (
+	"math/rand"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/popularity"
+)
+
+// 전략이 이번 회차 구매를 정할 때 참고하는 상태
+type State struct {
+	Round   int // 0부터 시작하는 회차 순번
+	Jackpot int // 광고되는 1등 금액 추정
+
+	LastSpent int   // 직전 회차 구매 금액 (쉬었으면 0)
+	LastWon   int   // 직전 회차 수령액
+	LastDraw  []int // 직전 회차 당첨 번호 (첫 회차는 비어 있음)
+
+	Spent int // 지금까지 쓴 금액 합계
+	Won   int // 지금까지 받은 금액 합계
+}
+
+// 회차마다 얼마를 쓸지 정하는 규칙
+type Budget interface {
+	Amount(s State) int // 이번 회차 구매 금액 (장당 가격 미만이면 쉼)
+}
+
+// 티켓 번호를 고르는 규칙
+type Picker interface {
+	Pick(s State, ticket int, rng *rand.Rand) []int // 이번 회차 ticket번째 티켓 번호
+}
+
+// 구매 금액 규칙 + 번호 선택 규칙
+type Strategy interface {
+	Budget
+	Picker
+}
+
+// 구매 금액 규칙과 번호 선택 규칙을 묶은 전략
+type Combined struct {
+	Budget
+	Picker
+}
+
+// 전략대로 이번 회차 티켓 구매. 금액은 장당 가격 단위로 내림
+func Buy(st Strategy, s State, rng *rand.Rand) []lotto.Lotto {
+	count := st.Amount(s) / lotto.LottoPrice
+	if count <= 0 {
+		return nil
+	}
+
+	tickets := make([]lotto.Lotto, 0, count)
+	for i := range count {
+		ticket, err := lotto.NewLotto(st.Pick(s, i, rng))
+		if err != nil {
+			// 잘못된 번호를 고르면 그 장은 자동으로 대체
+			ticket = lotto.Lotto{Numbers: lotto.RandomNumbers(rng)}
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets
+}
+
+// 매 회차 같은 금액
+type FixedBudget struct {
+	Per int // 회차당 구매 금액
+}
+
+func (b FixedBudget) Amount(State) int { return b.Per }
+
+// 광고 1등 금액이 Threshold 이상인 회차에만 구매
+type JackpotThreshold struct {
+	Threshold int
+	Per       int
+}
+
+func (b JackpotThreshold) Amount(s State) int {
+	if s.Jackpot < b.Threshold {
+		return 0
+	}
+	return b.Per
+}
+
+// Martingale.Max가 0일 때 쓰는 상한 배수 (기본 금액을 열 번 두 배로 늘린 금액)
+// 연패가 길어져도 한 회차 티켓 수와 금액이 끝없이 커지지 않게 한다
+const MartingaleMaxMultiple = 1 << 10
+
+// 직전 회차에 손해를 보면 금액을 두 배로, 본전 이상이면 기본 금액으로
+type Martingale struct {
+	Base int
+	Max  int // 회차당 상한 (0이면 Base × MartingaleMaxMultiple)
+}
+
+func (b Martingale) Amount(s State) int {
+	if s.LastSpent == 0 || s.LastWon >= s.LastSpent {
+		return b.Base
+	}
+
+	limit := b.Max
+	if limit <= 0 {
+		limit = b.Base * MartingaleMaxMultiple
+	}
+	// 두 배로 늘리기 전에 비교해 int 범위를 넘지 않게
+	if s.LastSpent >= limit/2 {
+		return limit
+	}
+	return s.LastSpent * 2
+}
+
+// 모든 티켓 자동 번호
+type AllAuto struct{}
+
+func (AllAuto) Pick(_ State, _ int, rng *rand.Rand) []int {
+	return lotto.RandomNumbers(rng)
+}
+
+// 첫 티켓은 매 회차 같은 번호, 나머지는 자동
+type FavoriteNumbers struct {
+	Numbers []int
+}
+
+func (p FavoriteNumbers) Pick(_ State, ticket int, rng *rand.Rand) []int {
+	if ticket == 0 && len(p.Numbers) == lotto.LottoSize {
+		return p.Numbers
+	}
+	return lotto.RandomNumbers(rng)
+}
+
+// 번호 선호 모델대로 고른다 (생일 번호, 흔한 패턴, 직전 당첨 번호 등)
+type Popular struct {
+	Model popularity.Model
+}
+
+func (p Popular) Pick(s State, _ int, rng *rand.Rand) []int {
+	return p.Model.WithPrevious(s.LastDraw).Ticket(rng)
+}
+
+// 많은 사람이 고르는 패턴을 피해 자동 번호를 다시 뽑는다
+// 같은 번호에 당첨자가 몰리면 1인당 당첨금이 줄어드는 것을 피하려는 전략
+type AvoidPopular struct{}
+
+// 다시 뽑는 최대 횟수 (넘으면 마지막 번호 사용)
+const maxRedraws = 100
+
+func (AvoidPopular) Pick(_ State, _ int, rng *rand.Rand) []int {
+	numbers := lotto.RandomNumbers(rng)
+	for range maxRedraws {
+		if !IsPopularPattern(numbers) {
+			break
+		}
+		numbers = lotto.RandomNumbers(rng)
+	}
+	return numbers
+}
+
+// 오름차순 번호가 흔히 고르는 패턴인지
+// 생일처럼 모두 31 이하, 연속 번호 3개 이상, 등차수열
+func IsPopularPattern(numbers []int) bool {
+	if len(numbers) < 2 {
+		return false
+	}
+
+	allDates := true
+	run, longest := 1, 1
+	step := numbers[1] - numbers[0]
+	arithmetic := true
+
+	for i, n := range numbers {
+		if n > 31 {
+			allDates = false
+		}
+		if i == 0 {
+			continue
+		}
+
+		if n == numbers[i-1]+1 {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 1
+		}
+		if n-numbers[i-1] != step {
+			arithmetic = false
+		}
+	}
+
+	return allDates || longest >= 3 || arithmetic
+}
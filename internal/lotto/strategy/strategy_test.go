@@ -0,0 +1,207 @@
+package strategy
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/popularity"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
+)
+
+func TestBudgets(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget Budget
+		state  State
+		want   int
+	}{
+		{"고정 금액", FixedBudget{Per: 5000}, State{}, 5000},
+		{"1등 금액 미달이면 쉼", JackpotThreshold{Threshold: 1_000_000, Per: 3000}, State{Jackpot: 999_999}, 0},
+		{"1등 금액 이상이면 구매", JackpotThreshold{Threshold: 1_000_000, Per: 3000}, State{Jackpot: 1_000_000}, 3000},
+		{"마틴게일 첫 회차", Martingale{Base: 1000, Max: 8000}, State{}, 1000},
+		{"마틴게일 손해 후 두 배", Martingale{Base: 1000, Max: 8000}, State{LastSpent: 2000, LastWon: 0}, 4000},
+		{"마틴게일 상한", Martingale{Base: 1000, Max: 8000}, State{LastSpent: 8000}, 8000},
+		{"마틴게일 본전 이상이면 초기화", Martingale{Base: 1000}, State{LastSpent: 4000, LastWon: 5000}, 1000},
+		{"마틴게일 상한 없으면 기본 상한", Martingale{Base: 1000}, State{LastSpent: 1000 * MartingaleMaxMultiple}, 1000 * MartingaleMaxMultiple},
+		{"마틴게일 int 범위 상한", Martingale{Base: 1000, Max: math.MaxInt}, State{LastSpent: math.MaxInt - 1}, math.MaxInt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.budget.Amount(tt.state); got != tt.want {
+				t.Errorf("구매 금액이 예상과 다릅니다. got=%d, want=%d", got, tt.want)
+			}
+		})
+	}
+}
+
+// 계속 잃어도 구매 금액이 상한에서 멈추고 음수로 넘치지 않는지 검증
+func TestMartingaleLosingStreak(t *testing.T) {
+	for _, b := range []Martingale{{Base: 1000}, {Base: 1000, Max: 64_000}} {
+		limit := b.Max
+		if limit == 0 {
+			limit = b.Base * MartingaleMaxMultiple
+		}
+
+		var s State
+		for round := range 200 {
+			amount := b.Amount(s)
+			if amount < b.Base || amount > limit {
+				t.Fatalf("%+v: %d회차 구매 금액이 범위를 벗어났습니다. got=%d, limit=%d", b, round+1, amount, limit)
+			}
+			s = State{Round: round + 1, LastSpent: amount}
+		}
+		if got := b.Amount(s); got != limit {
+			t.Errorf("%+v: 연패 끝에는 상한만큼 사야 합니다. got=%d, want=%d", b, got, limit)
+		}
+	}
+}
+
+func TestIsPopularPattern(t *testing.T) {
+	tests := []struct {
+		numbers []int
+		want    bool
+	}{
+		{[]int{3, 9, 14, 22, 27, 30}, true},  // 모두 31 이하
+		{[]int{5, 12, 13, 14, 33, 41}, true}, // 연속 3개
+		{[]int{7, 14, 21, 28, 35, 42}, true}, // 등차수열
+		{[]int{2, 11, 19, 26, 34, 43}, false},
+		{[]int{1, 2, 20, 21, 38, 40}, false}, // 연속 2개까지는 허용
+	}
+
+	for _, tt := range tests {
+		if got := IsPopularPattern(tt.numbers); got != tt.want {
+			t.Errorf("%v 패턴 판정이 예상과 다릅니다. got=%v, want=%v", tt.numbers, got, tt.want)
+		}
+	}
+}
+
+func TestBuyPicks(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	favorite := []int{1, 7, 13, 22, 34, 45}
+
+	tickets := Buy(Combined{FixedBudget{Per: 3500}, FavoriteNumbers{Numbers: favorite}}, State{}, rng)
+	if len(tickets) != 3 {
+		t.Fatalf("장당 가격 단위로 내림해 구매해야 합니다. got=%d, want=%d", len(tickets), 3)
+	}
+	for i, n := range favorite {
+		if tickets[0].Numbers[i] != n {
+			t.Fatalf("첫 티켓은 고정 번호여야 합니다. got=%v, want=%v", tickets[0].Numbers, favorite)
+		}
+	}
+
+	repeat := Popular{Model: popularity.Model{PreviousShare: 1}}
+	last := []int{4, 11, 17, 25, 38, 42}
+	if got := Buy(Combined{FixedBudget{Per: 1000}, repeat}, State{LastDraw: last}, rng); got[0].Numbers[0] != 4 || got[0].Numbers[5] != 42 {
+		t.Errorf("직전 당첨 번호를 다시 골라야 합니다. got=%v, want=%v", got[0].Numbers, last)
+	}
+
+	for range 200 {
+		for _, ticket := range Buy(Combined{FixedBudget{Per: 1000}, AvoidPopular{}}, State{}, rng) {
+			if IsPopularPattern(ticket.Numbers) {
+				t.Fatalf("흔한 패턴을 피해야 합니다. got=%v", ticket.Numbers)
+			}
+		}
+	}
+}
+
+func TestParsePopulation(t *testing.T) {
+	p := Params{Amount: 2000, Threshold: 1_000_000, Favorite: []int{1, 2, 3, 4, 5, 6}}
+
+	groups, err := ParsePopulation("fixed=3, jackpot/avoid-popular=2,martingale/favorite=1", p)
+	if err != nil {
+		t.Fatalf("구성 해석 중 에러가 발생했습니다: %v", err)
+	}
+	wantNames := []string{"fixed/auto", "jackpot/avoid-popular", "martingale/favorite"}
+	if len(groups) != len(wantNames) {
+		t.Fatalf("묶음 수가 예상과 다릅니다. got=%d, want=%d", len(groups), len(wantNames))
+	}
+	for i, g := range groups {
+		if g.Name != wantNames[i] {
+			t.Errorf("묶음 이름이 예상과 다릅니다. got=%s, want=%s", g.Name, wantNames[i])
+		}
+	}
+	if got := len(NewPopulation(groups)); got != 6 {
+		t.Errorf("플레이어 수가 예상과 다릅니다. got=%d, want=%d", got, 6)
+	}
+
+	if _, err := ParsePopulation("lucky=3", p); !errors.Is(err, ErrUnknownStrategy) {
+		t.Errorf("없는 전략은 ErrUnknownStrategy여야 합니다. got=%v", err)
+	}
+	for _, spec := range []string{"fixed", "fixed=0", "", "fixed/favorite=1"} {
+		if _, err := ParsePopulation(spec, Params{Amount: 1000}); err == nil {
+			t.Errorf("%q 는 에러가 나야 합니다", spec)
+		}
+	}
+	for _, bad := range []Params{{Amount: 1000, MaxAmount: -1}, {Amount: math.MaxInt}} {
+		if _, err := ParsePopulation("martingale=1", bad); err == nil {
+			t.Errorf("%+v 는 에러가 나야 합니다", bad)
+		}
+	}
+}
+
+// 전략 플레이어들이 기존 정산 로직을 거쳐 구매/수령이 원장과 맞는지 검증
+func TestSimulate(t *testing.T) {
+	game, err := profile.NewRegistry().Get(profile.KR645Parimutuel)
+	if err != nil {
+		t.Fatalf("기본 프로필을 찾지 못했습니다: %v", err)
+	}
+
+	groups, err := ParsePopulation("fixed=20,jackpot=20,martingale=10", Params{
+		Amount:    3000,
+		Threshold: 1_000_000_000,
+		MaxAmount: 20_000,
+	})
+	if err != nil {
+		t.Fatalf("구성 해석 중 에러가 발생했습니다: %v", err)
+	}
+
+	sim := Simulation{Game: game, Rounds: 12, Rng: rand.New(rand.NewSource(7))}
+	report, err := Simulate(sim, NewPopulation(groups))
+	if err != nil {
+		t.Fatalf("시뮬레이션 중 에러가 발생했습니다: %v", err)
+	}
+
+	if len(report.Rounds) != 12 || len(report.Bettors) != 50 || len(report.Groups) != 3 {
+		t.Fatalf("결과 크기가 예상과 다릅니다. rounds=%d, bettors=%d, groups=%d",
+			len(report.Rounds), len(report.Bettors), len(report.Groups))
+	}
+
+	sales, paid := 0, 0
+	for _, r := range report.Rounds {
+		sales += r.Output.Sales
+		for _, total := range r.Output.PaidTotal {
+			paid += total
+		}
+	}
+	spent, won := 0, 0
+	for _, g := range report.Groups {
+		spent += g.Spent
+		won += g.Won
+	}
+	if spent != sales || won != paid {
+		t.Errorf("구매/수령 합계가 회차 정산과 다릅니다. spent=%d, sales=%d, won=%d, paid=%d", spent, sales, won, paid)
+	}
+
+	// 플레이어 판매만으로는 1등 금액이 기준에 못 미쳐 jackpot 전략은 한 번도 사지 않는다
+	if g := report.Groups[1]; g.Group != "jackpot/auto" || g.Spent != 0 {
+		t.Errorf("jackpot 전략은 구매하지 않아야 합니다. got=%+v", g)
+	}
+	if g := report.Groups[0]; g.Spent != 20*3000*12 {
+		t.Errorf("고정 금액 전략의 구매 금액이 예상과 다릅니다. got=%d, want=%d", g.Spent, 20*3000*12)
+	}
+}
+
+func TestSimulateRejectsDuplicateNames(t *testing.T) {
+	bettors := []Bettor{
+		{Name: "a", Strategy: Combined{FixedBudget{Per: 1000}, AllAuto{}}},
+		{Name: "a", Strategy: Combined{FixedBudget{Per: 1000}, AllAuto{}}},
+	}
+	sim := Simulation{Game: profile.Profile{Mode: lotto.ModeParimutuel}, Rounds: 1, Rng: rand.New(rand.NewSource(1))}
+	if _, err := Simulate(sim, bettors); err == nil {
+		t.Error("이름이 겹치면 에러가 나야 합니다")
+	}
+}
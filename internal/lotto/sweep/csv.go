@@ -0,0 +1,58 @@
+package sweep
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+var csvHeader = []string{
+	"rank",
+	"alloc1", "alloc2", "alloc3", "alloc4", "alloc5",
+	"cap1", "roundingUnit", "rollDownMethod",
+	"avgJackpot", "maxJackpot", "jackpotVolatility", "rolloverRate", "payoutRatio",
+}
+
+// 정렬된 결과를 CSV로 기록 (첫 열은 순위)
+func WriteCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for i, res := range results {
+		row := []string{strconv.Itoa(i + 1)}
+		for _, bps := range AllocationBps(res.Config) {
+			row = append(row, strconv.Itoa(bps))
+		}
+		row = append(row,
+			strconv.Itoa(res.Config.CapPerRank[lotto.Rank1]),
+			strconv.Itoa(res.Config.RoundingUnit),
+			res.Config.RollDownMethod.String(),
+			strconv.Itoa(res.Metrics.AvgJackpot),
+			strconv.Itoa(res.Metrics.MaxJackpot),
+			strconv.FormatFloat(res.Metrics.JackpotVolatility, 'f', 4, 64),
+			strconv.FormatFloat(res.Metrics.RolloverRate, 'f', 4, 64),
+			strconv.FormatFloat(res.Metrics.PayoutRatio, 'f', 4, 64),
+		)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// 1~5등 순서의 배정 비율 (없는 등수는 0)
+func AllocationBps(cfg lotto.SeriesConfig) []int {
+	bps := make([]int, 5)
+	for _, a := range cfg.Allocations {
+		if n := a.Rank.Number(); n >= 1 && n <= 5 {
+			bps[n-1] = a.BasisPoints
+		}
+	}
+	return bps
+}
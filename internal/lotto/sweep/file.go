@@ -0,0 +1,146 @@
+package sweep
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+var ErrInvalidSpace = errors.New("잘못된 탐색 공간입니다")
+
+// 탐색 공간 파일 형식 (YAML/JSON 공통). 적지 않은 축은 DefaultSpace 후보를 쓴다
+//
+//	allocations:                 # 1~5등 배정 비율(bps) 묶음
+//	  - [7500, 1250, 1250, 0, 0]
+//	  - [6000, 1500, 1500, 500, 500]
+//	rank1Caps: [0, 2000000000]   # 0이면 상한 없음
+//	roundingUnits: [1, 1000]
+//	rollDownMethods: [proportional, equal]
+type spaceFile struct {
+	Allocations     [][]int  `json:"allocations" yaml:"allocations"`
+	Rank1Caps       []int    `json:"rank1Caps" yaml:"rank1Caps"`
+	RoundingUnits   []int    `json:"roundingUnits" yaml:"roundingUnits"`
+	RollDownMethods []string `json:"rollDownMethods" yaml:"rollDownMethods"`
+}
+
+// 파일에서 탐색 공간을 읽는다. 확장자(.yaml/.yml/.json)로 형식 결정
+func LoadSpaceFile(path string) (Space, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Space{}, err
+	}
+
+	var file spaceFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		// JSON처럼 모르는 필드는 오타로 보고 거부한다. 빈 파일은 모두 기본 후보
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err = dec.Decode(&file); errors.Is(err, io.EOF) {
+			err = nil
+		}
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&file)
+	default:
+		return Space{}, fmt.Errorf("지원하지 않는 탐색 공간 파일 형식입니다: %s", path)
+	}
+	if err != nil {
+		return Space{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	space, err := file.toSpace(DefaultSpace())
+	if err != nil {
+		return Space{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return space, nil
+}
+
+func (f spaceFile) toSpace(base Space) (Space, error) {
+	space := base
+	if len(f.Allocations) > 0 {
+		space.Allocations = make([][]lotto.Allocation, 0, len(f.Allocations))
+		for _, bps := range f.Allocations {
+			allocs, err := AllocationsFromBasisPoints(bps)
+			if err != nil {
+				return Space{}, err
+			}
+			space.Allocations = append(space.Allocations, allocs)
+		}
+	}
+	if len(f.Rank1Caps) > 0 {
+		space.Rank1Caps = f.Rank1Caps
+	}
+	if len(f.RoundingUnits) > 0 {
+		space.RoundingUnits = f.RoundingUnits
+	}
+	if len(f.RollDownMethods) > 0 {
+		methods, err := ParseRollDownMethods(f.RollDownMethods)
+		if err != nil {
+			return Space{}, err
+		}
+		space.RollDownMethods = methods
+	}
+	return space, space.Validate()
+}
+
+// 1~5등 순서의 bps 다섯 개 → 배정 비율
+func AllocationsFromBasisPoints(bps []int) ([]lotto.Allocation, error) {
+	if len(bps) != 5 {
+		return nil, fmt.Errorf("%w: 배정 비율은 1~5등 5개여야 합니다 (%v)", ErrInvalidSpace, bps)
+	}
+	return allocations(bps[0], bps[1], bps[2], bps[3], bps[4]), nil
+}
+
+// 롤다운 방식 이름(proportional, equal) 목록 → 방식
+func ParseRollDownMethods(names []string) ([]lotto.RollDownMethod, error) {
+	methods := make([]lotto.RollDownMethod, 0, len(names))
+	for _, name := range names {
+		var m lotto.RollDownMethod
+		if err := m.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSpace, err)
+		}
+		methods = append(methods, m)
+	}
+	return methods, nil
+}
+
+// 축마다 후보가 하나 이상이고 값이 유효한지 검사
+func (s Space) Validate() error {
+	if s.Size() == 0 {
+		return fmt.Errorf("%w: 축마다 후보가 하나 이상 있어야 합니다", ErrInvalidSpace)
+	}
+	for _, allocs := range s.Allocations {
+		total := 0
+		for _, a := range allocs {
+			if a.BasisPoints < 0 {
+				return fmt.Errorf("%w: 배정 비율은 음수일 수 없습니다 (%d등 %d)", ErrInvalidSpace, a.Rank.Number(), a.BasisPoints)
+			}
+			total += a.BasisPoints
+		}
+		if total > lotto.BasisPoints {
+			return fmt.Errorf("%w: 배정 비율 합이 %d을 넘습니다 (%d)", ErrInvalidSpace, lotto.BasisPoints, total)
+		}
+	}
+	for _, c := range s.Rank1Caps {
+		if c < 0 {
+			return fmt.Errorf("%w: 1등 상한은 음수일 수 없습니다 (%d)", ErrInvalidSpace, c)
+		}
+	}
+	for _, u := range s.RoundingUnits {
+		if u < 1 {
+			return fmt.Errorf("%w: 라운딩 단위는 1 이상이어야 합니다 (%d)", ErrInvalidSpace, u)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package sweep
+
+import (
+	"math/rand"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+// 탐색할 설정 축. 축마다 후보를 나열하고 조합마다 시리즈를 돌린다
+type Space struct {
+	Allocations     [][]lotto.Allocation   `json:"allocations"`
+	Rank1Caps       []int                  `json:"rank1Caps"` // 0이면 상한 없음
+	RoundingUnits   []int                  `json:"roundingUnits"`
+	RollDownMethods []lotto.RollDownMethod `json:"rollDownMethods"`
+}
+
+// 1~3등 배정 비율 몇 가지와 상한/라운딩/롤다운 방식을 섞은 기본 탐색 공간
+func DefaultSpace() Space {
+	return Space{
+		Allocations: [][]lotto.Allocation{
+			allocations(7_500, 1_250, 1_250, 0, 0),
+			allocations(6_000, 1_500, 1_500, 500, 500),
+			allocations(5_000, 1_000, 1_000, 1_500, 1_500),
+			allocations(4_000, 1_000, 1_000, 2_000, 2_000),
+		},
+		Rank1Caps:       []int{0, 2_000_000_000, 5_000_000_000},
+		RoundingUnits:   []int{1, 100, 1_000},
+		RollDownMethods: []lotto.RollDownMethod{lotto.RollDownProportional, lotto.RollDownEqual},
+	}
+}
+
+// 모든 축의 데카르트 곱
+func (s Space) Grid() []lotto.SeriesConfig {
+	var configs []lotto.SeriesConfig
+	for _, allocs := range s.Allocations {
+		for _, cap1 := range s.Rank1Caps {
+			for _, unit := range s.RoundingUnits {
+				for _, method := range s.RollDownMethods {
+					configs = append(configs, newConfig(allocs, cap1, unit, method))
+				}
+			}
+		}
+	}
+	return configs
+}
+
+// 축마다 후보 하나씩 무작위로 골라 n개 조합 (중복 가능)
+func (s Space) Random(rng *rand.Rand, n int) []lotto.SeriesConfig {
+	if s.Size() == 0 {
+		return nil
+	}
+
+	configs := make([]lotto.SeriesConfig, 0, n)
+	for range n {
+		configs = append(configs, newConfig(
+			s.Allocations[rng.Intn(len(s.Allocations))],
+			s.Rank1Caps[rng.Intn(len(s.Rank1Caps))],
+			s.RoundingUnits[rng.Intn(len(s.RoundingUnits))],
+			s.RollDownMethods[rng.Intn(len(s.RollDownMethods))],
+		))
+	}
+	return configs
+}
+
+// 격자 조합 수
+func (s Space) Size() int {
+	return len(s.Allocations) * len(s.Rank1Caps) * len(s.RoundingUnits) * len(s.RollDownMethods)
+}
+
+func newConfig(allocs []lotto.Allocation, cap1, unit int, method lotto.RollDownMethod) lotto.SeriesConfig {
+	caps := map[lotto.Rank]int{}
+	if cap1 > 0 {
+		caps[lotto.Rank1] = cap1
+	}
+
+	return lotto.SeriesConfig{
+		Mode:           lotto.ModeParimutuel,
+		Allocations:    allocs,
+		CapPerRank:     caps,
+		RoundingUnit:   unit,
+		RollDownMethod: method,
+	}
+}
+
+func allocations(rank1, rank2, rank3, rank4, rank5 int) []lotto.Allocation {
+	return []lotto.Allocation{
+		{Rank: lotto.Rank1, BasisPoints: rank1},
+		{Rank: lotto.Rank2, BasisPoints: rank2},
+		{Rank: lotto.Rank3, BasisPoints: rank3},
+		{Rank: lotto.Rank4, BasisPoints: rank4},
+		{Rank: lotto.Rank5, BasisPoints: rank5},
+	}
+}
@@ -0,0 +1,165 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+var ErrUnknownObjective = errors.New("지원하지 않는 정렬 기준입니다")
+
+// 모든 설정이 같은 조건에서 비교되도록 고정하는 시뮬레이션 조건
+// Elasticity/Noise가 0이면 매 회차 TicketsPerRound장으로 판매량 고정
+type Scenario struct {
+	Rounds          int     `json:"rounds"`
+	TicketsPerRound int     `json:"ticketsPerRound"` // 이월이 없을 때의 회차당 티켓 수
+	Elasticity      float64 `json:"elasticity"`      // 광고 1등 금액에 대한 판매 탄력성
+	Noise           float64 `json:"noise"`           // 회차별 판매량 변동 비율
+	Seed            int64   `json:"seed"`
+}
+
+// 설정마다 같은 시드로 새 난수열을 만들어 같은 조건에서 비교
+func (sc Scenario) simulate(cfg lotto.SeriesConfig) ([]lotto.RoundOutput, error) {
+	winnerRng := rand.New(rand.NewSource(sc.Seed))
+	sample := func(_ int, tickets int) map[lotto.Rank]int {
+		return lotto.SampleWinners(winnerRng, tickets)
+	}
+
+	var demand lotto.DemandModel = lotto.ConstantDemand{N: sc.TicketsPerRound}
+	if sc.Elasticity != 0 || sc.Noise > 0 {
+		demand = lotto.JackpotDemand{
+			Base:       sc.TicketsPerRound,
+			Elasticity: sc.Elasticity,
+			Noise:      sc.Noise,
+			Rng:        rand.New(rand.NewSource(sc.Seed + 1)),
+		}
+	}
+
+	return lotto.SimulateDemandSeries(cfg, sc.Rounds, demand, sample, nil)
+}
+
+// 설정 하나의 시리즈 결과 지표
+type Metrics struct {
+	AvgJackpot        int     `json:"avgJackpot"`        // 1등이 나온 회차의 1등 1인당 평균 당첨금
+	MaxJackpot        int     `json:"maxJackpot"`        // 회차별 1등 풀(상한 적용 후) 최대값
+	JackpotVolatility float64 `json:"jackpotVolatility"` // 1등 1인당 당첨금의 변동 계수 (표준편차 / 평균). 1등이 없으면 0
+	RolloverRate      float64 `json:"rolloverRate"`      // 1등 당첨자가 없어 풀 전체가 이월된 회차 비율
+	PayoutRatio       float64 `json:"payoutRatio"`       // 총 지급액 / 총 판매액
+}
+
+type Result struct {
+	Config  lotto.SeriesConfig `json:"config"`
+	Metrics Metrics            `json:"metrics"`
+}
+
+// 정렬 기준별 "앞에 와야 하는" 비교 함수
+var objectives = map[string]func(a, b Metrics) bool{
+	"volatility": func(a, b Metrics) bool {
+		// 1등이 한 번도 나오지 않은 설정은 변동 계수를 잴 수 없어 0이므로 가장 뒤로 보낸다
+		if a.noJackpot() != b.noJackpot() {
+			return b.noJackpot()
+		}
+		return a.JackpotVolatility < b.JackpotVolatility
+	},
+	"jackpot":  func(a, b Metrics) bool { return a.AvgJackpot > b.AvgJackpot },
+	"rollover": func(a, b Metrics) bool { return a.RolloverRate < b.RolloverRate },
+	"payout":   func(a, b Metrics) bool { return a.PayoutRatio > b.PayoutRatio },
+}
+
+// 설정마다 시나리오대로 시리즈를 돌려 objective 순으로 정렬해 반환
+func Run(sc Scenario, configs []lotto.SeriesConfig, objective string) ([]Result, error) {
+	better, exists := objectives[objective]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownObjective, objective)
+	}
+	if sc.Rounds <= 0 {
+		return nil, fmt.Errorf("회차 수는 1 이상이어야 합니다: %d", sc.Rounds)
+	}
+
+	results := make([]Result, 0, len(configs))
+	for _, cfg := range configs {
+		outs, err := sc.simulate(cfg)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, Result{Config: cfg, Metrics: score(outs)})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return better(results[i].Metrics, results[j].Metrics)
+	})
+	return results, nil
+}
+
+// 지원하는 정렬 기준 이름
+func Objectives() []string {
+	names := make([]string, 0, len(objectives))
+	for name := range objectives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 모든 회차가 이월돼 1등 당첨금을 한 번도 지급하지 않았는지
+func (m Metrics) noJackpot() bool {
+	return m.RolloverRate == 1
+}
+
+func score(outs []lotto.RoundOutput) Metrics {
+	var m Metrics
+	if len(outs) == 0 {
+		return m
+	}
+
+	var jackpotSum, rollovers, paid, sales int
+	jackpots := make([]float64, 0, len(outs))
+
+	for _, out := range outs {
+		m.MaxJackpot = max(m.MaxJackpot, out.PoolAfterCap[lotto.Rank1])
+
+		if out.PaidTotal[lotto.Rank1] > 0 {
+			perWin := out.PaidPerWin[lotto.Rank1]
+			jackpotSum += perWin
+			jackpots = append(jackpots, float64(perWin))
+		} else {
+			rollovers++
+		}
+		for _, amount := range out.PaidTotal {
+			paid += amount
+		}
+		sales += out.Sales
+	}
+
+	if len(jackpots) > 0 {
+		m.AvgJackpot = jackpotSum / len(jackpots)
+		m.JackpotVolatility = coefficientOfVariation(jackpots)
+	}
+	m.RolloverRate = float64(rollovers) / float64(len(outs))
+	if sales > 0 {
+		m.PayoutRatio = float64(paid) / float64(sales)
+	}
+	return m
+}
+
+func coefficientOfVariation(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	if mean == 0 {
+		return 0
+	}
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+	return math.Sqrt(variance) / mean
+}
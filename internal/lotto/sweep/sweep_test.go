@@ -0,0 +1,242 @@
+package sweep
+
+import (
+	"encoding/csv"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+func TestSpaceGridAndRandom(t *testing.T) {
+	space := DefaultSpace()
+
+	grid := space.Grid()
+	if len(grid) != space.Size() {
+		t.Errorf("격자 조합 수가 예상과 다릅니다. got=%d, want=%d", len(grid), space.Size())
+	}
+
+	random := space.Random(rand.New(rand.NewSource(1)), 7)
+	if len(random) != 7 {
+		t.Errorf("무작위 조합 수가 예상과 다릅니다. got=%d, want=%d", len(random), 7)
+	}
+
+	if got := (Space{}).Random(rand.New(rand.NewSource(1)), 3); got != nil {
+		t.Errorf("빈 탐색 공간에서는 조합이 없어야 합니다. got=%v", got)
+	}
+}
+
+func TestLoadSpaceFile(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "space.yaml")
+	writeFile(t, yamlPath, `allocations:
+  - [7000, 1500, 1500, 0, 0]
+rank1Caps: [0, 3000000000]
+rollDownMethods: [equal]
+`)
+	space, err := LoadSpaceFile(yamlPath)
+	if err != nil {
+		t.Fatalf("탐색 공간 파일을 불러오는 중 에러가 발생했습니다: %v", err)
+	}
+	if len(space.Allocations) != 1 || space.Allocations[0][0].BasisPoints != 7000 || space.Allocations[0][4].Rank != lotto.Rank5 {
+		t.Errorf("배정 비율 후보가 예상과 다릅니다. got=%v", space.Allocations)
+	}
+	if len(space.Rank1Caps) != 2 || len(space.RollDownMethods) != 1 || space.RollDownMethods[0] != lotto.RollDownEqual {
+		t.Errorf("파일 값이 예상과 다릅니다. got=%+v", space)
+	}
+	if got, want := len(space.RoundingUnits), len(DefaultSpace().RoundingUnits); got != want {
+		t.Errorf("적지 않은 축은 기본 후보를 써야 합니다. got=%d, want=%d", got, want)
+	}
+
+	jsonPath := filepath.Join(dir, "space.json")
+	writeFile(t, jsonPath, `{"roundingUnits": [1000]}`)
+	space, err = LoadSpaceFile(jsonPath)
+	if err != nil {
+		t.Fatalf("탐색 공간 파일을 불러오는 중 에러가 발생했습니다: %v", err)
+	}
+	if space.Size() != len(DefaultSpace().Grid())/len(DefaultSpace().RoundingUnits) {
+		t.Errorf("JSON 탐색 공간 크기가 예상과 다릅니다. got=%d", space.Size())
+	}
+}
+
+func TestLoadSpaceFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"배정 비율 개수 부족", "s.yaml", `allocations: [[7000, 1500, 1500]]`},
+		{"음수 배정 비율", "s.yaml", `allocations: [[7000, -1, 1500, 0, 0]]`},
+		{"배정 비율 합 초과", "s.yaml", `allocations: [[9000, 1500, 0, 0, 0]]`},
+		{"음수 1등 상한", "s.yaml", `rank1Caps: [-1]`},
+		{"0 라운딩 단위", "s.yaml", `roundingUnits: [0, 1000]`},
+		{"잘못된 롤다운 방식", "s.yaml", `rollDownMethods: [random]`},
+		{"모르는 JSON 필드", "s.json", `{"caps": [0]}`},
+		{"모르는 YAML 필드", "s.yaml", `caps: [0]`},
+		{"YAML 필드 오타", "s.yaml", "rank1Caps: [0]\nroundingUnit: [1000]"},
+		{"지원하지 않는 확장자", "s.txt", `rank1Caps: [0]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			writeFile(t, path, tt.content)
+
+			_, err := LoadSpaceFile(path)
+			if err == nil {
+				t.Errorf("에러가 발생해야 합니다")
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	sc := Scenario{Rounds: 30, TicketsPerRound: 5_000_000, Seed: 42}
+	space := Space{
+		Allocations:     [][]lotto.Allocation{allocations(7_500, 1_250, 1_250, 0, 0)},
+		Rank1Caps:       []int{0, 1_000_000_000},
+		RoundingUnits:   []int{100},
+		RollDownMethods: []lotto.RollDownMethod{lotto.RollDownProportional},
+	}
+
+	tests := []struct {
+		objective string
+		ordered   func(a, b Metrics) bool
+	}{
+		{"volatility", func(a, b Metrics) bool { return !objectives["volatility"](b, a) }},
+		{"jackpot", func(a, b Metrics) bool { return a.AvgJackpot >= b.AvgJackpot }},
+		{"rollover", func(a, b Metrics) bool { return a.RolloverRate <= b.RolloverRate }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.objective, func(t *testing.T) {
+			results, err := Run(sc, space.Grid(), tt.objective)
+			if err != nil {
+				t.Fatalf("스윕 중 에러가 발생했습니다: %v", err)
+			}
+			if len(results) != 2 {
+				t.Fatalf("결과 수가 예상과 다릅니다. got=%d, want=%d", len(results), 2)
+			}
+			if !tt.ordered(results[0].Metrics, results[1].Metrics) {
+				t.Errorf("%s 기준으로 정렬되지 않았습니다. got=%+v", tt.objective, results)
+			}
+		})
+	}
+}
+
+// 1등이 나오지 않은 설정은 변동 계수가 0이어도 가장 뒤에 오는지 검증
+func TestVolatilityObjective(t *testing.T) {
+	metrics := []Metrics{
+		{JackpotVolatility: 0, RolloverRate: 1},
+		{JackpotVolatility: 0.8, RolloverRate: 0.9},
+		{JackpotVolatility: 0, RolloverRate: 0.95},
+		{JackpotVolatility: 0.2, RolloverRate: 0.5},
+		{JackpotVolatility: 0, RolloverRate: 1},
+	}
+	want := []Metrics{metrics[2], metrics[3], metrics[1], metrics[0], metrics[4]}
+
+	better := objectives["volatility"]
+	slices.SortStableFunc(metrics, func(a, b Metrics) int {
+		switch {
+		case better(a, b):
+			return -1
+		case better(b, a):
+			return 1
+		}
+		return 0
+	})
+	if !slices.Equal(metrics, want) {
+		t.Errorf("변동성 순서가 예상과 다릅니다.\ngot=%+v\nwant=%+v", metrics, want)
+	}
+}
+
+// 같은 시드면 같은 결과
+func TestRun_Deterministic(t *testing.T) {
+	sc := Scenario{Rounds: 10, TicketsPerRound: 1_000_000, Seed: 7}
+	configs := DefaultSpace().Grid()[:3]
+
+	first, err := Run(sc, configs, "jackpot")
+	if err != nil {
+		t.Fatalf("스윕 중 에러가 발생했습니다: %v", err)
+	}
+	second, _ := Run(sc, configs, "jackpot")
+
+	for i := range first {
+		if first[i].Metrics != second[i].Metrics {
+			t.Errorf("같은 시드인데 결과가 다릅니다. got=%+v, want=%+v", second[i].Metrics, first[i].Metrics)
+		}
+	}
+}
+
+func TestRun_InvalidInput(t *testing.T) {
+	configs := DefaultSpace().Grid()[:1]
+
+	if _, err := Run(Scenario{Rounds: 1}, configs, "없는기준"); !errors.Is(err, ErrUnknownObjective) {
+		t.Errorf("알 수 없는 정렬 기준은 ErrUnknownObjective여야 합니다. got=%v", err)
+	}
+	if _, err := Run(Scenario{Rounds: 0}, configs, "jackpot"); err == nil {
+		t.Errorf("회차 수가 0이면 에러가 발생해야 합니다")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	results := []Result{
+		{Config: DefaultSpace().Grid()[0], Metrics: Metrics{AvgJackpot: 1_000, RolloverRate: 0.5}},
+	}
+
+	var b strings.Builder
+	if err := WriteCSV(&b, results); err != nil {
+		t.Fatalf("CSV 기록 중 에러가 발생했습니다: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("CSV 줄 수가 예상과 다릅니다. got=%d, want=%d", len(lines), 2)
+	}
+	if want := "1,7500,1250,1250,0,0,0,1,proportional,1000,0,0.0000,0.5000,0.0000"; lines[1] != want {
+		t.Errorf("CSV 행이 예상과 다릅니다.\ngot=%s\nwant=%s", lines[1], want)
+	}
+}
+
+// rollDownMethod 열은 숫자가 아니라 -rolldown/스페이스 파일과 같은 이름으로 기록
+func TestWriteCSV_RollDownMethod(t *testing.T) {
+	for _, method := range []lotto.RollDownMethod{lotto.RollDownProportional, lotto.RollDownEqual} {
+		cfg := DefaultSpace().Grid()[0]
+		cfg.RollDownMethod = method
+
+		var b strings.Builder
+		if err := WriteCSV(&b, []Result{{Config: cfg}}); err != nil {
+			t.Fatalf("CSV 기록 중 에러가 발생했습니다: %v", err)
+		}
+
+		records, err := csv.NewReader(strings.NewReader(b.String())).ReadAll()
+		if err != nil {
+			t.Fatalf("CSV를 읽지 못했습니다: %v", err)
+		}
+		col := slices.Index(records[0], "rollDownMethod")
+		if col < 0 {
+			t.Fatalf("rollDownMethod 열이 없습니다. header=%v", records[0])
+		}
+		if got := records[1][col]; got != method.String() {
+			t.Errorf("rollDownMethod 열이 예상과 다릅니다. got=%s, want=%s", got, method)
+		}
+
+		parsed, err := ParseRollDownMethods([]string{records[1][col]})
+		if err != nil || parsed[0] != method {
+			t.Errorf("기록한 이름을 다시 읽을 수 있어야 합니다. got=%v err=%v", parsed, err)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,124 @@
+package lotto
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSON/폼 등 외부 표기용 이름. 입력은 예전 숫자 값도 받는다 (Rank는 내부 값: "5" = rank1)
+var (
+	rankNames           = [...]string{RankNone: "none", Rank5: "rank5", Rank4: "rank4", Rank3: "rank3", Rank2: "rank2", Rank1: "rank1"}
+	modeNames           = [...]string{ModeFixedPayout: "fixed", ModeParimutuel: "parimutuel"}
+	rollDownMethodNames = [...]string{RollDownProportional: "proportional", RollDownEqual: "equal"}
+)
+
+func (r Rank) String() string {
+	if r < RankNone || r > Rank1 {
+		return "Rank(" + strconv.Itoa(int(r)) + ")"
+	}
+	return rankNames[r]
+}
+
+func (r Rank) MarshalText() ([]byte, error) {
+	if r < RankNone || r > Rank1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRank, int(r))
+	}
+	return []byte(rankNames[r]), nil
+}
+
+func (r *Rank) UnmarshalText(text []byte) error {
+	v, ok := parseName(string(text), rankNames[:])
+	if !ok {
+		return fmt.Errorf("%w: %q (%s)", ErrInvalidRank, text, strings.Join(rankNames[:], ", "))
+	}
+	*r = Rank(v)
+	return nil
+}
+
+func (r *Rank) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONName(data, r)
+}
+
+func (m Mode) String() string {
+	if m < ModeFixedPayout || m > ModeParimutuel {
+		return "Mode(" + strconv.Itoa(int(m)) + ")"
+	}
+	return modeNames[m]
+}
+
+func (m Mode) MarshalText() ([]byte, error) {
+	if m < ModeFixedPayout || m > ModeParimutuel {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMode, int(m))
+	}
+	return []byte(modeNames[m]), nil
+}
+
+func (m *Mode) UnmarshalText(text []byte) error {
+	v, ok := parseName(string(text), modeNames[:])
+	if !ok {
+		return fmt.Errorf("%w: %q (%s)", ErrInvalidMode, text, strings.Join(modeNames[:], ", "))
+	}
+	*m = Mode(v)
+	return nil
+}
+
+func (m *Mode) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONName(data, m)
+}
+
+func (m RollDownMethod) String() string {
+	if m < RollDownProportional || m > RollDownEqual {
+		return "RollDownMethod(" + strconv.Itoa(int(m)) + ")"
+	}
+	return rollDownMethodNames[m]
+}
+
+func (m RollDownMethod) MarshalText() ([]byte, error) {
+	if m < RollDownProportional || m > RollDownEqual {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRollDownMethod, int(m))
+	}
+	return []byte(rollDownMethodNames[m]), nil
+}
+
+func (m *RollDownMethod) UnmarshalText(text []byte) error {
+	v, ok := parseName(string(text), rollDownMethodNames[:])
+	if !ok {
+		return fmt.Errorf("%w: %q (%s)", ErrInvalidRollDownMethod, text, strings.Join(rollDownMethodNames[:], ", "))
+	}
+	*m = RollDownMethod(v)
+	return nil
+}
+
+func (m *RollDownMethod) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONName(data, m)
+}
+
+// 이름(대소문자 무시) 또는 숫자 값 → 인덱스
+func parseName(s string, names []string) (int, bool) {
+	for i, name := range names {
+		if strings.EqualFold(s, name) {
+			return i, true
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n >= len(names) {
+		return 0, false
+	}
+	return n, true
+}
+
+// JSON 문자열은 이름, JSON 숫자는 예전 형식으로 보고 UnmarshalText에 넘긴다
+func unmarshalJSONName(data []byte, u encoding.TextUnmarshaler) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		s = string(data)
+	}
+	return u.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,123 @@
+package lotto
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRankText(t *testing.T) {
+	tests := []struct {
+		rank Rank
+		want string
+	}{
+		{Rank1, "rank1"},
+		{Rank2, "rank2"},
+		{Rank3, "rank3"},
+		{Rank4, "rank4"},
+		{Rank5, "rank5"},
+		{RankNone, "none"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rank.String(); got != tt.want {
+			t.Errorf("Rank(%d).String() = %q, want %q", int(tt.rank), got, tt.want)
+		}
+
+		var back Rank
+		if err := back.UnmarshalText([]byte(tt.want)); err != nil || back != tt.rank {
+			t.Errorf("UnmarshalText(%q) = %d, %v, want %d", tt.want, int(back), err, int(tt.rank))
+		}
+	}
+
+	if _, err := Rank(6).MarshalText(); !errors.Is(err, ErrInvalidRank) {
+		t.Errorf("범위 밖 등수 MarshalText 에러 = %v, want ErrInvalidRank", err)
+	}
+}
+
+func TestUnmarshalNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    RoundInput
+		wantErr error
+	}{
+		{
+			name: "이름",
+			json: `{"mode": "parimutuel", "winners": {"rank1": 1, "rank5": 10}, "rollDownMethod": "equal", "allocations": [{"Rank": "rank1", "BasisPoints": 7500}]}`,
+			want: RoundInput{
+				Mode:           ModeParimutuel,
+				Winners:        map[Rank]int{Rank1: 1, Rank5: 10},
+				RollDownMethod: RollDownEqual,
+				Allocations:    []Allocation{{Rank: Rank1, BasisPoints: 7500}},
+			},
+		},
+		{
+			name: "예전 숫자 값 (등수는 내부 값: 5 = 1등)",
+			json: `{"mode": 1, "winners": {"5": 1, "1": 10}, "rollDownMethod": 1, "allocations": [{"Rank": 5, "BasisPoints": 7500}]}`,
+			want: RoundInput{
+				Mode:           ModeParimutuel,
+				Winners:        map[Rank]int{Rank1: 1, Rank5: 10},
+				RollDownMethod: RollDownEqual,
+				Allocations:    []Allocation{{Rank: Rank1, BasisPoints: 7500}},
+			},
+		},
+		{
+			name: "대소문자 무시와 null",
+			json: `{"mode": "Fixed", "winners": {"RANK2": 3}, "rollDownMethod": null}`,
+			want: RoundInput{Mode: ModeFixedPayout, Winners: map[Rank]int{Rank2: 3}},
+		},
+		{name: "없는 등수 이름", json: `{"winners": {"rank6": 1}}`, wantErr: ErrInvalidRank},
+		{name: "범위 밖 등수 값", json: `{"winners": {"6": 1}}`, wantErr: ErrInvalidRank},
+		{name: "없는 모드", json: `{"mode": "lottery"}`, wantErr: ErrInvalidMode},
+		{name: "범위 밖 모드 값", json: `{"mode": 2}`, wantErr: ErrInvalidMode},
+		{name: "없는 롤다운 방식", json: `{"rollDownMethod": "random"}`, wantErr: ErrInvalidRollDownMethod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got RoundInput
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("에러 = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("예상치 못한 에러: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("결과 = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalNames(t *testing.T) {
+	in := struct {
+		Mode    Mode           `json:"mode"`
+		Method  RollDownMethod `json:"method"`
+		Rank    Rank           `json:"rank"`
+		Winners map[Rank]int   `json:"winners"`
+	}{
+		Mode:    ModeParimutuel,
+		Method:  RollDownProportional,
+		Rank:    RankNone,
+		Winners: map[Rank]int{Rank1: 1, Rank5: 2},
+	}
+
+	got, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"mode":"parimutuel","method":"proportional","rank":"none","winners":{"rank1":1,"rank5":2}}`
+	if string(got) != want {
+		t.Errorf("JSON = %s, want %s", got, want)
+	}
+
+	if _, err := json.Marshal(Mode(2)); !errors.Is(err, ErrInvalidMode) {
+		t.Errorf("범위 밖 모드 인코딩 에러 = %v, want ErrInvalidMode", err)
+	}
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -11,6 +12,9 @@ import (
 func FormatRoundReport(in lotto.RoundInput, out lotto.RoundOutput) string {
 	var b strings.Builder
 
+	if out.Meta.Sequence > 0 {
+		b.WriteString(fmt.Sprintf("회차: %s [%s]\n", out.Meta.Label(), out.Meta.ID()))
+	}
 	b.WriteString(fmt.Sprintf("총 판매액: %s원\n", Comma(out.Sales)))
 	b.WriteString(fmt.Sprintf("라운드 잔액: %s원\n\n", Comma(out.RoundRemainder)))
 
@@ -41,9 +45,59 @@ func FormatRoundReport(in lotto.RoundInput, out lotto.RoundOutput) string {
 		))
 	}
 
+	if len(out.Reserves) > 0 {
+		names := make([]string, 0, len(out.Reserves))
+		for name := range out.Reserves {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		b.WriteString("\n적립금:")
+		for _, name := range names {
+			b.WriteString(fmt.Sprintf(" %s=%s원", name, Comma(out.Reserves[name])))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func FormatLedger(in lotto.RoundInput, out lotto.RoundOutput) string {
+	var b strings.Builder
+
+	b.WriteString("[원장]\n")
+	b.WriteString(fmt.Sprintf("%-10s | %-16s | %-16s | %14s\n", "Kind", "From", "To", "Amount"))
+	b.WriteString(strings.Repeat("-", 66) + "\n")
+
+	for _, e := range out.Ledger.Entries {
+		b.WriteString(fmt.Sprintf(
+			"%-10s | %-16s | %-16s | %14s\n",
+			e.Kind,
+			ledgerAccountLabel(e.From, e.FromRank, ""),
+			ledgerAccountLabel(e.To, e.ToRank, e.Reserve),
+			Comma(e.Amount),
+		))
+	}
+
+	if err := lotto.CheckLedger(in, out); err != nil {
+		b.WriteString("원장 검증: 실패 - " + err.Error() + "\n")
+	} else {
+		b.WriteString("원장 검증: 정상\n")
+	}
+
 	return b.String()
 }
 
+func ledgerAccountLabel(account lotto.LedgerAccount, rank lotto.Rank, reserve string) string {
+	if reserve != "" {
+		return fmt.Sprintf("%s(%s)", account, reserve)
+	}
+	if rank == lotto.RankNone {
+		return string(account)
+	}
+	return fmt.Sprintf("%s(%d등)", account, rank.Number())
+}
+
 func Comma(n int) string {
 	s := strconv.Itoa(n)
 	neg := false
@@ -98,3 +98,36 @@ func TestValidateNoDuplicates(t *testing.T) {
 		}
 	}
 }
+
+func TestNewLotto(t *testing.T) {
+	tests := []struct {
+		name    string
+		numbers []int
+		wantErr bool
+	}{
+		{"정상 번호는 정렬", []int{45, 1, 23, 7, 2, 3}, false},
+		{"개수 부족", []int{1, 2, 3, 4, 5}, true},
+		{"범위 위반", []int{0, 2, 3, 4, 5, 6}, true},
+		{"중복 번호", []int{1, 1, 3, 4, 5, 6}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := NewLotto(tt.numbers)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("잘못된 번호(%v)에서 에러가 발생하지 않았습니다.", tt.numbers)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("정상 번호(%v)에서 에러가 발생했습니다: %v", tt.numbers, err)
+			}
+			for i := 1; i < len(l.Numbers); i++ {
+				if l.Numbers[i-1] > l.Numbers[i] {
+					t.Errorf("번호가 오름차순이 아닙니다: %v", l.Numbers)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package wheel
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+// 보장 조건 검증 결과에 남길 미충족 조합 수
+const maxMissing = 10
+
+// 티켓 묶음이 보장 조건을 얼마나 만족하는지
+type Coverage struct {
+	Guarantee Guarantee `json:"guarantee"`
+	Targets   int       `json:"targets"` // 확인한 IfDrawn개 조합 수
+	Covered   int       `json:"covered"` // 그중 Match개 이상 맞는 티켓이 있는 조합 수
+	Missing   [][]int   `json:"missing"` // 보장이 깨지는 조합 (앞의 일부)
+}
+
+func (c Coverage) Guaranteed() bool { return c.Covered == c.Targets }
+
+// 휠 번호 중 IfDrawn개가 나오는 모든 경우마다 Match개 이상 맞는 티켓이 있는지 확인
+// 티켓은 휠 번호 밖의 번호를 포함해도 된다
+func Verify(tickets []lotto.Lotto, numbers []int, g Guarantee) (Coverage, error) {
+	pool, err := normalize(numbers)
+	if err != nil {
+		return Coverage{}, err
+	}
+	if err := g.validate(len(pool)); err != nil {
+		return Coverage{}, err
+	}
+	if work := verifyWork(len(pool), g.IfDrawn, len(tickets)); work > maxWork {
+		return Coverage{}, fmt.Errorf("%w: 번호 %d개, 티켓 %d장으로 %s 보장을 검증하기에는 조합이 너무 많습니다", ErrTooLarge, len(pool), len(tickets), g)
+	}
+
+	masks := make([]uint64, 0, len(tickets))
+	for _, t := range tickets {
+		masks = append(masks, numbersMask(t.Numbers))
+	}
+
+	c := Coverage{Guarantee: g, Missing: [][]int{}}
+	for _, target := range subsets(pool, g.IfDrawn) {
+		c.Targets++
+		if covers(masks, target, g.Match) {
+			c.Covered++
+			continue
+		}
+		if len(c.Missing) < maxMissing {
+			c.Missing = append(c.Missing, maskNumbers(target))
+		}
+	}
+	return c, nil
+}
+
+// 당첨수 3~6개마다 완전히 보장되는 최대 일치수 (보장이 없으면 Match 0)
+func Guarantees(tickets []lotto.Lotto, numbers []int) ([]Guarantee, error) {
+	pool, err := normalize(numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	// 당첨수마다 최대 drawn번 Verify를 돌리므로 합계로 미리 거른다
+	work := 0
+	for drawn := 3; drawn <= min(lotto.LottoSize, len(pool)); drawn++ {
+		work += drawn * verifyWork(len(pool), drawn, len(tickets))
+	}
+	if work > maxWork {
+		return nil, fmt.Errorf("%w: 번호 %d개, 티켓 %d장의 보장을 요약하기에는 조합이 너무 많습니다", ErrTooLarge, len(pool), len(tickets))
+	}
+
+	var result []Guarantee
+	for drawn := 3; drawn <= min(lotto.LottoSize, len(pool)); drawn++ {
+		g := Guarantee{IfDrawn: drawn}
+		for match := drawn; match >= 1; match-- {
+			c, err := Verify(tickets, pool, Guarantee{Match: match, IfDrawn: drawn})
+			if err != nil {
+				return nil, err
+			}
+			if c.Guaranteed() {
+				g.Match = match
+				break
+			}
+		}
+		result = append(result, g)
+	}
+	return result, nil
+}
+
+// 대상 조합을 펼치는 비용도 있으므로 티켓이 없어도 한 장으로 센다
+func verifyWork(poolSize, drawn, tickets int) int {
+	return binomial(poolSize, drawn) * max(tickets, 1)
+}
+
+func covers(tickets []uint64, target uint64, match int) bool {
+	for _, t := range tickets {
+		if bits.OnesCount64(t&target) >= match {
+			return true
+		}
+	}
+	return false
+}
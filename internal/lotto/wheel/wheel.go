@@ -0,0 +1,304 @@
+package wheel
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+var (
+	ErrInvalidNumbers   = errors.New("휠 번호가 올바르지 않습니다")
+	ErrInvalidGuarantee = errors.New("보장 조건이 올바르지 않습니다")
+	ErrTooLarge         = errors.New("조합이 너무 많습니다")
+	ErrUnknownMethod    = errors.New("지원하지 않는 휠 방식입니다")
+)
+
+const (
+	MaxTickets = 10_000     // 한 번에 만들 수 있는 최대 티켓 수
+	maxWork    = 20_000_000 // 축약 휠 탐색/보장 검증에서 허용하는 (후보 또는 티켓 × 대상) 조합 수
+	// 축약 휠 탐색 전체에서 허용하는 작업량 (티켓을 고를 때마다 후보 × 남은 대상을 더한 값)
+	// 한 번 고르는 작업은 maxWork 안이어도 티켓 수만큼 반복하므로 따로 제한한다
+	maxSearchWork = 200_000_000
+)
+
+// "내 번호 중 IfDrawn개가 당첨 번호에 있으면 적어도 한 장은 Match개 일치"
+// 예: {3, 4} = 4개가 나오면 5등(3개 일치) 이상 한 장 보장
+type Guarantee struct {
+	Match   int `json:"match"`
+	IfDrawn int `json:"ifDrawn"`
+}
+
+func (g Guarantee) String() string {
+	return fmt.Sprintf("%d-if-%d", g.Match, g.IfDrawn)
+}
+
+// 보장되는 일치 개수에 해당하는 등수 (보너스를 보지 않으므로 2등은 없음)
+func (g Guarantee) Rank() lotto.Rank {
+	return lotto.DetermineRank(g.Match, false)
+}
+
+// "3/4" 또는 "3-if-4" 형식
+func ParseGuarantee(input string) (Guarantee, error) {
+	input = strings.TrimSpace(input)
+	match, drawn, found := strings.Cut(input, "/")
+	if !found {
+		match, drawn, found = strings.Cut(input, "-if-")
+	}
+	if !found {
+		return Guarantee{}, fmt.Errorf("%w: 일치수/당첨수 형식이어야 합니다 (예: 3/4): %q", ErrInvalidGuarantee, input)
+	}
+
+	m, err1 := strconv.Atoi(strings.TrimSpace(match))
+	d, err2 := strconv.Atoi(strings.TrimSpace(drawn))
+	if err1 != nil || err2 != nil {
+		return Guarantee{}, fmt.Errorf("%w: 숫자가 아닌 값이 있습니다: %q", ErrInvalidGuarantee, input)
+	}
+	return Guarantee{Match: m, IfDrawn: d}, nil
+}
+
+func (g Guarantee) validate(pool int) error {
+	if g.Match < 1 || g.Match > g.IfDrawn || g.IfDrawn > lotto.LottoSize {
+		return fmt.Errorf("%w: 1 ≤ 일치수 ≤ 당첨수 ≤ %d 여야 합니다: %s", ErrInvalidGuarantee, lotto.LottoSize, g)
+	}
+	if g.IfDrawn > pool {
+		return fmt.Errorf("%w: 당첨수가 휠 번호 수(%d)보다 큽니다: %s", ErrInvalidGuarantee, pool, g)
+	}
+	return nil
+}
+
+// 휠 생성 요청
+type Request struct {
+	Method    string    `json:"method"`
+	Numbers   []int     `json:"numbers"`
+	Guarantee Guarantee `json:"guarantee"` // abbreviated
+	Tickets   int       `json:"tickets"`   // balanced: 만들 티켓 수
+}
+
+var generators = map[string]func(req Request) ([]lotto.Lotto, error){
+	"full":        func(req Request) ([]lotto.Lotto, error) { return Full(req.Numbers) },
+	"abbreviated": func(req Request) ([]lotto.Lotto, error) { return Abbreviated(req.Numbers, req.Guarantee) },
+	"balanced":    func(req Request) ([]lotto.Lotto, error) { return Balanced(req.Numbers, req.Tickets) },
+}
+
+func Generate(req Request) ([]lotto.Lotto, error) {
+	gen, exists := generators[req.Method]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s (%s)", ErrUnknownMethod, req.Method, strings.Join(Methods(), ", "))
+	}
+	return gen(req)
+}
+
+func Methods() []string {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 고른 번호로 만들 수 있는 모든 6개 조합
+func Full(numbers []int) ([]lotto.Lotto, error) {
+	pool, err := normalize(numbers)
+	if err != nil {
+		return nil, err
+	}
+	if n := binomial(len(pool), lotto.LottoSize); n > MaxTickets {
+		return nil, fmt.Errorf("%w: 전체 휠은 %d장입니다 (최대 %d장)", ErrTooLarge, n, MaxTickets)
+	}
+	return toLottos(subsets(pool, lotto.LottoSize))
+}
+
+// 보장 조건을 만족하는 티켓 묶음을 탐욕적으로 구성
+// 남은 대상(IfDrawn개 조합)을 가장 많이 덮는 티켓을 차례로 고르고,
+// 같으면 지금까지 덜 쓴 번호가 많은 티켓을 골라 번호 사용을 고르게 한다
+func Abbreviated(numbers []int, g Guarantee) ([]lotto.Lotto, error) {
+	pool, err := normalize(numbers)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.validate(len(pool)); err != nil {
+		return nil, err
+	}
+
+	// 조합을 펼치기 전에 크기만 계산해 거른다
+	if work := binomial(len(pool), lotto.LottoSize) * binomial(len(pool), g.IfDrawn); work > maxWork {
+		return nil, fmt.Errorf("%w: 번호 %d개로 %s 보장을 찾기에는 조합이 너무 많습니다", ErrTooLarge, len(pool), g)
+	}
+	candidates := subsets(pool, lotto.LottoSize)
+	targets := subsets(pool, g.IfDrawn)
+
+	uncovered := targets
+	usage := make(map[int]int, len(pool))
+	var chosen []uint64
+
+	work := 0
+	for len(uncovered) > 0 {
+		if work += len(candidates) * len(uncovered); work > maxSearchWork {
+			return nil, fmt.Errorf("%w: 번호 %d개로 %s 보장을 찾기에는 작업량이 너무 많습니다 (%d장에서 중단)", ErrTooLarge, len(pool), g, len(chosen))
+		}
+
+		best, bestCount, bestUsage := uint64(0), -1, 0
+		for _, c := range candidates {
+			count := 0
+			for _, t := range uncovered {
+				if bits.OnesCount64(c&t) >= g.Match {
+					count++
+				}
+			}
+			used := maskUsage(c, usage)
+			if count > bestCount || (count == bestCount && used < bestUsage) {
+				best, bestCount, bestUsage = c, count, used
+			}
+		}
+
+		chosen = append(chosen, best)
+		for _, n := range maskNumbers(best) {
+			usage[n]++
+		}
+		uncovered = slices.DeleteFunc(uncovered, func(t uint64) bool {
+			return bits.OnesCount64(best&t) >= g.Match
+		})
+	}
+
+	return toLottos(chosen)
+}
+
+// 번호마다 쓰인 횟수가 최대 1 차이 나도록 count장 구성
+// 같은 티켓에 이미 함께 나온 번호 쌍은 피해서 쌍도 고르게 퍼뜨린다
+func Balanced(numbers []int, count int) ([]lotto.Lotto, error) {
+	pool, err := normalize(numbers)
+	if err != nil {
+		return nil, err
+	}
+	if count <= 0 || count > MaxTickets {
+		return nil, fmt.Errorf("%w: 티켓 수는 1~%d장이어야 합니다: %d", ErrTooLarge, MaxTickets, count)
+	}
+
+	usage := make(map[int]int, len(pool))
+	pairs := make(map[[2]int]int)
+	tickets := make([]uint64, 0, count)
+
+	for range count {
+		var picked []int
+		for len(picked) < lotto.LottoSize {
+			best, bestScore := 0, -1
+			for _, n := range pool {
+				if slices.Contains(picked, n) {
+					continue
+				}
+				score := usage[n] * 1_000
+				for _, p := range picked {
+					score += pairs[[2]int{min(n, p), max(n, p)}]
+				}
+				if bestScore < 0 || score < bestScore {
+					best, bestScore = n, score
+				}
+			}
+			picked = append(picked, best)
+		}
+
+		for i, n := range picked {
+			usage[n]++
+			for _, p := range picked[:i] {
+				pairs[[2]int{min(n, p), max(n, p)}]++
+			}
+		}
+		tickets = append(tickets, numbersMask(picked))
+	}
+
+	return toLottos(tickets)
+}
+
+// 번호 범위/중복 검사 후 오름차순 정렬
+func normalize(numbers []int) ([]int, error) {
+	if len(numbers) < lotto.LottoSize {
+		return nil, fmt.Errorf("%w: 번호는 %d개 이상이어야 합니다: %d개", ErrInvalidNumbers, lotto.LottoSize, len(numbers))
+	}
+
+	pool := slices.Clone(numbers)
+	sort.Ints(pool)
+	for i, n := range pool {
+		if n < lotto.LottoMinNum || n > lotto.LottoMaxNum {
+			return nil, fmt.Errorf("%w: %d~%d 범위를 벗어났습니다: %d", ErrInvalidNumbers, lotto.LottoMinNum, lotto.LottoMaxNum, n)
+		}
+		if i > 0 && pool[i-1] == n {
+			return nil, fmt.Errorf("%w: 중복된 번호가 있습니다: %d", ErrInvalidNumbers, n)
+		}
+	}
+	return pool, nil
+}
+
+// 기존 티켓 검증을 거쳐 Lotto로 변환
+func toLottos(masks []uint64) ([]lotto.Lotto, error) {
+	tickets := make([]lotto.Lotto, 0, len(masks))
+	for _, m := range masks {
+		ticket, err := lotto.NewLotto(maskNumbers(m))
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, nil
+}
+
+// 번호 n은 n번째 비트 (1~45이므로 uint64에 들어간다)
+func numbersMask(numbers []int) uint64 {
+	var m uint64
+	for _, n := range numbers {
+		m |= 1 << n
+	}
+	return m
+}
+
+func maskNumbers(m uint64) []int {
+	numbers := make([]int, 0, bits.OnesCount64(m))
+	for m != 0 {
+		n := bits.TrailingZeros64(m)
+		numbers = append(numbers, n)
+		m &^= 1 << n
+	}
+	return numbers
+}
+
+func maskUsage(m uint64, usage map[int]int) int {
+	total := 0
+	for _, n := range maskNumbers(m) {
+		total += usage[n]
+	}
+	return total
+}
+
+// pool에서 k개를 고르는 모든 조합 (사전순)
+func subsets(pool []int, k int) []uint64 {
+	var result []uint64
+	var walk func(start int, m uint64, left int)
+	walk = func(start int, m uint64, left int) {
+		if left == 0 {
+			result = append(result, m)
+			return
+		}
+		for i := start; i <= len(pool)-left; i++ {
+			walk(i+1, m|1<<pool[i], left-1)
+		}
+	}
+	walk(0, 0, k)
+	return result
+}
+
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+	return result
+}
@@ -0,0 +1,199 @@
+package wheel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+var ten = []int{3, 8, 11, 17, 21, 26, 30, 34, 39, 44}
+
+func TestFull(t *testing.T) {
+	tickets, err := Full([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	if err != nil {
+		t.Fatalf("전체 휠 생성 중 에러가 발생했습니다: %v", err)
+	}
+	if len(tickets) != 28 {
+		t.Errorf("8개 번호의 전체 휠은 28장이어야 합니다. got=%d", len(tickets))
+	}
+	assertValid(t, tickets)
+
+	c, err := Verify(tickets, []int{1, 2, 3, 4, 5, 6, 7, 8}, Guarantee{Match: 6, IfDrawn: 6})
+	if err != nil || !c.Guaranteed() {
+		t.Errorf("전체 휠은 6-if-6을 보장해야 합니다. got=%+v, err=%v", c, err)
+	}
+
+	if _, err := Full(make20()); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("조합이 너무 많으면 ErrTooLarge여야 합니다. got=%v", err)
+	}
+}
+
+func TestAbbreviated(t *testing.T) {
+	tests := []Guarantee{
+		{Match: 3, IfDrawn: 3},
+		{Match: 3, IfDrawn: 4},
+		{Match: 4, IfDrawn: 5},
+		{Match: 4, IfDrawn: 6},
+	}
+
+	for _, g := range tests {
+		t.Run(g.String(), func(t *testing.T) {
+			tickets, err := Abbreviated(ten, g)
+			if err != nil {
+				t.Fatalf("축약 휠 생성 중 에러가 발생했습니다: %v", err)
+			}
+			assertValid(t, tickets)
+
+			if len(tickets) >= 210 {
+				t.Errorf("축약 휠은 전체 휠(210장)보다 작아야 합니다. got=%d", len(tickets))
+			}
+			c, err := Verify(tickets, ten, g)
+			if err != nil || !c.Guaranteed() {
+				t.Errorf("보장 조건을 만족해야 합니다. got=%+v, err=%v", c, err)
+			}
+		})
+	}
+
+	if g := (Guarantee{Match: 3, IfDrawn: 4}); g.Rank() != lotto.Rank5 {
+		t.Errorf("3개 일치 보장은 5등이어야 합니다. got=%v", g.Rank())
+	}
+}
+
+func TestBalanced(t *testing.T) {
+	tickets, err := Balanced(ten, 5)
+	if err != nil {
+		t.Fatalf("균형 휠 생성 중 에러가 발생했습니다: %v", err)
+	}
+	assertValid(t, tickets)
+
+	usage := make(map[int]int)
+	for _, ticket := range tickets {
+		for _, n := range ticket.Numbers {
+			usage[n]++
+		}
+	}
+	low, high := len(tickets), 0
+	for _, n := range ten {
+		low, high = min(low, usage[n]), max(high, usage[n])
+	}
+	if high-low > 1 {
+		t.Errorf("번호별 사용 횟수 차이는 1 이하여야 합니다. got=%v", usage)
+	}
+}
+
+func TestVerifyReportsMissing(t *testing.T) {
+	tickets := []lotto.Lotto{{Numbers: []int{3, 8, 11, 17, 21, 26}}}
+
+	c, err := Verify(tickets, ten, Guarantee{Match: 3, IfDrawn: 4})
+	if err != nil {
+		t.Fatalf("검증 중 에러가 발생했습니다: %v", err)
+	}
+	if c.Guaranteed() || c.Targets != 210 || len(c.Missing) == 0 {
+		t.Errorf("한 장으로는 보장할 수 없어야 합니다. got=%+v", c)
+	}
+
+	gs, err := Guarantees(tickets, []int{3, 8, 11, 17, 21, 26})
+	if err != nil {
+		t.Fatalf("보장 요약 중 에러가 발생했습니다: %v", err)
+	}
+	for _, g := range gs {
+		if g.Match != g.IfDrawn {
+			t.Errorf("휠 번호가 6개면 나온 만큼 모두 맞아야 합니다. got=%s", g)
+		}
+	}
+}
+
+// 조합을 펼치기 전이나 탐색 도중에 작업량으로 거르는지 검증
+func TestWorkLimit(t *testing.T) {
+	pool := make([]int, lotto.LottoMaxNum)
+	for i := range pool {
+		pool[i] = i + 1
+	}
+	tickets := []lotto.Lotto{{Numbers: []int{1, 2, 3, 4, 5, 6}}, {Numbers: []int{7, 8, 9, 10, 11, 12}}, {Numbers: []int{13, 14, 15, 16, 17, 18}}}
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{"축약 휠", func() error {
+			_, err := Abbreviated(make20(), Guarantee{Match: 3, IfDrawn: 6})
+			return err
+		}},
+		{"축약 휠 반복 탐색", func() error {
+			// 한 번 고르는 작업은 작아도 수백 장을 골라야 해서 전체 작업량으로 거른다
+			if work := binomial(15, lotto.LottoSize) * binomial(15, 5); work > maxWork {
+				t.Fatalf("한 번 고르는 작업량(%d)이 maxWork 안이어야 하는 경우입니다", work)
+			}
+			_, err := Abbreviated(pool[:15], Guarantee{Match: 5, IfDrawn: 5})
+			return err
+		}},
+		{"보장 검증", func() error {
+			_, err := Verify(tickets, pool, Guarantee{Match: 3, IfDrawn: 6})
+			return err
+		}},
+		{"보장 요약", func() error {
+			_, err := Guarantees(tickets, pool)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, ErrTooLarge) {
+				t.Errorf("작업량이 너무 크면 ErrTooLarge여야 합니다. got=%v", err)
+			}
+		})
+	}
+}
+
+func TestInvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		req  Request
+		want error
+	}{
+		{"번호 부족", Request{Method: "full", Numbers: []int{1, 2, 3}}, ErrInvalidNumbers},
+		{"중복 번호", Request{Method: "full", Numbers: []int{1, 1, 2, 3, 4, 5, 6}}, ErrInvalidNumbers},
+		{"범위 밖", Request{Method: "full", Numbers: []int{0, 1, 2, 3, 4, 5}}, ErrInvalidNumbers},
+		{"보장 조건 역전", Request{Method: "abbreviated", Numbers: ten, Guarantee: Guarantee{Match: 5, IfDrawn: 4}}, ErrInvalidGuarantee},
+		{"없는 방식", Request{Method: "magic", Numbers: ten}, ErrUnknownMethod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Generate(tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("에러가 예상과 다릅니다. got=%v, want=%v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGuarantee(t *testing.T) {
+	for _, input := range []string{"3/4", "3-if-4", " 3 / 4 "} {
+		g, err := ParseGuarantee(input)
+		if err != nil || g != (Guarantee{Match: 3, IfDrawn: 4}) {
+			t.Errorf("%q 해석 결과가 예상과 다릅니다. got=%+v, err=%v", input, g, err)
+		}
+	}
+	if _, err := ParseGuarantee("3"); !errors.Is(err, ErrInvalidGuarantee) {
+		t.Errorf("형식이 틀리면 ErrInvalidGuarantee여야 합니다. got=%v", err)
+	}
+}
+
+func assertValid(t *testing.T, tickets []lotto.Lotto) {
+	t.Helper()
+	for _, ticket := range tickets {
+		if _, err := lotto.NewLotto(ticket.Numbers); err != nil {
+			t.Fatalf("기존 검증을 통과해야 합니다. got=%v, err=%v", ticket.Numbers, err)
+		}
+	}
+}
+
+func make20() []int {
+	numbers := make([]int, 20)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+	return numbers
+}
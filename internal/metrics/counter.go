@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+)
+
+// 늘어나기만 하는 값
+type Counter struct {
+	v atomicFloat
+}
+
+func (c *Counter) Inc() { c.v.add(1) }
+
+// v는 0 이상이어야 한다 (음수면 패닉)
+func (c *Counter) Add(v float64) {
+	if v < 0 {
+		panic("metrics: 카운터는 줄어들 수 없습니다")
+	}
+	c.v.add(v)
+}
+
+func (c *Counter) Value() float64 { return c.v.load() }
+
+// 레이블별 카운터
+type CounterVec struct {
+	s *series[Counter]
+}
+
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{s: newSeries(desc{name, help, labels}, func() *Counter { return &Counter{} })}
+	r.register(c)
+	return c
+}
+
+func (r *Registry) NewCounter(name, help string) *Counter {
+	return r.NewCounterVec(name, help).With()
+}
+
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return Default.NewCounterVec(name, help, labels...)
+}
+
+func NewCounter(name, help string) *Counter {
+	return Default.NewCounter(name, help)
+}
+
+// 레이블 값 순서는 만들 때 준 레이블 이름 순서와 같다
+func (c *CounterVec) With(values ...string) *Counter { return c.s.with(values) }
+
+func (c *CounterVec) header() (string, string, string) { return c.s.name, c.s.help, "counter" }
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.s.each(func(labels []string, v *Counter) {
+		fmt.Fprintf(w, "%s%s %s\n", c.s.name, c.s.labelString(labels), formatFloat(v.Value()))
+	})
+}
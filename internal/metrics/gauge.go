@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+)
+
+// 오르내리는 값
+type Gauge struct {
+	v atomicFloat
+}
+
+func (g *Gauge) Set(v float64) { g.v.set(v) }
+func (g *Gauge) Add(v float64) { g.v.add(v) }
+func (g *Gauge) Inc()          { g.v.add(1) }
+func (g *Gauge) Dec()          { g.v.add(-1) }
+
+func (g *Gauge) Value() float64 { return g.v.load() }
+
+// 레이블별 게이지
+type GaugeVec struct {
+	s *series[Gauge]
+}
+
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{s: newSeries(desc{name, help, labels}, func() *Gauge { return &Gauge{} })}
+	r.register(g)
+	return g
+}
+
+func (r *Registry) NewGauge(name, help string) *Gauge {
+	return r.NewGaugeVec(name, help).With()
+}
+
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return Default.NewGaugeVec(name, help, labels...)
+}
+
+func NewGauge(name, help string) *Gauge {
+	return Default.NewGauge(name, help)
+}
+
+func (g *GaugeVec) With(values ...string) *Gauge { return g.s.with(values) }
+
+func (g *GaugeVec) header() (string, string, string) { return g.s.name, g.s.help, "gauge" }
+
+func (g *GaugeVec) write(w *bufio.Writer) {
+	g.s.each(func(labels []string, v *Gauge) {
+		fmt.Fprintf(w, "%s%s %s\n", g.s.name, g.s.labelString(labels), formatFloat(v.Value()))
+	})
+}
+
+// 내보낼 때마다 fn을 불러 값을 읽는 게이지 (작업 대기열 길이처럼 다른 곳이 가진 값)
+type gaugeFunc struct {
+	desc
+	fn func() float64
+}
+
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) {
+	r.register(&gaugeFunc{desc: desc{name: name, help: help}, fn: fn})
+}
+
+func NewGaugeFunc(name, help string, fn func() float64) {
+	Default.NewGaugeFunc(name, help, fn)
+}
+
+func (g *gaugeFunc) header() (string, string, string) { return g.name, g.help, "gauge" }
+
+func (g *gaugeFunc) write(w *bufio.Writer) {
+	fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(g.fn()))
+}
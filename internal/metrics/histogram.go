@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// 요청 처리 시간(초)에 맞춘 기본 구간
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// 관측값 분포 (구간별 누적 개수, 합, 개수)
+type Histogram struct {
+	upper  []float64 // 구간 상한 (오름차순, +Inf 제외)
+	counts []atomic.Uint64
+	count  atomic.Uint64
+	sum    atomicFloat
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	return &Histogram{upper: buckets, counts: make([]atomic.Uint64, len(buckets))}
+}
+
+func (h *Histogram) Observe(v float64) {
+	// 상한이 v 이상인 첫 구간에만 세고, 내보낼 때 누적한다
+	if i, _ := slices.BinarySearch(h.upper, v); i < len(h.upper) {
+		h.counts[i].Add(1)
+	}
+	h.count.Add(1)
+	h.sum.add(v)
+}
+
+// 레이블별 히스토그램
+type HistogramVec struct {
+	s *series[Histogram]
+}
+
+// buckets가 비어 있으면 DefBuckets
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	h := &HistogramVec{s: newSeries(desc{name, help, labels}, func() *Histogram { return newHistogram(buckets) })}
+	r.register(h)
+	return h
+}
+
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return Default.NewHistogramVec(name, help, buckets, labels...)
+}
+
+func (h *HistogramVec) With(values ...string) *Histogram { return h.s.with(values) }
+
+func (h *HistogramVec) header() (string, string, string) { return h.s.name, h.s.help, "histogram" }
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	name := h.s.name
+	h.s.each(func(labels []string, v *Histogram) {
+		var cumulative uint64
+		for i, upper := range v.upper {
+			cumulative += v.counts[i].Load()
+			fmt.Fprintf(w, "%s_bucket%s %d\n", name, h.s.labelString(labels, "le", formatFloat(upper)), cumulative)
+		}
+		// 관측 중인 값이 구간에만 먼저 세어졌을 수 있으므로 개수는 누적값보다 작지 않게
+		count := max(v.count.Load(), cumulative)
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, h.s.labelString(labels, "le", "+Inf"), count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", name, h.s.labelString(labels), formatFloat(v.sum.load()))
+		fmt.Fprintf(w, "%s_count%s %d\n", name, h.s.labelString(labels), count)
+	})
+}
@@ -0,0 +1,226 @@
+// Package metrics는 외부 의존성 없이 Prometheus 텍스트 형식(0.0.4)으로 내보내는
+// 카운터, 게이지, 히스토그램을 제공한다
+//
+// 패키지 함수(NewCounter 등)로 만든 지표는 Default 레지스트리에 바로 등록되며,
+// 같은 이름을 두 번 등록하면 패닉한다 (expvar와 같은 방식)
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// /metrics 응답의 Content-Type
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// 지표 묶음. http.Handler로 바로 노출할 수 있다
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]family
+}
+
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]family)}
+}
+
+// 서버 전체가 공유하는 레지스트리
+var Default = NewRegistry()
+
+// 이름이 같은 시계열 묶음 (# HELP/# TYPE 하나)
+type family interface {
+	header() (name, help, typ string)
+	write(w *bufio.Writer)
+}
+
+func (r *Registry) register(f family) {
+	name, _, _ := f.header()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.families[name]; dup {
+		panic("metrics: 이미 등록된 지표입니다: " + name)
+	}
+	r.families[name] = f
+}
+
+// 모든 지표를 이름순으로 텍스트 형식으로 쓴다
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	families := make([]family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+
+	slices.SortFunc(families, func(a, b family) int {
+		an, _, _ := a.header()
+		bn, _, _ := b.header()
+		return strings.Compare(an, bn)
+	})
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for _, f := range families {
+		name, help, typ := f.header()
+		fmt.Fprintf(bw, "# HELP %s %s\n", name, escapeHelp(help))
+		fmt.Fprintf(bw, "# TYPE %s %s\n", name, typ)
+		f.write(bw)
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_, _ = r.WriteTo(w)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// 이름, 설명, 레이블 이름
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+// 레이블 값 조합별 시계열 (레이블이 없으면 하나뿐)
+type series[T any] struct {
+	desc
+	mu     sync.RWMutex
+	values map[string]*T
+	keys   map[string][]string // key -> 레이블 값
+	newT   func() *T
+}
+
+func newSeries[T any](d desc, newT func() *T) *series[T] {
+	return &series[T]{desc: d, values: make(map[string]*T), keys: make(map[string][]string), newT: newT}
+}
+
+func (s *series[T]) with(values []string) *T {
+	if len(values) != len(s.labels) {
+		panic(fmt.Sprintf("metrics: %s 레이블 값 개수가 %d개여야 합니다 (받은 값 %d개)", s.name, len(s.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	s.mu.RLock()
+	v, ok := s.values[key]
+	s.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.values[key]; ok {
+		return v
+	}
+	v = s.newT()
+	s.values[key] = v
+	s.keys[key] = slices.Clone(values)
+	return v
+}
+
+// 레이블 값 순으로 정렬해 하나씩 넘긴다
+func (s *series[T]) each(fn func(labels []string, v *T)) {
+	s.mu.RLock()
+	keys := make([]string, 0, len(s.values))
+	for k := range s.values {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	type entry struct {
+		labels []string
+		v      *T
+	}
+	entries := make([]entry, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, entry{s.keys[k], s.values[k]})
+	}
+	s.mu.RUnlock()
+
+	for _, e := range entries {
+		fn(e.labels, e.v)
+	}
+}
+
+// 레이블 쌍을 {a="x",b="y"} 형태로. extra는 히스토그램의 le 같은 추가 레이블
+func (d desc) labelString(values []string, extra ...string) string {
+	if len(values) == 0 && len(extra) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `%s="%s"`, d.labels[i], escapeLabel(v))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `%s="%s"`, extra[i], escapeLabel(extra[i+1]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// 동시에 더할 수 있는 float64
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) add(v float64) {
+	for {
+		old := f.bits.Load()
+		if f.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+v)) {
+			return
+		}
+	}
+}
+
+func (f *atomicFloat) set(v float64) {
+	f.bits.Store(math.Float64bits(v))
+}
+
+func (f *atomicFloat) load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func exposition(t *testing.T, r *Registry) string {
+	t.Helper()
+	var b strings.Builder
+	if _, err := r.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	return b.String()
+}
+
+func TestRegistryExposition(t *testing.T) {
+	r := NewRegistry()
+	requests := r.NewCounterVec("test_requests_total", "요청 수", "route", "status")
+	busy := r.NewGauge("test_busy", "실행 중인 수")
+	latency := r.NewHistogramVec("test_latency_seconds", "처리 시간", []float64{0.1, 1}, "route")
+	r.NewGaugeFunc("test_queue", "대기 중인 수", func() float64 { return 3 })
+
+	requests.With("/b", "200").Inc()
+	requests.With("/a", "500").Add(2)
+	requests.With("/b", "200").Inc()
+	busy.Inc()
+	busy.Inc()
+	busy.Dec()
+	latency.With("/a").Observe(0.05)
+	latency.With("/a").Observe(0.5)
+	latency.With("/a").Observe(3)
+
+	want := `# HELP test_busy 실행 중인 수
+# TYPE test_busy gauge
+test_busy 1
+# HELP test_latency_seconds 처리 시간
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{route="/a",le="0.1"} 1
+test_latency_seconds_bucket{route="/a",le="1"} 2
+test_latency_seconds_bucket{route="/a",le="+Inf"} 3
+test_latency_seconds_sum{route="/a"} 3.55
+test_latency_seconds_count{route="/a"} 3
+# HELP test_queue 대기 중인 수
+# TYPE test_queue gauge
+test_queue 3
+# HELP test_requests_total 요청 수
+# TYPE test_requests_total counter
+test_requests_total{route="/a",status="500"} 2
+test_requests_total{route="/b",status="200"} 2
+`
+	if got := exposition(t, r); got != want {
+		t.Errorf("출력이 다릅니다\n--- 결과\n%s--- 기대값\n%s", got, want)
+	}
+}
+
+func TestEscaping(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("test_total", "줄\n바꿈과 \\", "v").With("a\"b\\c\nd").Inc()
+
+	got := exposition(t, r)
+	for _, want := range []string{
+		`# HELP test_total 줄\n바꿈과 \\`,
+		`test_total{v="a\"b\\c\nd"} 1`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%q가 없습니다\n%s", want, got)
+		}
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(r *Registry)
+	}{
+		{"같은 이름 두 번 등록", func(r *Registry) {
+			r.NewCounter("dup_total", "")
+			r.NewGauge("dup_total", "")
+		}},
+		{"레이블 값 개수 불일치", func(r *Registry) {
+			r.NewCounterVec("labels_total", "", "a", "b").With("x")
+		}},
+		{"카운터 감소", func(r *Registry) {
+			r.NewCounter("neg_total", "").Add(-1)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("패닉이 나야 합니다")
+				}
+			}()
+			tt.fn(NewRegistry())
+		})
+	}
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("test_total", "", "worker")
+	h := r.NewHistogramVec("test_seconds", "", nil)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				c.With(string(rune('a' + i%2))).Inc()
+				h.With().Observe(0.01)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := c.With("a").Value() + c.With("b").Value(); got != 8000 {
+		t.Errorf("합계 = %v, 기대값 8000", got)
+	}
+	if !strings.Contains(exposition(t, r), "test_seconds_count 8000\n") {
+		t.Errorf("히스토그램 개수가 맞지 않습니다\n%s", exposition(t, r))
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("test_total", "").Inc()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q, 기대값 %q", ct, ContentType)
+	}
+	if !strings.Contains(rec.Body.String(), "test_total 1\n") {
+		t.Errorf("본문 = %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// 요청 하나가 끝날 때마다 구조화된 접근 로그를 남긴다
+// 5xx는 Error, 나머지는 Info 수준. 이벤트 스트림은 스트림이 닫힐 때 남는다
+func AccessLog(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := newResponseWriter(w)
+			next.ServeHTTP(rw, r)
+
+			status := rw.statusCode()
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(r.Context(), level, "http 요청",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Int64("bytes", rw.bytes),
+				slog.Duration("duration", time.Since(start)),
+				slog.String("remote", r.RemoteAddr),
+				slog.String("request_id", RequestIDFrom(r.Context())),
+			)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+)
+
+const bodyTooLargeMsg = "요청 본문이 너무 큽니다"
+
+// 요청 본문을 n바이트로 제한한다 (0 이하면 제한하지 않음)
+// Content-Length가 이미 넘으면 writeError로 바로 413을 쓰고,
+// 모르면 본문 읽기가 *http.MaxBytesError로 실패하므로 핸들러가 413으로 응답한다 (IsBodyTooLarge)
+func LimitBody(n int64, writeError StatusErrorWriter) Middleware {
+	return func(next http.Handler) http.Handler {
+		if n <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > n {
+				writeError(w, r, http.StatusRequestEntityTooLarge, bodyTooLargeMsg)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, n)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// 본문 크기 제한을 넘어서 난 에러인지
+func IsBodyTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr)
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/metrics"
+)
+
+var (
+	httpRequests = metrics.NewCounterVec(
+		"lotto_http_requests_total",
+		"처리한 HTTP 요청 수 (등록된 경로 패턴별)",
+		"method", "route", "status",
+	)
+	httpDuration = metrics.NewHistogramVec(
+		"lotto_http_request_duration_seconds",
+		"HTTP 요청 처리 시간 (초). 이벤트 스트림은 연결이 닫힐 때까지",
+		nil,
+		"method", "route",
+	)
+	httpInFlight = metrics.NewGauge(
+		"lotto_http_requests_in_flight",
+		"처리 중인 HTTP 요청 수",
+	)
+)
+
+// 경로 패턴별 요청 수와 처리 시간을 기록한다
+// route는 실제 경로가 아니라 등록된 패턴(/api/jobs/{id} 등)이라 시계열 수가 늘어나지 않는다
+func Metrics() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			httpInFlight.Inc()
+			defer httpInFlight.Dec()
+
+			rw := newResponseWriter(w)
+			next.ServeHTTP(rw, r)
+
+			method, route := methodLabel(r.Method), Route(r)
+			httpRequests.With(method, route, strconv.Itoa(rw.statusCode())).Inc()
+			httpDuration.With(method, route).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// 요청과 맞은 경로 패턴 (메서드 제외, 예: /api/jobs/{id}). 라우터를 거치지 않았으면 "unmatched"
+func Route(r *http.Request) string {
+	return routeLabel(r.Pattern)
+}
+
+// "GET /api/jobs/{id}" -> "/api/jobs/{id}"
+func routeLabel(pattern string) string {
+	if pattern == "" {
+		return "unmatched"
+	}
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		return strings.TrimSpace(path)
+	}
+	return pattern
+}
+
+// 경로 패턴에 메서드가 없으면 아무 메서드나 들어오므로 알려진 것만 그대로 쓴다
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		return method
+	}
+	return "OTHER"
+}
@@ -0,0 +1,98 @@
+// Package middleware는 HTTP 핸들러에 공통으로 씌우는 미들웨어
+// (요청 ID, 접근 로그, 패닉 복구, 요청 본문 크기 제한)를 제공한다
+package middleware
+
+import (
+	"net/http"
+	"slices"
+)
+
+// 핸들러를 감싸 새 핸들러를 만든다
+type Middleware func(http.Handler) http.Handler
+
+// 미들웨어 여러 개를 하나로 묶는다. 앞에 있는 것이 바깥쪽(먼저 실행)
+func Chain(mws ...Middleware) Middleware {
+	return func(h http.Handler) http.Handler {
+		for _, mw := range slices.Backward(mws) {
+			h = mw(h)
+		}
+		return h
+	}
+}
+
+// 라우트를 등록하는 대상 (*http.ServeMux가 만족)
+type Router interface {
+	Handle(pattern string, handler http.Handler)
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+// 등록하는 모든 핸들러에 미들웨어를 씌우는 Router
+// 등록기마다 다른 미들웨어를 쓰려면 등록기별로 감싸서 넘긴다
+func Wrap(r Router, mws ...Middleware) Router {
+	return &wrappedRouter{router: r, chain: Chain(mws...)}
+}
+
+type wrappedRouter struct {
+	router Router
+	chain  Middleware
+}
+
+func (w *wrappedRouter) Handle(pattern string, handler http.Handler) {
+	w.router.Handle(pattern, w.chain(handler))
+}
+
+func (w *wrappedRouter) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	w.Handle(pattern, http.HandlerFunc(handler))
+}
+
+// 상태 코드와 쓴 바이트 수를 기록하는 ResponseWriter
+// 이벤트 스트림(SSE)을 위해 Flush를 지원하고, http.ResponseController가 원래 writer에 닿도록 Unwrap을 제공한다
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	return &responseWriter{ResponseWriter: w}
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// 헤더를 이미 보냈는지
+func (w *responseWriter) written() bool {
+	return w.status != 0
+}
+
+// 아무것도 쓰지 않고 끝난 응답은 200
+func (w *responseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
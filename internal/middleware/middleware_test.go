@@ -0,0 +1,321 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestChainOrder(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	mux := http.NewServeMux()
+	Wrap(mux, mark("a"), mark("b")).HandleFunc("/x", func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	})
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/x", nil))
+
+	if got := strings.Join(order, ","); got != "a,b,handler" {
+		t.Errorf("실행 순서 = %s, 기대값 a,b,handler", got)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "없으면 새로 만듦", incoming: "", keep: false},
+		{name: "올바른 ID는 유지", incoming: "edge-1234.abc", keep: true},
+		{name: "허용하지 않는 문자", incoming: "a b\nc", keep: false},
+		{name: "너무 긴 ID", incoming: strings.Repeat("a", maxRequestIDLen+1), keep: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromCtx string
+			h := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fromCtx = RequestIDFrom(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(RequestIDHeader)
+			if got == "" || got != fromCtx {
+				t.Fatalf("응답 헤더 ID %q, context ID %q: 같은 값이어야 합니다", got, fromCtx)
+			}
+			if (got == tt.incoming) != tt.keep {
+				t.Errorf("ID = %q, 받은 값 %q 유지 여부 기대값 %v", got, tt.incoming, tt.keep)
+			}
+		})
+	}
+}
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		name        string
+		writeError  ErrorWriter
+		contentType string
+	}{
+		{name: "API는 JSON", writeError: JSONError, contentType: "application/json"},
+		{name: "웹 화면은 HTML", writeError: HTMLError, contentType: "text/html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Chain(RequestID(), Recover(discardLogger(), tt.writeError))(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					panic("boom")
+				}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(RequestIDHeader, "req-1")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusInternalServerError {
+				t.Errorf("상태 코드 = %d, 기대값 500", rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.contentType) {
+				t.Errorf("Content-Type = %q, 기대값 %s", ct, tt.contentType)
+			}
+			if !strings.Contains(rec.Body.String(), "req-1") {
+				t.Errorf("본문에 요청 ID가 없습니다: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRecoverJSONBody(t *testing.T) {
+	h := Chain(RequestID(), Recover(discardLogger(), JSONError))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var body errorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("JSON이 아닙니다: %v (%s)", err, rec.Body.String())
+	}
+	if body.Error == "" || body.RequestID != rec.Header().Get(RequestIDHeader) {
+		t.Errorf("본문 = %+v, 헤더 ID = %q", body, rec.Header().Get(RequestIDHeader))
+	}
+}
+
+func TestRecoverAfterWrite(t *testing.T) {
+	h := Recover(discardLogger(), JSONError)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+		panic("boom")
+	}))
+
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("응답을 쓰기 시작한 뒤 패닉은 연결을 끊어야 합니다: recover() = %v", v)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestLimitBody(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int64
+		body          string
+		unknownLength bool // Content-Length 없이 보내는 경우
+		wantStatus    int
+	}{
+		{name: "제한 이내", limit: 10, body: "0123456789", wantStatus: http.StatusOK},
+		{name: "Content-Length가 제한 초과", limit: 10, body: "0123456789a", wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "읽다가 제한 초과", limit: 10, body: "0123456789a", unknownLength: true, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "0이면 제한 없음", limit: 0, body: strings.Repeat("a", 1000), wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := LimitBody(tt.limit, WriteJSONError)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if _, err := io.ReadAll(r.Body); err != nil {
+					if IsBodyTooLarge(err) {
+						w.WriteHeader(http.StatusRequestEntityTooLarge)
+						return
+					}
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			if tt.unknownLength {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("상태 코드 = %d, 기대값 %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// Content-Length만 보고 바로 거절할 때도 등록기에 맞는 형식으로 응답한다
+func TestLimitBodyErrorWriter(t *testing.T) {
+	tests := []struct {
+		name        string
+		writeError  StatusErrorWriter
+		contentType string
+	}{
+		{name: "API는 JSON", writeError: WriteJSONError, contentType: "application/json"},
+		{name: "웹 화면은 HTML", writeError: WriteHTMLError, contentType: "text/html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Chain(RequestID(), LimitBody(4, tt.writeError))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Error("제한을 넘은 요청이 핸들러까지 왔습니다")
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too large"))
+			req.Header.Set(RequestIDHeader, "req-413")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusRequestEntityTooLarge {
+				t.Errorf("상태 코드 = %d, 기대값 413", rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.contentType) {
+				t.Errorf("Content-Type = %q, 기대값 %s", ct, tt.contentType)
+			}
+			if !strings.Contains(rec.Body.String(), "req-413") || !strings.Contains(rec.Body.String(), bodyTooLargeMsg) {
+				t.Errorf("본문에 메시지와 요청 ID가 없습니다: %s", rec.Body.String())
+			}
+		})
+	}
+
+	h := Chain(RequestID(), LimitBody(4, WriteJSONError))(http.NotFoundHandler())
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too large"))
+	req.Header.Set(RequestIDHeader, "req-413")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var body errorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("JSON 본문이 아닙니다: %v (%s)", err, rec.Body.String())
+	}
+	if body != (errorResponse{Error: bodyTooLargeMsg, RequestID: "req-413"}) {
+		t.Errorf("본문 = %+v", body)
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	h := Chain(RequestID(), AccessLog(logger))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("hello"))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/round", nil)
+	req.Header.Set(RequestIDHeader, "req-2")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("로그가 JSON 한 줄이 아닙니다: %v (%s)", err, buf.String())
+	}
+	want := map[string]any{
+		"method":     "POST",
+		"path":       "/api/round",
+		"status":     float64(http.StatusTeapot),
+		"bytes":      float64(5),
+		"request_id": "req-2",
+	}
+	for k, v := range want {
+		if entry[k] != v {
+			t.Errorf("로그 %s = %v, 기대값 %v", k, entry[k], v)
+		}
+	}
+}
+
+// 이벤트 스트림 핸들러는 감싼 writer에서도 Flush할 수 있어야 한다
+func TestResponseWriterFlush(t *testing.T) {
+	h := Chain(AccessLog(discardLogger()), Recover(discardLogger(), JSONError))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			flusher, ok := w.(http.Flusher)
+			if !ok {
+				t.Fatal("http.Flusher를 지원하지 않습니다")
+			}
+			_, _ = w.Write([]byte("data: 1\n\n"))
+			flusher.Flush()
+		}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !rec.Flushed {
+		t.Error("Flush가 원래 writer까지 전달되지 않았습니다")
+	}
+}
+
+func TestMetricsLabels(t *testing.T) {
+	tests := []struct {
+		pattern string
+		method  string
+		route   string
+		label   string
+	}{
+		{pattern: "GET /api/jobs/{id}", method: "GET", route: "/api/jobs/{id}", label: "GET"},
+		{pattern: "/api/round", method: "POST", route: "/api/round", label: "POST"},
+		{pattern: "", method: "BREW", route: "unmatched", label: "OTHER"},
+	}
+
+	for _, tt := range tests {
+		if got := routeLabel(tt.pattern); got != tt.route {
+			t.Errorf("routeLabel(%q) = %q, 기대값 %q", tt.pattern, got, tt.route)
+		}
+		if got := methodLabel(tt.method); got != tt.label {
+			t.Errorf("methodLabel(%q) = %q, 기대값 %q", tt.method, got, tt.label)
+		}
+	}
+}
+
+func TestMetricsCountsRoute(t *testing.T) {
+	mux := http.NewServeMux()
+	Wrap(mux, Metrics()).HandleFunc("GET /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	counter := httpRequests.With("GET", "/items/{id}", "404")
+	before := counter.Value()
+	for _, path := range []string{"/items/1", "/items/2"} {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if got := counter.Value() - before; got != 2 {
+		t.Errorf("패턴별 요청 수 증가 = %v, 기대값 2", got)
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+const internalErrorMsg = "서버 내부 오류가 발생했습니다"
+
+// 패닉 후 500 응답을 쓰는 함수. id는 요청 ID (없으면 "")
+type ErrorWriter func(w http.ResponseWriter, r *http.Request, id string)
+
+// 미들웨어가 요청을 직접 거절할 때 쓰는 에러 응답 (예: 본문 크기 제한의 413)
+// 등록기에 맞게 WriteJSONError 또는 WriteHTMLError를 넘긴다
+type StatusErrorWriter func(w http.ResponseWriter, r *http.Request, status int, msg string)
+
+// 핸들러의 패닉을 잡아 로그(스택 포함)를 남기고 500으로 응답한다
+// 응답을 이미 쓰기 시작했으면 상태를 바꿀 수 없으므로 연결만 끊는다
+// http.ErrAbortHandler는 의도한 중단이므로 그대로 다시 던진다
+func Recover(logger *slog.Logger, writeError ErrorWriter) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := newResponseWriter(w)
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+
+				id := RequestIDFrom(r.Context())
+				logger.LogAttrs(r.Context(), slog.LevelError, "핸들러 패닉",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.String("request_id", id),
+					slog.String("panic", fmt.Sprint(v)),
+					slog.String("stack", string(debug.Stack())),
+				)
+				if rw.written() {
+					panic(http.ErrAbortHandler)
+				}
+				writeError(rw, r, id)
+			}()
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+type errorResponse struct {
+	Error     string `json:"error"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// API용 500 응답: {"error": ..., "requestId": ...}
+func JSONError(w http.ResponseWriter, r *http.Request, _ string) {
+	WriteJSONError(w, r, http.StatusInternalServerError, internalErrorMsg)
+}
+
+// JSON 에러 응답 {"error": msg, "requestId": ...} (요청 ID 미들웨어를 거쳤으면 ID 포함)
+func WriteJSONError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: msg, RequestID: RequestIDFrom(r.Context())})
+}
+
+var errorPage = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head><meta charset="utf-8"><title>{{if .ServerError}}서버 오류{{else}}요청 오류{{end}}</title></head>
+<body>
+<h1>{{.Error}}</h1>
+{{if .ServerError}}<p>잠시 후 다시 시도해 주세요.</p>{{end}}
+{{if .RequestID}}<p>요청 ID: <code>{{.RequestID}}</code></p>{{end}}
+<p><a href="/">처음으로</a></p>
+</body>
+</html>
+`))
+
+type errorPageData struct {
+	Error       string
+	RequestID   string
+	ServerError bool
+}
+
+// 웹 화면용 500 응답: 요청 ID를 보여주는 간단한 HTML 페이지
+func HTMLError(w http.ResponseWriter, _ *http.Request, id string) {
+	writeHTMLError(w, http.StatusInternalServerError, internalErrorMsg, id)
+}
+
+// 웹 화면용 에러 페이지 (요청 ID 미들웨어를 거쳤으면 ID 포함)
+func WriteHTMLError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	writeHTMLError(w, status, msg, RequestIDFrom(r.Context()))
+}
+
+func writeHTMLError(w http.ResponseWriter, status int, msg, id string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_ = errorPage.Execute(w, errorPageData{Error: msg, RequestID: id, ServerError: status >= 500})
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// 요청 ID를 주고받는 헤더
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLen = 64
+
+type requestIDKey struct{}
+
+// 요청마다 ID를 붙인다
+// 클라이언트(프록시)가 보낸 X-Request-ID가 올바르면 그대로 쓰고, 아니면 새로 만든다
+// ID는 요청 context와 응답 헤더에 넣으므로 에러 응답에도 항상 포함된다
+func RequestID() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+		})
+	}
+}
+
+// context에 담긴 요청 ID. 없으면 ""
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// 로그와 헤더에 그대로 옮겨도 안전한 값만 받는다
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
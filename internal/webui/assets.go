@@ -0,0 +1,211 @@
+package webui
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"embed"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:generate go run fetch_vendor.go
+
+// 바이너리에 포함하는 템플릿과 정적 파일 (/static/으로 제공)
+var (
+	//go:embed templates/*.gohtml
+	embeddedTemplates embed.FS
+
+	//go:embed static
+	embeddedStatic embed.FS
+)
+
+// 외부 CSS/JS. go generate로 static/vendor/에 내려받아 함께 포함한다
+// 파일이 없거나 integrity가 맞지 않으면 핸들러를 만들지 않는다 (CDN 주소는 vendorCDN일 때만)
+type VendorFile struct {
+	Name      string // static/vendor/ 아래 경로
+	URL       string
+	Integrity string // 내려받은 파일 검증과 <link>/<script> integrity 속성에 사용
+}
+
+var VendorFiles = []VendorFile{
+	{
+		Name:      "bootstrap/bootstrap.min.css",
+		URL:       "https://cdn.jsdelivr.net/npm/bootstrap@5.3.3/dist/css/bootstrap.min.css",
+		Integrity: "sha384-QWTKZyjpPEjISv5WaRU9OFeRpok6YctnYmDr5pNlyT2bRjXh0JMhjY6hW+ALEwIH",
+	},
+	{
+		Name:      "bootstrap/bootstrap.bundle.min.js",
+		URL:       "https://cdn.jsdelivr.net/npm/bootstrap@5.3.3/dist/js/bootstrap.bundle.min.js",
+		Integrity: "sha384-YvpcrYf0tY3lHB60NNkmXc5s9fDVZLESaAA55NDzOxhy9GkcIdslK1eN7N6jIeHz",
+	},
+}
+
+const (
+	staticPrefix = "/static/"
+
+	// ?v=내용 해시가 붙은 주소는 내용이 바뀌면 주소도 바뀌므로 오래 캐시
+	immutableCache = "public, max-age=31536000, immutable"
+	defaultCache   = "public, max-age=3600"
+)
+
+// 템플릿과 정적 파일을 읽는 곳
+type assets struct {
+	templates fs.FS
+	static    fs.FS
+	dev       bool              // 디스크에서 읽고 템플릿을 요청마다 다시 파싱
+	cdn       bool              // 내려받지 않은 외부 파일은 CDN 주소로 (명시적으로 켤 때만)
+	versions  map[string]string // 정적 파일 경로 → 내용 해시 (dev면 비어 있음)
+}
+
+// dir가 비어 있으면 바이너리에 포함된 파일, 지정하면 dir/templates와 dir/static (개발용)
+func loadAssets(dir string, vendorCDN bool) (assets, error) {
+	a, err := openAssets(dir)
+	if err != nil {
+		return assets{}, err
+	}
+	a.cdn = vendorCDN
+	if err := a.checkVendor(); err != nil {
+		return assets{}, err
+	}
+	return a, nil
+}
+
+func openAssets(dir string) (assets, error) {
+	if dir != "" {
+		templates := filepath.Join(dir, "templates")
+		if _, err := os.Stat(templates); err != nil {
+			return assets{}, fmt.Errorf("템플릿 디렉터리를 읽을 수 없습니다: %w", err)
+		}
+		return assets{
+			templates: os.DirFS(templates),
+			static:    os.DirFS(filepath.Join(dir, "static")),
+			dev:       true,
+		}, nil
+	}
+
+	templates, err := fs.Sub(embeddedTemplates, "templates")
+	if err != nil {
+		return assets{}, fmt.Errorf("포함된 템플릿을 읽을 수 없습니다: %w", err)
+	}
+	static, err := fs.Sub(embeddedStatic, "static")
+	if err != nil {
+		return assets{}, fmt.Errorf("포함된 정적 파일을 읽을 수 없습니다: %w", err)
+	}
+	versions, err := hashFiles(static)
+	if err != nil {
+		return assets{}, err
+	}
+	return assets{templates: templates, static: static, versions: versions}, nil
+}
+
+// 내려받은 외부 파일이 고정한 integrity와 같은지 확인한다
+// 없는 파일은 CDN을 켰을 때만 허용한다
+func (a assets) checkVendor() error {
+	for _, f := range VendorFiles {
+		data, err := fs.ReadFile(a.static, "vendor/"+f.Name)
+		if errors.Is(err, fs.ErrNotExist) && a.cdn {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("외부 파일 %s를 읽을 수 없습니다 (go generate ./internal/webui로 내려받거나 CDN 사용을 켜 주세요): %w", f.Name, err)
+		}
+		sum := sha512.Sum384(data)
+		if got := "sha384-" + base64.StdEncoding.EncodeToString(sum[:]); got != f.Integrity {
+			return fmt.Errorf("외부 파일 %s의 integrity가 다릅니다: %s, want %s", f.Name, got, f.Integrity)
+		}
+	}
+	return nil
+}
+
+func hashFiles(fsys fs.FS) (map[string]string, error) {
+	versions := make(map[string]string)
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		versions[path] = hex.EncodeToString(sum[:8])
+		return nil
+	})
+	return versions, err
+}
+
+// 정적 파일 주소 (내용 해시를 붙여 캐시를 무효화)
+func (a assets) url(name string) string {
+	if v, ok := a.versions[name]; ok {
+		return staticPrefix + name + "?v=" + v
+	}
+	return staticPrefix + name
+}
+
+func (a assets) vendor(name string) (src string, integrity string) {
+	for _, f := range VendorFiles {
+		if f.Name != name {
+			continue
+		}
+		path := "vendor/" + f.Name
+		if _, err := fs.Stat(a.static, path); err != nil && a.cdn {
+			return f.URL, f.Integrity
+		}
+		return a.url(path), f.Integrity
+	}
+	return a.url(name), ""
+}
+
+func (a assets) funcs() template.FuncMap {
+	return template.FuncMap{
+		"asset": a.url,
+		"stylesheet": func(name string) template.HTML {
+			src, integrity := a.vendor(name)
+			return template.HTML(fmt.Sprintf(
+				`<link href="%s" rel="stylesheet" integrity="%s" crossorigin="anonymous">`,
+				template.HTMLEscapeString(src), integrity,
+			))
+		},
+		"script": func(name string) template.HTML {
+			src, integrity := a.vendor(name)
+			return template.HTML(fmt.Sprintf(
+				`<script src="%s" integrity="%s" crossorigin="anonymous"></script>`,
+				template.HTMLEscapeString(src), integrity,
+			))
+		},
+	}
+}
+
+// GET /static/... 정적 파일. 디렉터리 목록은 보여 주지 않는다
+func (a assets) handler() http.Handler {
+	files := http.StripPrefix(staticPrefix, http.FileServerFS(a.static))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, staticPrefix)
+		if name == "" || strings.HasSuffix(name, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch {
+		case a.dev:
+			w.Header().Set("Cache-Control", "no-cache")
+		case r.URL.Query().Get("v") != "":
+			w.Header().Set("Cache-Control", immutableCache)
+		default:
+			w.Header().Set("Cache-Control", defaultCache)
+		}
+		if v, ok := a.versions[name]; ok {
+			w.Header().Set("ETag", `"`+v+`"`) // 포함된 파일은 수정 시각이 없으므로 ETag로 재검증
+		}
+		files.ServeHTTP(w, r)
+	})
+}
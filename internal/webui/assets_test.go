@@ -0,0 +1,129 @@
+package webui
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestStaticHandler(t *testing.T) {
+	static := fstest.MapFS{"js/app.js": {Data: []byte("console.log(1)")}}
+	versions, err := hashFiles(static)
+	if err != nil {
+		t.Fatal(err)
+	}
+	etag := `"` + versions["js/app.js"] + `"`
+
+	tests := []struct {
+		name        string
+		dev         bool
+		path        string
+		ifNoneMatch string
+		wantStatus  int
+		wantCache   string
+		wantETag    string
+	}{
+		{name: "해시가 붙은 주소는 오래 캐시", path: "/static/js/app.js?v=" + versions["js/app.js"], wantStatus: 200, wantCache: immutableCache, wantETag: etag},
+		{name: "해시 없는 주소는 짧게 캐시", path: "/static/js/app.js", wantStatus: 200, wantCache: defaultCache, wantETag: etag},
+		{name: "ETag가 같으면 304", path: "/static/js/app.js", ifNoneMatch: etag, wantStatus: 304, wantCache: defaultCache, wantETag: etag},
+		{name: "ETag가 다르면 200", path: "/static/js/app.js", ifNoneMatch: `"old"`, wantStatus: 200, wantCache: defaultCache, wantETag: etag},
+		{name: "개발 모드는 매번 재검증", dev: true, path: "/static/js/app.js?v=1", wantStatus: 200, wantCache: "no-cache"},
+		{name: "디렉터리 목록 없음", path: "/static/js/", wantStatus: 404},
+		{name: "정적 경로 자체", path: "/static/", wantStatus: 404},
+		{name: "없는 파일은 캐시하지 않음", path: "/static/js/missing.js", wantStatus: 404},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assets{static: static, versions: versions, dev: tt.dev}
+			if tt.dev {
+				a.versions = nil
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			a.handler().ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("상태 코드 = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.wantCache {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.wantCache)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", got, tt.wantETag)
+			}
+		})
+	}
+}
+
+// 내려받은 외부 파일은 integrity를 확인하고, 없으면 CDN을 켰을 때만 CDN 주소를 쓴다
+func TestLoadAssetsVendor(t *testing.T) {
+	content := []byte("body{}")
+	sum := sha512.Sum384(content)
+	saved := VendorFiles
+	VendorFiles = []VendorFile{{
+		Name:      "lib/lib.min.css",
+		URL:       "https://cdn.example.com/lib.min.css",
+		Integrity: "sha384-" + base64.StdEncoding.EncodeToString(sum[:]),
+	}}
+	t.Cleanup(func() { VendorFiles = saved })
+
+	tests := []struct {
+		name    string
+		file    []byte // nil이면 파일 없음
+		cdn     bool
+		wantErr string
+		wantSrc string
+	}{
+		{name: "포함된 파일 사용", file: content, wantSrc: "/static/vendor/lib/lib.min.css"},
+		{name: "CDN을 켜도 포함된 파일 우선", file: content, cdn: true, wantSrc: "/static/vendor/lib/lib.min.css"},
+		{name: "파일이 없으면 시작하지 않음", wantErr: "go generate"},
+		{name: "파일이 없고 CDN을 켜면 CDN 주소", cdn: true, wantSrc: "https://cdn.example.com/lib.min.css"},
+		{name: "integrity가 다르면 시작하지 않음", file: []byte("body{color:red}"), wantErr: "integrity"},
+		{name: "integrity가 다르면 CDN을 켜도 시작하지 않음", file: []byte("body{color:red}"), cdn: true, wantErr: "integrity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(dir, "templates"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if tt.file != nil {
+				path := filepath.Join(dir, "static", "vendor", "lib", "lib.min.css")
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, tt.file, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			a, err := loadAssets(dir, tt.cdn)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("에러 = %v, want %q 포함", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("에러가 발생했습니다: %v", err)
+			}
+
+			src, integrity := a.vendor("lib/lib.min.css")
+			if src != tt.wantSrc || integrity != VendorFiles[0].Integrity {
+				t.Errorf("vendor() = %q, %q, want %q, %q", src, integrity, tt.wantSrc, VendorFiles[0].Integrity)
+			}
+		})
+	}
+}
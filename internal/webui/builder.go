@@ -1,7 +1,8 @@
 package webui
 
 import (
-	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/meoraeng/lotto_simulator/internal/lotto"
 )
@@ -26,6 +27,7 @@ func buildPurchasePageData(
 	mode lotto.Mode,
 	count int,
 	roundCount int,
+	calendar calendarForm,
 	players []playerTicketsView,
 	totalSales int,
 	errorMsg string,
@@ -34,6 +36,8 @@ func buildPurchasePageData(
 		Mode:       mode,
 		Count:      count,
 		RoundCount: roundCount,
+		Calendar:   calendar,
+		RoundMetas: calendar.drawCalendar().Rounds(roundCount),
 		IndexList:  makeIndexList(count),
 		LottoPrice: lotto.LottoPrice,
 		Players:    players,
@@ -47,10 +51,57 @@ func buildPurchasePageData(
 	return data
 }
 
-func buildPlayerSummaries(players []playerTicketsView, payouts map[string]int) []playerSummary {
-	summaries := make([]playerSummary, 0, len(players))
+// 이번 회차 구매를 플레이어별 누적 구매에 더한다
+func addSpending(spending []playerSpending, players []playerTicketsView) []playerSpending {
+	if spending == nil {
+		spending = make([]playerSpending, len(players))
+	}
+	for i, p := range players {
+		spending[i].Name = p.Name
+		spending[i].Amount += p.Amount
+		spending[i].TicketCount += len(p.Tickets)
+	}
+	return spending
+}
+
+func totalSpent(spending []playerSpending) int {
+	total := 0
+	for _, s := range spending {
+		total += s.Amount
+	}
+	return total
+}
+
+// 같은 회차당 금액으로 다음 회차 구매. keepNumbers면 번호는 그대로 두고 금액만 다시 지불
+func purchaseRound(players []playerTicketsView, keepNumbers bool) ([]playerTicketsView, error) {
+	if keepNumbers {
+		return players, nil
+	}
 
+	next := make([]playerTicketsView, 0, len(players))
 	for _, p := range players {
+		if p.Wheel != "" {
+			next = append(next, p) // 휠은 같은 번호 묶음을 계속 구매
+			continue
+		}
+
+		lottos, err := lotto.PurchaseLottos(p.Amount)
+		if err != nil {
+			return nil, err
+		}
+		next = append(next, playerTicketsView{
+			Name:    p.Name,
+			Amount:  p.Amount,
+			Tickets: lottos.Lottos,
+		})
+	}
+	return next, nil
+}
+
+func buildPlayerSummaries(spending []playerSpending, payouts map[string]int) []playerSummary {
+	summaries := make([]playerSummary, 0, len(spending))
+
+	for _, p := range spending {
 		spent := p.Amount
 		earned := payouts[p.Name]
 
@@ -62,7 +113,7 @@ func buildPlayerSummaries(players []playerTicketsView, payouts map[string]int) [
 		summaries = append(summaries, playerSummary{
 			Name:        p.Name,
 			Amount:      spent,
-			TicketCount: len(p.Tickets),
+			TicketCount: p.TicketCount,
 			Earned:      earned,
 			ProfitRate:  rate,
 		})
@@ -77,20 +128,31 @@ func buildResultData(
 	domainPlayers []lotto.Player,
 ) map[string]any {
 	stats := l.CompileStatisticsParallel()
-	roundIn := buildRoundInputForMode(req.Mode, req.TotalSales, stats)
+	roundIn := req.Game.RoundInput(req.TotalSales, stats, nil)
+	roundIn.Meta = req.roundMeta(0)
+
+	marketTickets := 0
+	if market := req.Demand.market(); market != nil {
+		roundIn, marketTickets = market.AddTo(roundIn, 0)
+	}
 
 	roundOut, _ := lotto.CalculateRound(roundIn)
 	payouts := lotto.DistributeRewardsParallel(domainPlayers, *l, roundOut)
 	rankRows := buildRankRows(req.Mode, stats, roundOut)
-	playerSummaries := buildPlayerSummaries(req.Players, payouts)
+	playerSummaries := buildPlayerSummaries(addSpending(nil, req.Players), payouts)
 
 	data := map[string]any{
 		"Mode":            req.Mode,
+		"Profile":         req.Game,
+		"Meta":            roundIn.Meta,
 		"TotalSales":      req.TotalSales,
+		"MarketTickets":   marketTickets,
+		"RoundSales":      roundIn.Sales,
 		"WinningNumbers":  l.WinningNumbers,
 		"BonusNumber":     l.BonusNumber,
 		"RankRows":        rankRows,
 		"PlayerSummaries": playerSummaries,
+		"Proofs":          req.Proofs,
 	}
 
 	if req.Mode == lotto.ModeParimutuel {
@@ -101,99 +163,6 @@ func buildResultData(
 	return data
 }
 
-func buildRoundInputForMode(
-	mode lotto.Mode,
-	totalSales int,
-	stats map[lotto.Rank]int,
-) lotto.RoundInput {
-	if mode == lotto.ModeFixedPayout {
-		// 고정 상금 모드
-		fixedPayout := map[lotto.Rank]int{
-			lotto.Rank1: lotto.Rank1.Prize(),
-			lotto.Rank2: lotto.Rank2.Prize(),
-			lotto.Rank3: lotto.Rank3.Prize(),
-			lotto.Rank4: lotto.Rank4.Prize(),
-			lotto.Rank5: lotto.Rank5.Prize(),
-		}
-		return lotto.RoundInput{
-			Mode:        mode,
-			Sales:       totalSales,
-			Winners:     stats,
-			FixedPayout: fixedPayout,
-		}
-	}
-
-	// 분배 모드
-	allocations := []lotto.Allocation{
-		{Rank: lotto.Rank1, BasisPoints: 7500},
-		{Rank: lotto.Rank2, BasisPoints: 1250},
-		{Rank: lotto.Rank3, BasisPoints: 1250},
-		{Rank: lotto.Rank4, BasisPoints: 0},
-		{Rank: lotto.Rank5, BasisPoints: 0},
-	}
-
-	caps := map[lotto.Rank]int{
-		lotto.Rank1: 2_000_000_000,
-	}
-
-	return lotto.RoundInput{
-		Mode:           mode,
-		Sales:          totalSales,
-		Winners:        stats,
-		CarryIn:        map[lotto.Rank]int{},
-		Allocations:    allocations,
-		CapPerRank:     caps,
-		RoundingUnit:   100,
-		RollDownMethod: lotto.RollDownProportional,
-	}
-}
-
-func buildRoundInputForModeWithCarry(
-	mode lotto.Mode,
-	totalSales int,
-	stats map[lotto.Rank]int,
-	carryIn map[lotto.Rank]int,
-) lotto.RoundInput {
-	if mode == lotto.ModeFixedPayout {
-		fixedPayout := map[lotto.Rank]int{
-			lotto.Rank1: lotto.Rank1.Prize(),
-			lotto.Rank2: lotto.Rank2.Prize(),
-			lotto.Rank3: lotto.Rank3.Prize(),
-			lotto.Rank4: lotto.Rank4.Prize(),
-			lotto.Rank5: lotto.Rank5.Prize(),
-		}
-		return lotto.RoundInput{
-			Mode:        mode,
-			Sales:       totalSales,
-			Winners:     stats,
-			FixedPayout: fixedPayout,
-		}
-	}
-
-	allocations := []lotto.Allocation{
-		{Rank: lotto.Rank1, BasisPoints: 7500},
-		{Rank: lotto.Rank2, BasisPoints: 1250},
-		{Rank: lotto.Rank3, BasisPoints: 1250},
-		{Rank: lotto.Rank4, BasisPoints: 0},
-		{Rank: lotto.Rank5, BasisPoints: 0},
-	}
-
-	caps := map[lotto.Rank]int{
-		lotto.Rank1: 2_000_000_000,
-	}
-
-	return lotto.RoundInput{
-		Mode:           mode,
-		Sales:          totalSales,
-		Winners:        stats,
-		CarryIn:        carryIn,
-		Allocations:    allocations,
-		CapPerRank:     caps,
-		RoundingUnit:   100,
-		RollDownMethod: lotto.RollDownProportional,
-	}
-}
-
 func buildRankRows(
 	mode lotto.Mode,
 	stats map[lotto.Rank]int,
@@ -280,6 +249,46 @@ func mergePayouts(dst, src map[string]int) {
 	}
 }
 
-func buildPlayerRedirectURL(mode lotto.Mode, count int, roundCount int) string {
-	return fmt.Sprintf("/purchase?mode=%d&count=%d&rounds=%d", mode, count, roundCount)
+func newRoundProgress(result roundResultView) roundProgressView {
+	paid := 0
+	for _, amount := range result.Payouts {
+		paid += amount
+	}
+	return roundProgressView{
+		Round:          result.Round,
+		Label:          result.Meta.Label(),
+		WinningNumbers: result.WinningNumbers,
+		BonusNumber:    result.BonusNumber,
+		Sales:          result.RoundOutput.Sales,
+		Paid:           paid,
+	}
+}
+
+func buildPlayerRedirectURL(
+	mode lotto.Mode,
+	profileName string,
+	count int,
+	roundCount int,
+	calendar calendarForm,
+	demand demandForm,
+	drawSource string,
+) string {
+	q := url.Values{}
+	q.Set("mode", mode.String())
+	if profileName != "" {
+		q.Set("profile", profileName)
+	}
+	q.Set("count", strconv.Itoa(count))
+	q.Set("rounds", strconv.Itoa(roundCount))
+	if calendar.FirstRound > 0 {
+		q.Set("firstRound", strconv.Itoa(calendar.FirstRound))
+	}
+	if calendar.FirstDraw != "" {
+		q.Set("firstDraw", calendar.FirstDraw)
+	}
+	demand.setQuery(q)
+	if drawSource != "" {
+		q.Set("drawSource", drawSource)
+	}
+	return "/purchase?" + q.Encode()
 }
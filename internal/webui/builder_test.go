@@ -0,0 +1,70 @@
+package webui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+)
+
+func TestPurchaseRound(t *testing.T) {
+	manual := []lotto.Lotto{{Numbers: []int{1, 2, 3, 4, 5, 6}}}
+	wheel := []lotto.Lotto{{Numbers: []int{1, 2, 3, 4, 5, 7}}, {Numbers: []int{1, 2, 3, 4, 6, 7}}}
+	players := []playerTicketsView{
+		{Name: "a", Amount: 3_000, Tickets: manual},
+		{Name: "b", Amount: 2_000, Tickets: wheel, Wheel: "full"},
+	}
+
+	kept, err := purchaseRound(players, true)
+	if err != nil {
+		t.Fatalf("구매 중 에러가 발생했습니다. err=%v", err)
+	}
+	if !slices.Equal(kept[0].Tickets[0].Numbers, manual[0].Numbers) || len(kept[0].Tickets) != 1 {
+		t.Errorf("keepNumbers면 번호가 그대로여야 합니다. got=%v", kept[0].Tickets)
+	}
+
+	next, err := purchaseRound(players, false)
+	if err != nil {
+		t.Fatalf("구매 중 에러가 발생했습니다. err=%v", err)
+	}
+	if len(next[0].Tickets) != 3 || next[0].Amount != 3_000 || next[0].Name != "a" {
+		t.Errorf("회차당 금액만큼 새로 사야 합니다. got=%+v", next[0])
+	}
+	if len(next[1].Tickets) != 2 || next[1].Wheel != "full" {
+		t.Errorf("휠 티켓은 같은 번호로 다시 사야 합니다. got=%+v", next[1])
+	}
+}
+
+// 회차마다 더한 구매 금액/장수가 요약의 수익률 계산에 쓰이는지 검증
+func TestBuildPlayerSummaries(t *testing.T) {
+	round := []playerTicketsView{
+		{Name: "a", Amount: 2_000, Tickets: make([]lotto.Lotto, 2)},
+		{Name: "b", Amount: 0},
+	}
+	spending := addSpending(nil, round)
+	spending = addSpending(spending, round)
+
+	summaries := buildPlayerSummaries(spending, map[string]int{"a": 5_000, "b": 1_000})
+
+	tests := []struct {
+		name    string
+		got     playerSummary
+		amount  int
+		tickets int
+		earned  int
+		rate    float64
+	}{
+		{"두 회차 누적", summaries[0], 4_000, 4, 5_000, 125},
+		{"구매 금액 0", summaries[1], 0, 0, 1_000, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.got
+			if s.Amount != tt.amount || s.TicketCount != tt.tickets || s.Earned != tt.earned || s.ProfitRate != tt.rate {
+				t.Errorf("요약이 예상과 다릅니다. got=%+v, want=(%d, %d장, %d, %.0f%%)",
+					s, tt.amount, tt.tickets, tt.earned, tt.rate)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package webui
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+)
+
+// 당첨 번호를 정하는 방식. 비어 있으면 직접 입력(또는 과거 기록 가져오기)
+// API의 drawSource와 같은 이름을 쓴다
+const drawSourceCommitReveal = "commit-reveal"
+
+// 커밋-공개 추첨으로 돌릴 수 있는 최대 회차 수 (구매 페이지를 열 때마다 회차 수만큼 커밋한다)
+const maxCommitRevealRounds = 104
+
+// 구매 페이지를 연 뒤 판매 마감까지의 시간. 결과를 요청하면 그 시점에 바로 마감한다
+const commitRevealSalesWindow = time.Hour
+
+var errCommitRevealDraws = errors.New("커밋-공개 추첨에서는 과거 추첨 결과를 가져올 수 없습니다")
+
+func parseDrawSource(s string) (string, error) {
+	switch s {
+	case "", "manual":
+		return "", nil
+	case drawSourceCommitReveal:
+		return s, nil
+	default:
+		return "", fmt.Errorf("지원하지 않는 추첨 방식입니다: %s", s)
+	}
+}
+
+func validateDrawSource(source string, roundCount int) error {
+	if source == drawSourceCommitReveal && roundCount > maxCommitRevealRounds {
+		return fmt.Errorf("커밋-공개 추첨은 %d회차까지만 진행할 수 있습니다", maxCommitRevealRounds)
+	}
+	return nil
+}
+
+// 폼에 실어 보낸 회차별 추첨 ID (drawId_1, drawId_2, ...)
+func readDrawIDs(r *http.Request, roundCount int) []string {
+	var ids []string
+	for round := 1; round <= roundCount; round++ {
+		id := r.FormValue("drawId_" + strconv.Itoa(round))
+		if id == "" {
+			break
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// 판매 전에 회차마다 추첨을 커밋한다 (커밋값만 화면에 보여 준다)
+func (h *Handler) commitRounds(metas []lotto.RoundMeta) ([]commitreveal.Draw, error) {
+	salesClose := time.Now().Add(commitRevealSalesWindow)
+	draws := make([]commitreveal.Draw, 0, len(metas))
+	for _, meta := range metas {
+		d, err := h.draws.Commit(meta.ID(), salesClose)
+		if err != nil {
+			return nil, err
+		}
+		draws = append(draws, d)
+	}
+	return draws, nil
+}
+
+// 앞서 커밋한 추첨을 다시 찾는다 (구매 페이지를 다시 그릴 때)
+func (h *Handler) committedDraws(ids []string) ([]commitreveal.Draw, error) {
+	draws := make([]commitreveal.Draw, 0, len(ids))
+	for _, id := range ids {
+		d, err := h.draws.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		draws = append(draws, d)
+	}
+	return draws, nil
+}
+
+// 판매를 마감하고 회차마다 시드를 공개해 당첨 번호를 정한다
+// 모든 추첨을 먼저 확인한 뒤 공개해, 중간에 실패해도 일부만 공개되지 않게 한다
+func (h *Handler) revealRounds(req resultRequest) ([]lotto.Draw, []commitreveal.Draw, error) {
+	if req.DrawHistory != "" {
+		return nil, nil, errCommitRevealDraws
+	}
+	if len(req.DrawIDs) != req.RoundCount {
+		return nil, nil, fmt.Errorf("회차마다 커밋된 추첨이 필요합니다 (%d/%d)", len(req.DrawIDs), req.RoundCount)
+	}
+
+	metas := req.Calendar.drawCalendar().Rounds(req.RoundCount)
+	for i, id := range req.DrawIDs {
+		d, err := h.draws.Get(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if d.Revealed {
+			return nil, nil, fmt.Errorf("%w: %s", commitreveal.ErrAlreadyRevealed, id)
+		}
+		if d.Round != metas[i].ID() {
+			return nil, nil, fmt.Errorf("%s 추첨이 아닙니다: %s", metas[i].Label(), id)
+		}
+	}
+
+	draws := make([]lotto.Draw, 0, len(req.DrawIDs))
+	proofs := make([]commitreveal.Draw, 0, len(req.DrawIDs))
+	for i, id := range req.DrawIDs {
+		if _, err := h.draws.CloseSales(id); err != nil {
+			return nil, nil, err
+		}
+		d, err := h.draws.Reveal(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		draws = append(draws, lotto.Draw{
+			Meta:           metas[i],
+			WinningNumbers: d.WinningNumbers,
+			BonusNumber:    d.BonusNumber,
+		})
+		proofs = append(proofs, d)
+	}
+	return draws, proofs, nil
+}
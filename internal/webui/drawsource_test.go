@@ -0,0 +1,71 @@
+package webui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+)
+
+// 결과 요청 때 판매를 마감하고 회차 순서대로 공개하는지, 잘못된 추첨은 하나도 공개하지 않는지 검증
+func TestRevealRounds(t *testing.T) {
+	h := &Handler{draws: commitreveal.NewStore()}
+	req := resultRequest{RoundCount: 2, DrawSource: drawSourceCommitReveal}
+	metas := req.Calendar.drawCalendar().Rounds(2)
+
+	committed, err := h.commitRounds(metas)
+	if err != nil {
+		t.Fatalf("커밋 중 에러가 발생했습니다. err=%v", err)
+	}
+
+	tests := []struct {
+		name string
+		ids  []string
+		hist string
+	}{
+		{name: "회차 수 부족", ids: []string{committed[0].ID}},
+		{name: "회차 순서가 바뀜", ids: []string{committed[1].ID, committed[0].ID}},
+		{name: "없는 추첨", ids: []string{committed[0].ID, "missing"}},
+		{name: "과거 기록과 함께 사용", ids: []string{committed[0].ID, committed[1].ID}, hist: "1,2024-06-01,1,2,3,4,5,6,7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bad := req
+			bad.DrawIDs = tt.ids
+			bad.DrawHistory = tt.hist
+			if _, _, err := h.revealRounds(bad); err == nil {
+				t.Fatal("에러가 발생해야 합니다")
+			}
+			for _, c := range committed {
+				if d, _ := h.draws.Get(c.ID); d.Revealed {
+					t.Fatalf("실패한 요청으로 공개되면 안 됩니다. id=%s", c.ID)
+				}
+			}
+		})
+	}
+
+	req.DrawIDs = []string{committed[0].ID, committed[1].ID}
+	draws, proofs, err := h.revealRounds(req)
+	if err != nil {
+		t.Fatalf("공개 중 에러가 발생했습니다. err=%v", err)
+	}
+	for i, d := range draws {
+		if d.Meta != metas[i] || len(d.WinningNumbers) != 6 || proofs[i].Seed == "" {
+			t.Errorf("%d번째 회차 공개 결과가 올바르지 않습니다. draw=%+v proof=%+v", i, d, proofs[i])
+		}
+		v, err := commitreveal.Verify(commitreveal.VerifyRequest{
+			Round:          proofs[i].Round,
+			Commitment:     committed[i].Commitment,
+			Seed:           proofs[i].Seed,
+			WinningNumbers: d.WinningNumbers,
+			BonusNumber:    d.BonusNumber,
+		})
+		if err != nil || !v.Valid {
+			t.Errorf("판매 전 커밋값으로 검증되어야 합니다. v=%+v err=%v", v, err)
+		}
+	}
+
+	if _, _, err := h.revealRounds(req); !errors.Is(err, commitreveal.ErrAlreadyRevealed) {
+		t.Errorf("두 번 공개하면 ErrAlreadyRevealed여야 합니다. err=%v", err)
+	}
+}
@@ -0,0 +1,54 @@
+//go:build ignore
+
+// static/vendor/에 외부 CSS/JS를 내려받는다 (go generate ./internal/webui)
+// 내려받은 내용이 고정한 integrity 해시와 다르면 저장하지 않는다
+package main
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/meoraeng/lotto_simulator/internal/webui"
+)
+
+func main() {
+	for _, f := range webui.VendorFiles {
+		if err := fetch(f); err != nil {
+			log.Fatalf("%s: %v", f.Name, err)
+		}
+		log.Printf("%s 저장", f.Name)
+	}
+}
+
+func fetch(f webui.VendorFile) error {
+	resp, err := http.Get(f.URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", f.URL, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	sum := sha512.Sum384(data)
+	if got := "sha384-" + base64.StdEncoding.EncodeToString(sum[:]); got != f.Integrity {
+		return fmt.Errorf("integrity 불일치: %s, want %s", got, f.Integrity)
+	}
+
+	path := filepath.Join("static", "vendor", filepath.FromSlash(f.Name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -1,16 +1,35 @@
 package webui
 
 import (
+	"context"
+	"errors"
+	"maps"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
+	"github.com/meoraeng/lotto_simulator/internal/apikey"
+	"github.com/meoraeng/lotto_simulator/internal/job"
 	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/middleware"
 )
 
-func (h *Handler) Register(mux *http.ServeMux) {
+func (h *Handler) Register(mux middleware.Router) {
 	mux.HandleFunc("/", h.handlePlayer)
 	mux.HandleFunc("/purchase", h.handlePurchase)
 	mux.HandleFunc("/result", h.handleResult)
+	mux.HandleFunc("/result/jobs/", h.handleResultJob)
+	mux.HandleFunc("/stats", h.handleStats)
+	mux.Handle(staticPrefix, h.assets.handler())
+}
+
+// 웹 화면 요청의 한도 종류. 결과 계산(여러 회차 포함)과 통계는 큰 시뮬레이션으로 센다
+func QuotaClass(r *http.Request) string {
+	if r.Method == http.MethodPost && (r.URL.Path == "/result" || r.URL.Path == "/stats") {
+		return apikey.ClassHeavy
+	}
+	return apikey.ClassStandard
 }
 
 func (h *Handler) handlePlayer(w http.ResponseWriter, r *http.Request) {
@@ -28,13 +47,16 @@ func handlePlayerGet(w http.ResponseWriter, h *Handler) {
 	data := playersPageData{
 		Mode: lotto.ModeFixedPayout,
 	}
-	_ = h.tmpl.ExecuteTemplate(w, "players.gohtml", data)
+	h.renderPlayersPage(w, data)
+}
+
+func (h *Handler) renderPlayersPage(w http.ResponseWriter, data playersPageData) {
+	data.Profiles = h.profiles.List()
+	h.render(w, "players.gohtml", data)
 }
 
 func handlePlayerPost(w http.ResponseWriter, r *http.Request, h *Handler) {
-	modeStr := r.FormValue("mode")
-	modeInt, _ := strconv.Atoi(modeStr)
-	mode := lotto.Mode(modeInt)
+	mode := parseMode(r.FormValue("mode"))
 
 	countStr := r.FormValue("playerCount")
 	count, err := strconv.Atoi(countStr)
@@ -43,13 +65,49 @@ func handlePlayerPost(w http.ResponseWriter, r *http.Request, h *Handler) {
 		return
 	}
 
+	// 프로필을 고르면 모드는 프로필을 따른다
+	profileName := r.FormValue("profile")
+	if profileName != "" {
+		game, err := h.profiles.Get(profileName)
+		if err != nil {
+			h.renderPlayersPage(w, playersPageData{
+				Mode:        mode,
+				PlayerCount: count,
+				Error:       errorMsg(err),
+			})
+			return
+		}
+		mode = game.Mode
+	}
+
 	roundCountStr := r.FormValue("roundCount")
 	roundCount, _ := strconv.Atoi(roundCountStr)
 	if roundCount <= 0 {
 		roundCount = 1
 	}
 
-	url := buildPlayerRedirectURL(mode, count, roundCount)
+	calendar := readCalendarForm(r)
+	demand := readDemandForm(r)
+	drawSource, sourceErr := parseDrawSource(r.FormValue("drawSource"))
+	if sourceErr == nil {
+		sourceErr = validateDrawSource(drawSource, roundCount)
+	}
+	if err := errors.Join(calendar.validate(), demand.validate(), sourceErr); err != nil {
+		data := playersPageData{
+			Mode:        mode,
+			Profile:     profileName,
+			PlayerCount: count,
+			FirstRound:  calendar.FirstRound,
+			FirstDraw:   calendar.FirstDraw,
+			Demand:      demand,
+			DrawSource:  drawSource,
+			Error:       errorMsg(err),
+		}
+		h.renderPlayersPage(w, data)
+		return
+	}
+
+	url := buildPlayerRedirectURL(mode, profileName, count, roundCount, calendar, demand, drawSource)
 	http.Redirect(w, r, url, http.StatusSeeOther)
 }
 
@@ -66,7 +124,7 @@ func validatePlayerCount(
 			PlayerCount: count,
 			Error:       errorText("플레이어 수는 1 이상 입력해야 합니다"),
 		}
-		_ = h.tmpl.ExecuteTemplate(w, "players.gohtml", data)
+		h.renderPlayersPage(w, data)
 		return false
 	}
 	return true
@@ -90,13 +148,31 @@ func handlePurchaseGet(w http.ResponseWriter, r *http.Request, h *Handler) {
 		return
 	}
 
-	data := buildPurchasePageData(mode, count, roundCount, nil, 0, "")
-	_ = h.tmpl.ExecuteTemplate(w, "purchase.gohtml", data)
+	drawSource, err := parseDrawSource(r.FormValue("drawSource"))
+	if err == nil {
+		err = validateDrawSource(drawSource, roundCount)
+	}
+	if err != nil {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	data := buildPurchasePageData(mode, count, roundCount, readCalendarForm(r), nil, 0, "")
+	data.Profile = r.FormValue("profile")
+	data.Demand = readDemandForm(r)
+	data.DrawSource = drawSource
+
+	// 커밋-공개 추첨: 판매(티켓 발행) 전에 회차별 커밋값을 먼저 보여 준다
+	if data.CommitReveal() {
+		if data.Commitments, err = h.commitRounds(data.RoundMetas); err != nil {
+			data.Error = errorMsg(err)
+		}
+	}
+	h.render(w, "purchase.gohtml", data)
 }
 
 func handlePurchasePost(w http.ResponseWriter, r *http.Request, h *Handler) {
-	modeInt, _ := strconv.Atoi(r.FormValue("mode"))
-	mode := lotto.Mode(modeInt)
+	mode := parseMode(r.FormValue("mode"))
 
 	count, _ := strconv.Atoi(r.FormValue("count"))
 	if count <= 0 {
@@ -104,17 +180,39 @@ func handlePurchasePost(w http.ResponseWriter, r *http.Request, h *Handler) {
 		return
 	}
 
-	_, _, roundCount := readModeAndCountFromQuery(r)
+	roundCount, _ := strconv.Atoi(r.FormValue("rounds"))
+	if roundCount <= 0 {
+		roundCount = 1
+	}
+	calendar := readCalendarForm(r)
 
 	players, totalSales, err := parsePlayersFromForm(r, count)
 	if err != nil {
-		data := buildPurchasePageData(mode, count, roundCount, nil, 0, err.Error())
-		_ = h.tmpl.ExecuteTemplate(w, "purchase.gohtml", data)
+		data := buildPurchasePageData(mode, count, roundCount, calendar, nil, 0, err.Error())
+		h.fillPurchaseForm(r, &data)
+		h.render(w, "purchase.gohtml", data)
+		return
+	}
+
+	data := buildPurchasePageData(mode, count, roundCount, calendar, players, totalSales, "")
+	h.fillPurchaseForm(r, &data)
+	h.render(w, "purchase.gohtml", data)
+}
+
+// 구매 페이지 사이에서 그대로 전달하는 값 (프로필, 수요, 커밋된 추첨)
+func (h *Handler) fillPurchaseForm(r *http.Request, data *purchasePageData) {
+	data.Profile = r.FormValue("profile")
+	data.Demand = readDemandForm(r)
+	data.DrawSource, _ = parseDrawSource(r.FormValue("drawSource"))
+	if !data.CommitReveal() {
 		return
 	}
 
-	data := buildPurchasePageData(mode, count, roundCount, players, totalSales, "")
-	_ = h.tmpl.ExecuteTemplate(w, "purchase.gohtml", data)
+	commitments, err := h.committedDraws(readDrawIDs(r, data.RoundCount))
+	if err != nil && data.Error == "" {
+		data.Error = errorMsg(err)
+	}
+	data.Commitments = commitments
 }
 
 func (h *Handler) handleResult(w http.ResponseWriter, r *http.Request) {
@@ -124,19 +222,45 @@ func (h *Handler) handleResult(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := r.ParseForm(); err != nil {
+		if middleware.IsBodyTooLarge(err) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	req := parseResultRequest(r)
-	domainPlayers := convertToDomainPlayers(req.Players)
+
+	game, err := h.profiles.Resolve(req.Profile, req.Mode)
+	if err != nil {
+		renderPurchasePageWithError(w, h, req, err.Error())
+		return
+	}
+	req.Game = game
+	req.Mode = game.Mode
+
+	draws, err := parseDrawHistory(req.DrawHistory)
+	if err != nil {
+		renderPurchasePageWithError(w, h, req, err.Error())
+		return
+	}
+	req.Draws = draws
+
+	// 커밋-공개 추첨: 결과 요청이 곧 판매 마감. 시드를 공개해 회차별 번호를 정한다
+	if req.DrawSource == drawSourceCommitReveal {
+		if req.Draws, req.Proofs, err = h.revealRounds(req); err != nil {
+			renderPurchasePageWithError(w, h, req, err.Error())
+			return
+		}
+	}
 
 	if req.RoundCount > 1 {
-		handleMultipleRounds(w, r, h, req.Mode, req.TotalSales, req.Players, domainPlayers, req.RoundCount)
+		handleMultipleRounds(w, r, h, req)
 		return
 	}
 
-	handleSingleRound(w, r, h, req, domainPlayers)
+	handleSingleRound(w, r, h, req, convertToDomainPlayers(req.Players))
 }
 
 func handleSingleRound(
@@ -164,6 +288,13 @@ func validateWinningNumbers(
 	req resultRequest,
 	l *lotto.Lottos,
 ) bool {
+	if len(req.Draws) > 0 {
+		// 가져온 과거 기록의 첫 회차 사용
+		l.WinningNumbers = req.Draws[0].WinningNumbers
+		l.BonusNumber = req.Draws[0].BonusNumber
+		return true
+	}
+
 	if err := l.SetWinningNumbers(req.WinningInput); err != nil {
 		renderPurchasePageWithError(w, h, req, err.Error())
 		return false
@@ -185,8 +316,12 @@ func renderPurchasePageWithError(
 ) {
 	data := purchasePageData{
 		Mode:         req.Mode,
+		Profile:      req.Profile,
 		Count:        req.Count,
 		RoundCount:   req.RoundCount,
+		Calendar:     req.Calendar,
+		Demand:       req.Demand,
+		RoundMetas:   req.Calendar.drawCalendar().Rounds(req.RoundCount),
 		IndexList:    makeIndexList(req.Count),
 		LottoPrice:   lotto.LottoPrice,
 		Error:        errorText(errorMsg),
@@ -194,45 +329,83 @@ func renderPurchasePageWithError(
 		TotalSales:   req.TotalSales,
 		WinningInput: req.WinningInput,
 		BonusInput:   req.BonusInput,
+		DrawHistory:  req.DrawHistory,
+		DrawSource:   req.DrawSource,
+	}
+	if data.CommitReveal() {
+		// 공개에 실패했으면 아직 공개되지 않은 추첨을 그대로 다시 보여 준다
+		data.Commitments, _ = h.committedDraws(req.DrawIDs)
 	}
-	_ = h.tmpl.ExecuteTemplate(w, "purchase.gohtml", data)
+	h.render(w, "purchase.gohtml", data)
 }
 
 func renderResultPage(w http.ResponseWriter, h *Handler, data map[string]any) {
-	_ = h.tmpl.ExecuteTemplate(w, "result.gohtml", data)
+	h.render(w, "result.gohtml", data)
 }
 
 // 다중 회차 처리
+// 회차가 많으면 오래 걸리므로 작업으로 돌리고 진행 페이지로 보낸다
 func handleMultipleRounds(
 	w http.ResponseWriter,
 	r *http.Request,
 	h *Handler,
-	mode lotto.Mode,
-	totalSales int,
-	players []playerTicketsView,
-	domainPlayers []lotto.Player,
-	roundCount int,
+	req resultRequest,
 ) {
+	form := maps.Clone(r.Form) // 요청이 끝난 뒤에도 작업이 읽는다
+
+	j, err := h.jobs.Start("web-rounds", func(ctx context.Context, progress *job.Progress) (any, error) {
+		return runMultipleRounds(ctx, progress, req, form)
+	})
+	if err != nil {
+		// 대기열이 가득 찼거나 서버 종료 중
+		renderPurchasePageWithError(w, h, req, err.Error())
+		return
+	}
+	http.Redirect(w, r, resultJobPrefix+j.ID(), http.StatusSeeOther)
+}
+
+// 플레이어는 회차마다 같은 금액으로 다시 구매한다 (KeepNumbers면 같은 번호로)
+// 결과는 result_multi.gohtml 데이터
+func runMultipleRounds(
+	ctx context.Context,
+	progress *job.Progress,
+	req resultRequest,
+	form url.Values,
+) (map[string]any, error) {
+	mode := req.Mode
+	players := req.Players
+	roundCount := req.RoundCount
+
 	roundResults := make([]roundResultView, 0, roundCount)
 	carry := make(map[lotto.Rank]int)
 	totalPayouts := make(map[string]int)
+	market := req.Demand.market()
 
-	allTickets := flattenTickets(players)
+	var spending []playerSpending
 
+	progress.SetTotal(roundCount)
 	for round := 1; round <= roundCount; round++ {
-		result := processRound(
-			r,
-			round,
-			allTickets,
-			mode,
-			totalSales,
-			domainPlayers,
-			carry,
-		)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if round > 1 {
+			next, err := purchaseRound(players, req.KeepNumbers)
+			if err != nil {
+				return nil, err
+			}
+			players = next
+		}
+
+		result := processRound(form, req, round, players, carry, market)
 		if result == nil {
+			progress.Advance("round", nil)
 			continue
 		}
 
+		// 실제로 진행한 회차의 구매만 사용 금액에 포함
+		spending = addSpending(spending, players)
+
 		// 누적 수령액에 합산
 		mergePayouts(totalPayouts, result.Payouts)
 
@@ -240,38 +413,52 @@ func handleMultipleRounds(
 		carry = result.RoundOutput.CarryOut
 
 		roundResults = append(roundResults, *result)
+		progress.Advance("round", newRoundProgress(*result))
 	}
 
 	// 플레이어별 누적 요약
-	playerSummaries := buildPlayerSummaries(players, totalPayouts)
+	playerSummaries := buildPlayerSummaries(spending, totalPayouts)
 
 	data := map[string]any{
 		"Mode":            mode,
-		"TotalSales":      totalSales,
+		"Profile":         req.Game,
+		"KeepNumbers":     req.KeepNumbers,
+		"TotalSales":      totalSpent(spending),
 		"RoundCount":      roundCount,
 		"RoundResults":    roundResults,
 		"PlayerSummaries": playerSummaries,
+		"Proofs":          req.Proofs,
 	}
-
-	_ = h.tmpl.ExecuteTemplate(w, "result_multi.gohtml", data)
+	return data, nil
 }
 
 func processRound(
-	r *http.Request,
+	form url.Values,
+	req resultRequest,
 	round int,
-	allTickets []lotto.Lotto,
-	mode lotto.Mode,
-	totalSales int,
-	domainPlayers []lotto.Player,
+	players []playerTicketsView,
 	carry map[lotto.Rank]int,
+	market *lotto.Market,
 ) *roundResultView {
-	winning, ok := parseWinningNumbersForRound(r, round, allTickets)
+	mode := req.Mode
+	totalSales := totalSpent(addSpending(nil, players))
+	domainPlayers := convertToDomainPlayers(players)
+
+	winning, ok := parseWinningNumbersForRound(form, req, round, flattenTickets(players))
 	if !ok {
 		return nil
 	}
+	meta := req.roundMeta(round - 1)
 
 	stats := winning.CompileStatisticsParallel()
-	roundIn := buildRoundInputForModeWithCarry(mode, totalSales, stats, carry)
+	roundIn := req.Game.RoundInput(totalSales, stats, carry)
+	roundIn.Meta = meta
+
+	// 일반 구매자 판매분: 직전 회차 이월이 클수록 판매량 증가
+	marketTickets := 0
+	if market != nil {
+		roundIn, marketTickets = market.AddTo(roundIn, round-1)
+	}
 
 	roundOut, err := lotto.CalculateRound(roundIn)
 	if err != nil {
@@ -284,6 +471,9 @@ func processRound(
 
 	return &roundResultView{
 		Round:          round,
+		Meta:           meta,
+		MarketTickets:  marketTickets,
+		Players:        players,
 		WinningNumbers: winning.WinningNumbers,
 		BonusNumber:    winning.BonusNumber,
 		Stats:          stats,
@@ -294,3 +484,31 @@ func processRound(
 		Payouts:        payouts,
 	}
 }
+
+const resultJobPrefix = "/result/jobs/"
+
+// 다중 회차 작업 결과 페이지. 아직 돌고 있으면 진행 페이지 (끝나면 스스로 새로고침)
+func (h *Handler) handleResultJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	j, err := h.jobs.Get(strings.TrimPrefix(r.URL.Path, resultJobPrefix))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	status := j.Status()
+	if data, ok := j.Result().(map[string]any); ok && status.State == job.StateDone {
+		h.render(w, "result_multi.gohtml", data)
+		return
+	}
+
+	h.render(w, "result_progress.gohtml", resultProgressData{
+		Job:    status,
+		Events: "/api/jobs/" + j.ID() + "/events",
+		Cancel: "/api/jobs/" + j.ID(),
+	})
+}
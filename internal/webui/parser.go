@@ -2,17 +2,29 @@ package webui
 
 import (
 	"fmt"
+	"math/rand"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/history"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/wheel"
 )
 
+// 폼/쿼리의 모드 이름 (예전 링크의 숫자 값도 허용, 잘못된 값은 고정 상금 모드)
+func parseMode(s string) lotto.Mode {
+	var mode lotto.Mode
+	if err := mode.UnmarshalText([]byte(s)); err != nil {
+		return lotto.ModeFixedPayout
+	}
+	return mode
+}
+
 func readModeAndCountFromQuery(r *http.Request) (lotto.Mode, int, int) {
-	modeStr := r.URL.Query().Get("mode")
-	modeInt, _ := strconv.Atoi(modeStr)
-	mode := lotto.Mode(modeInt)
+	mode := parseMode(r.URL.Query().Get("mode"))
 
 	countStr := r.URL.Query().Get("count")
 	count, _ := strconv.Atoi(countStr)
@@ -26,9 +38,81 @@ func readModeAndCountFromQuery(r *http.Request) (lotto.Mode, int, int) {
 	return mode, count, roundCount
 }
 
+func readCalendarForm(r *http.Request) calendarForm {
+	firstRound, _ := strconv.Atoi(r.FormValue("firstRound"))
+	return calendarForm{
+		FirstRound: firstRound,
+		FirstDraw:  strings.TrimSpace(r.FormValue("firstDraw")),
+	}
+}
+
+func readDemandForm(r *http.Request) demandForm {
+	tickets, _ := strconv.Atoi(r.FormValue("marketTickets"))
+	elasticity, _ := strconv.ParseFloat(r.FormValue("elasticity"), 64)
+	noise, _ := strconv.ParseFloat(r.FormValue("noise"), 64)
+	return demandForm{
+		MarketTickets: tickets,
+		Elasticity:    elasticity,
+		Noise:         noise,
+	}
+}
+
+func (d demandForm) validate() error {
+	if d.MarketTickets < 0 {
+		return fmt.Errorf("일반 구매 티켓 수는 0 이상이어야 합니다")
+	}
+	if d.Noise < 0 || d.Noise > 1 {
+		return fmt.Errorf("판매량 변동 비율은 0~1 사이여야 합니다")
+	}
+	return nil
+}
+
+// 일반 구매자가 없으면 nil
+func (d demandForm) market() *lotto.Market {
+	if d.MarketTickets <= 0 {
+		return nil
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &lotto.Market{
+		Demand: lotto.JackpotDemand{
+			Base:       d.MarketTickets,
+			Elasticity: d.Elasticity,
+			Noise:      d.Noise,
+			Rng:        rng,
+		},
+		Rng: rng,
+	}
+}
+
+func (d demandForm) setQuery(q url.Values) {
+	if d.MarketTickets <= 0 {
+		return
+	}
+	q.Set("marketTickets", strconv.Itoa(d.MarketTickets))
+	q.Set("elasticity", strconv.FormatFloat(d.Elasticity, 'f', -1, 64))
+	q.Set("noise", strconv.FormatFloat(d.Noise, 'f', -1, 64))
+}
+
+func (c calendarForm) validate() error {
+	if c.FirstDraw == "" {
+		return nil
+	}
+	_, err := lotto.ParseDrawDate(c.FirstDraw)
+	return err
+}
+
+// 입력이 없거나 잘못되었으면 1회차부터, 날짜 없이
+func (c calendarForm) drawCalendar() lotto.DrawCalendar {
+	first := c.FirstRound
+	if first <= 0 {
+		first = 1
+	}
+	drawAt, _ := lotto.ParseDrawDate(c.FirstDraw)
+	return lotto.WeeklyCalendar(lotto.DefaultGameID, first, drawAt)
+}
+
 func parseResultRequest(r *http.Request) resultRequest {
-	modeInt, _ := strconv.Atoi(r.FormValue("mode"))
-	mode := lotto.Mode(modeInt)
+	mode := parseMode(r.FormValue("mode"))
 
 	count, _ := strconv.Atoi(r.FormValue("count"))
 	totalSales, _ := strconv.Atoi(r.FormValue("totalSales"))
@@ -41,18 +125,46 @@ func parseResultRequest(r *http.Request) resultRequest {
 
 	winningInput := r.FormValue("winningNumbers")
 	bonusInput := r.FormValue("bonusNumber")
+	drawHistory := strings.TrimSpace(r.FormValue("drawHistory"))
 
 	return resultRequest{
 		Mode:         mode,
+		Profile:      r.FormValue("profile"),
 		Count:        count,
 		TotalSales:   totalSales,
 		RoundCount:   roundCount,
+		Calendar:     readCalendarForm(r),
+		Demand:       readDemandForm(r),
 		Players:      players,
 		WinningInput: winningInput,
 		BonusInput:   bonusInput,
+		KeepNumbers:  r.FormValue("keepNumbers") == "on",
+		DrawHistory:  drawHistory,
+		DrawSource:   r.FormValue("drawSource"),
+		DrawIDs:      readDrawIDs(r, roundCount),
 	}
 }
 
+// 붙여 넣은 과거 추첨 결과를 기존 번호 규칙으로 검증해 가져온다
+func parseDrawHistory(text string) ([]lotto.Draw, error) {
+	if text == "" {
+		return nil, nil
+	}
+	records, err := history.Read(strings.NewReader(text), lotto.DefaultGameID)
+	if err != nil {
+		return nil, fmt.Errorf("과거 추첨 결과: %w", err)
+	}
+	return history.Draws(records), nil
+}
+
+// 가져온 추첨 결과가 있으면 그 회차 정보를, 없으면 입력한 달력 기준
+func (req resultRequest) roundMeta(idx int) lotto.RoundMeta {
+	if idx < len(req.Draws) {
+		return req.Draws[idx].Meta
+	}
+	return req.Calendar.drawCalendar().Round(idx)
+}
+
 func convertToDomainPlayers(players []playerTicketsView) []lotto.Player {
 	domainPlayers := make([]lotto.Player, 0, len(players))
 	for _, p := range players {
@@ -91,6 +203,10 @@ func parseSinglePlayerFromForm(r *http.Request, index int) (playerTicketsView, e
 		return playerTicketsView{}, fmt.Errorf("이름은 비울 수 없습니다")
 	}
 
+	if method := r.FormValue("wheel" + idx); method != "" {
+		return parseWheelPlayerFromForm(r, idx, name, method, amount)
+	}
+
 	lottos, err := lotto.PurchaseLottos(amount)
 	if err != nil {
 		return playerTicketsView{}, err
@@ -103,6 +219,45 @@ func parseSinglePlayerFromForm(r *http.Request, index int) (playerTicketsView, e
 	}, nil
 }
 
+// 휠 번호로 티켓 생성. 구매 금액은 만들어진 티켓 수로 정한다 (balanced는 입력 금액만큼)
+func parseWheelPlayerFromForm(r *http.Request, idx, name, method string, amount int) (playerTicketsView, error) {
+	numbers, err := parseIntList(r.FormValue("wheelNumbers" + idx))
+	if err != nil {
+		return playerTicketsView{}, fmt.Errorf("%s: 휠 번호를 쉼표로 구분해 입력해 주세요", name)
+	}
+
+	req := wheel.Request{Method: method, Numbers: numbers, Tickets: amount / lotto.LottoPrice}
+	if method == "abbreviated" {
+		if req.Guarantee, err = wheel.ParseGuarantee(r.FormValue("guarantee" + idx)); err != nil {
+			return playerTicketsView{}, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	tickets, err := wheel.Generate(req)
+	if err != nil {
+		return playerTicketsView{}, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return playerTicketsView{
+		Name:    name,
+		Amount:  len(tickets) * lotto.LottoPrice,
+		Tickets: tickets,
+		Wheel:   method,
+	}, nil
+}
+
+func parseIntList(input string) ([]int, error) {
+	var numbers []int
+	for _, part := range strings.Split(input, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
 func rebuildPlayersFromForm(r *http.Request, count int) []playerTicketsView {
 	players := make([]playerTicketsView, 0, count)
 
@@ -125,6 +280,7 @@ func rebuildPlayerFromForm(r *http.Request, idx string) playerTicketsView {
 		Name:    name,
 		Amount:  amount,
 		Tickets: tickets,
+		Wheel:   r.FormValue("wheel" + idx),
 	}
 }
 
@@ -175,15 +331,20 @@ func parseNumbersFromParts(parts []string) []int {
 }
 
 func parseWinningNumbersForRound(
-	r *http.Request,
+	form url.Values,
+	req resultRequest,
 	round int,
 	allTickets []lotto.Lotto,
 ) (lotto.Lottos, bool) {
+	if round-1 < len(req.Draws) {
+		return req.Draws[round-1].Lottos(allTickets), true
+	}
+
 	winningKey := fmt.Sprintf("winningNumbers_%d", round)
 	bonusKey := fmt.Sprintf("bonusNumber_%d", round)
 
-	winningInput := r.FormValue(winningKey)
-	bonusInput := r.FormValue(bonusKey)
+	winningInput := form.Get(winningKey)
+	bonusInput := form.Get(bonusKey)
 
 	if winningInput == "" || bonusInput == "" {
 		return lotto.Lottos{}, false
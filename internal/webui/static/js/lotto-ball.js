@@ -0,0 +1,13 @@
+// 번호 구간에 따라 공 색상 적용 (class="lotto-ball" data-num="번호")
+document.addEventListener("DOMContentLoaded", function () {
+    document.querySelectorAll(".lotto-ball").forEach(function (el) {
+        var n = parseInt(el.dataset.num, 10);
+        var cls = "lotto-range-1";
+        if (n >= 1 && n <= 10) cls = "lotto-range-1";
+        else if (n >= 11 && n <= 20) cls = "lotto-range-2";
+        else if (n >= 21 && n <= 30) cls = "lotto-range-3";
+        else if (n >= 31 && n <= 40) cls = "lotto-range-4";
+        else if (n >= 41 && n <= 45) cls = "lotto-range-5";
+        el.classList.add(cls);
+    });
+});
@@ -0,0 +1,88 @@
+package webui
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/drawstats"
+)
+
+// 난수 추첨으로 통계를 볼 때의 최대 회차 수
+const maxRandomDraws = 100_000
+
+type statsPageData struct {
+	DrawHistory  string
+	RandomDraws  int
+	Seed         int64
+	IncludeBonus bool
+	Source       string
+	Report       *drawstats.Report
+	Error        string
+}
+
+func (h *Handler) handleStats(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.render(w, "stats.gohtml", statsPageData{RandomDraws: 1000})
+	case http.MethodPost:
+		handleStatsPost(w, r, h)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func handleStatsPost(w http.ResponseWriter, r *http.Request, h *Handler) {
+	data := statsPageData{
+		DrawHistory:  strings.TrimSpace(r.FormValue("drawHistory")),
+		IncludeBonus: r.FormValue("includeBonus") == "on",
+	}
+	data.RandomDraws, _ = strconv.Atoi(r.FormValue("randomDraws"))
+	data.Seed, _ = strconv.ParseInt(r.FormValue("seed"), 10, 64)
+
+	draws, err := readStatsDraws(&data)
+	if err == nil {
+		var report drawstats.Report
+		report, err = drawstats.Analyze(draws, drawstats.Options{IncludeBonus: data.IncludeBonus})
+		data.Report = &report
+	}
+	if err != nil {
+		data.Report = nil
+		data.Error = errorMsg(err)
+	}
+	h.render(w, "stats.gohtml", data)
+}
+
+// 붙여 넣은 기록이 있으면 그 기록을, 없으면 난수 추첨을 사용 (시드가 0이면 현재 시각)
+func readStatsDraws(data *statsPageData) ([]lotto.Lottos, error) {
+	if data.DrawHistory != "" {
+		imported, err := parseDrawHistory(data.DrawHistory)
+		if err != nil {
+			return nil, err
+		}
+		draws := make([]lotto.Lottos, 0, len(imported))
+		for _, d := range imported {
+			draws = append(draws, d.Lottos(nil))
+		}
+		data.Source = "붙여 넣은 기록"
+		return draws, nil
+	}
+
+	if data.RandomDraws <= 0 || data.RandomDraws > maxRandomDraws {
+		return nil, errors.New("난수 추첨 회차 수는 1~" + strconv.Itoa(maxRandomDraws) + " 사이여야 합니다")
+	}
+	if data.Seed == 0 {
+		data.Seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(data.Seed))
+	draws := make([]lotto.Lottos, 0, data.RandomDraws)
+	for i := range data.RandomDraws {
+		draws = append(draws, lotto.RandomDraw(rng, lotto.RoundMeta{Sequence: i + 1}).Lottos(nil))
+	}
+	data.Source = "난수 추첨 (시드 " + strconv.FormatInt(data.Seed, 10) + ")"
+	return draws, nil
+}
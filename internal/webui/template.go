@@ -2,14 +2,34 @@ package webui
 
 import (
 	"html/template"
-	"path/filepath"
+	"maps"
+	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/meoraeng/lotto_simulator/internal/formatter"
+	"github.com/meoraeng/lotto_simulator/internal/job"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
 )
 
-func NewHandler(templatesDir string) (*Handler, error) {
+// assetsDir가 비어 있으면 바이너리에 포함된 템플릿/정적 파일을 쓴다
+// 지정하면 assetsDir/templates, assetsDir/static을 디스크에서 읽고 템플릿은 요청마다 다시 파싱한다 (개발용)
+// vendorCDN이면 static/vendor/에 내려받지 않은 외부 CSS/JS를 CDN에서 불러온다
+func NewHandler(assetsDir string, vendorCDN bool, profiles *profile.Registry, jobs *job.Manager) (*Handler, error) {
+	a, err := loadAssets(assetsDir, vendorCDN)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Handler{assets: a, profiles: profiles, jobs: jobs, draws: commitreveal.NewStore()}
+	if h.tmpl, err = h.parseTemplates(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *Handler) parseTemplates() (*template.Template, error) {
 	funcMap := template.FuncMap{
 		"add1": func(i int) int {
 			return i + 1
@@ -25,6 +45,24 @@ func NewHandler(templatesDir string) (*Handler, error) {
 			return strings.Join(parts, sep)
 		},
 		"money": formatter.Money,
+		"percent": func(count, total int) float64 {
+			if total == 0 {
+				return 0
+			}
+			return float64(count) / float64(total) * 100
+		},
+		"mul100": func(v float64) float64 {
+			return v * 100
+		},
+		"freqRatio": func(count int, expected float64) float64 {
+			if expected == 0 {
+				return 0
+			}
+			return float64(count) / expected / 2 // 기대값의 2배를 막대 전체 폭으로
+		},
+		"sub": func(a, b int) int {
+			return a - b
+		},
 		"seq": func(start, end int) []int {
 			if start > end {
 				return []int{}
@@ -37,11 +75,21 @@ func NewHandler(templatesDir string) (*Handler, error) {
 		},
 	}
 
-	pattern := filepath.Join(templatesDir, "*.gohtml")
-	tmpl, err := template.New("root").Funcs(funcMap).ParseGlob(pattern)
-	if err != nil {
-		return nil, err
-	}
+	maps.Copy(funcMap, h.assets.funcs())
 
-	return &Handler{tmpl: tmpl}, nil
+	return template.New("root").Funcs(funcMap).ParseFS(h.assets.templates, "*.gohtml")
+}
+
+// 개발 모드면 디스크의 템플릿을 다시 읽어 바로 반영
+func (h *Handler) render(w http.ResponseWriter, name string, data any) {
+	tmpl := h.tmpl
+	if h.assets.dev {
+		t, err := h.parseTemplates()
+		if err != nil {
+			http.Error(w, errorMsg(err), http.StatusInternalServerError)
+			return
+		}
+		tmpl = t
+	}
+	_ = tmpl.ExecuteTemplate(w, name, data)
 }
@@ -3,32 +3,67 @@ package webui
 import (
 	"html/template"
 
+	"github.com/meoraeng/lotto_simulator/internal/job"
 	"github.com/meoraeng/lotto_simulator/internal/lotto"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/commitreveal"
+	"github.com/meoraeng/lotto_simulator/internal/lotto/profile"
 )
 
 type Handler struct {
-	tmpl *template.Template
+	tmpl     *template.Template
+	assets   assets
+	profiles *profile.Registry
+	jobs     *job.Manager        // 다중 회차 시뮬레이션 작업
+	draws    *commitreveal.Store // 커밋-공개 추첨 (판매 전 커밋, 결과 요청 때 공개)
 }
 
 type playersPageData struct {
 	Mode        lotto.Mode
+	Profiles    []profile.Profile // 선택 가능한 게임 규칙 프로필
+	Profile     string            // 비어 있으면 모드별 기본 프로필
 	PlayerCount int
+	FirstRound  int
+	FirstDraw   string
+	Demand      demandForm
+	DrawSource  string // 비어 있으면 당첨 번호 직접 입력
 	Error       string
 }
 
+// 첫 회차 번호/추첨일 입력값 (페이지 사이에서 그대로 전달)
+type calendarForm struct {
+	FirstRound int
+	FirstDraw  string // YYYY-MM-DD
+}
+
+// 일반 구매자 수요 입력값 (페이지 사이에서 그대로 전달)
+// MarketTickets가 0이면 플레이어 구매분만으로 판매액 계산
+type demandForm struct {
+	MarketTickets int     // 이월이 없을 때의 회차당 일반 구매 티켓 수
+	Elasticity    float64 // 광고 1등 금액에 대한 판매 탄력성
+	Noise         float64 // 회차별 판매량 무작위 변동 비율
+}
+
 type playerTicketsView struct {
 	Name    string
 	Amount  int
 	Tickets []lotto.Lotto
+	Wheel   string // 휠 방식 (비어 있으면 자동 번호). 휠 티켓은 회차마다 같은 번호로 다시 구매
 }
 
 type purchasePageData struct {
 	Mode       lotto.Mode
+	Profile    string
 	Count      int
 	RoundCount int
+	Calendar   calendarForm
+	Demand     demandForm
+	RoundMetas []lotto.RoundMeta
 	IndexList  []int
-	LottoPrice int
-	Error      string
+	DrawSource string
+	// 커밋-공개 추첨이면 회차별로 판매 전에 커밋한 추첨 (커밋값만 채워져 있다)
+	Commitments []commitreveal.Draw
+	LottoPrice  int
+	Error       string
 
 	Players    []playerTicketsView
 	TotalSales int
@@ -36,6 +71,11 @@ type purchasePageData struct {
 	// invalid 값을 입력받은 경우 input창 유지를 위한 필드
 	WinningInput string
 	BonusInput   string
+	DrawHistory  string
+}
+
+func (d purchasePageData) CommitReveal() bool {
+	return d.DrawSource == drawSourceCommitReveal
 }
 
 type rankRowView struct {
@@ -56,6 +96,13 @@ type detailRowView struct {
 	Carry      int
 }
 
+// 플레이어별 누적 구매 (회차마다 다시 사므로 회차 수만큼 쌓인다)
+type playerSpending struct {
+	Name        string
+	Amount      int
+	TicketCount int
+}
+
 type playerSummary struct {
 	Name        string
 	Amount      int
@@ -66,16 +113,29 @@ type playerSummary struct {
 
 type resultRequest struct {
 	Mode         lotto.Mode
+	Profile      string
+	Game         profile.Profile // Profile(비어 있으면 모드 기본값)을 찾은 결과
 	Count        int
 	TotalSales   int
 	RoundCount   int
+	Calendar     calendarForm
+	Demand       demandForm
 	Players      []playerTicketsView
 	WinningInput string
 	BonusInput   string
+	KeepNumbers  bool         // 다중 회차에서 첫 회차 번호를 매 회차 다시 구매
+	DrawHistory  string       // 붙여 넣은 과거 추첨 결과 (CSV/JSON)
+	Draws        []lotto.Draw // DrawHistory를 가져온 결과 (커밋-공개 추첨이면 공개한 번호)
+	DrawSource   string
+	DrawIDs      []string            // 회차별 커밋-공개 추첨 ID
+	Proofs       []commitreveal.Draw // 공개한 추첨 (시드와 커밋값)
 }
 
 type roundResultView struct {
 	Round          int
+	Meta           lotto.RoundMeta
+	MarketTickets  int                 // 이번 회차 일반 구매 티켓 수
+	Players        []playerTicketsView // 이번 회차에 플레이어들이 구매한 티켓
 	WinningNumbers []int
 	BonusNumber    int
 	Stats          map[lotto.Rank]int
@@ -85,3 +145,20 @@ type roundResultView struct {
 	DetailRows     []detailRowView
 	Payouts        map[string]int
 }
+
+// 다중 회차 작업 진행 페이지
+type resultProgressData struct {
+	Job    job.Status
+	Events string // 진행 이벤트 스트림 경로
+	Cancel string // 취소(DELETE) 경로
+}
+
+// 회차 하나를 마쳤을 때 진행 페이지로 보내는 요약
+type roundProgressView struct {
+	Round          int    `json:"round"`
+	Label          string `json:"label"`
+	WinningNumbers []int  `json:"winningNumbers"`
+	BonusNumber    int    `json:"bonusNumber"`
+	Sales          int    `json:"sales"`
+	Paid           int    `json:"paid"` // 플레이어 수령액 합계
+}